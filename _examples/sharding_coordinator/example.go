@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/disgoorg/disgo"
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/sharding"
+)
+
+var (
+	token  = os.Getenv("disgo_token")
+	secret = os.Getenv("disgo_coordinator_secret")
+
+	serve          = flag.Bool("serve", false, "run the coordinator server instead of a bot")
+	addr           = flag.String("addr", ":8080", "address of the coordinator server")
+	coordinatorURL = flag.String("coordinator", "http://localhost:8080", "url of the coordinator server")
+)
+
+func main() {
+	flag.Parse()
+
+	if *serve {
+		slog.Info("starting coordinator server", slog.String("addr", *addr))
+		if err := http.ListenAndServe(*addr, sharding.NewCoordinatorHandler(sharding.NewMemoryCoordinator(), secret)); err != nil {
+			slog.Error("error while running coordinator server", slog.Any("err", err))
+		}
+		return
+	}
+
+	slog.Info("starting example...")
+	slog.Info("disgo version", slog.Any("version", disgo.Version))
+
+	// every process can be started with the same config, the coordinator makes sure every shard is only connected once
+	client, err := disgo.New(token,
+		bot.WithShardManagerConfigOpts(
+			sharding.WithCoordinator(sharding.NewHTTPCoordinator(*coordinatorURL, secret, nil)),
+			sharding.WithGatewayConfigOpts(
+				gateway.WithIntents(gateway.IntentGuilds),
+			),
+		),
+	)
+	if err != nil {
+		slog.Error("error while building disgo", slog.Any("err", err))
+		return
+	}
+
+	defer client.Close(context.TODO())
+
+	if err = client.OpenShardManager(context.TODO()); err != nil {
+		slog.Error("error while connecting to gateway", slog.Any("err", err))
+		return
+	}
+
+	slog.Info("example is now running. Press CTRL-C to exit.")
+	s := make(chan os.Signal, 1)
+	signal.Notify(s, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
+	<-s
+}
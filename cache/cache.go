@@ -62,15 +62,21 @@ func (c *DefaultCache[T]) Get(id snowflake.ID) (T, bool) {
 }
 
 func (c *DefaultCache[T]) Put(id snowflake.ID, entity T) {
+	c.swap(id, entity)
+}
+
+func (c *DefaultCache[T]) swap(id snowflake.ID, entity T) (old T, existed bool, stored bool) {
 	if c.flags.Missing(c.neededFlags) {
-		return
+		return old, false, false
 	}
 	if c.policy != nil && !c.policy(entity) {
-		return
+		return old, false, false
 	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	old, existed = c.cache[id]
 	c.cache[id] = entity
+	return old, existed, true
 }
 
 func (c *DefaultCache[T]) Remove(id snowflake.ID) (T, bool) {
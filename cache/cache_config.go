@@ -27,6 +27,7 @@ func DefaultConfig() *Config {
 // Config lets you configure your Caches instance.
 type Config struct {
 	CacheFlags Flags
+	Indexes    Indexes
 
 	SelfUserCache SelfUserCache
 
@@ -57,14 +58,19 @@ type Config struct {
 	VoiceStateCache       VoiceStateCache
 	VoiceStateCachePolicy Policy[discord.VoiceState]
 
-	MessageCache       MessageCache
-	MessageCachePolicy Policy[discord.Message]
+	MessageCache          MessageCache
+	MessageCachePolicy    Policy[discord.Message]
+	MessageCacheEviction  []EvictionConfigOpt
+	MessageCacheEvictFunc EvictFunc[discord.Message]
 
 	EmojiCache       EmojiCache
 	EmojiCachePolicy Policy[discord.Emoji]
 
 	StickerCache       StickerCache
 	StickerCachePolicy Policy[discord.Sticker]
+
+	// observables are the ObservableCache(s) & ObservableGroupedCache(s) of the default caches by their Flags
+	observables map[Flags]any
 }
 
 // ConfigOpt is a type alias for a function that takes a Config and is used to configure your Caches.
@@ -79,41 +85,71 @@ func (c *Config) Apply(opts []ConfigOpt) {
 		c.SelfUserCache = NewSelfUserCache()
 	}
 	if c.GuildCache == nil {
-		c.GuildCache = NewGuildCache(NewCache[discord.Guild](c.CacheFlags, FlagGuilds, c.GuildCachePolicy), NewSet[snowflake.ID](), NewSet[snowflake.ID]())
+		c.GuildCache = NewGuildCache(observeCache(c, FlagGuilds, NewCache[discord.Guild](c.CacheFlags, FlagGuilds, c.GuildCachePolicy)), NewSet[snowflake.ID](), NewSet[snowflake.ID]())
 	}
 	if c.ChannelCache == nil {
-		c.ChannelCache = NewChannelCache(NewCache[discord.GuildChannel](c.CacheFlags, FlagChannels, c.ChannelCachePolicy))
+		c.ChannelCache = NewChannelCache(observeCache(c, FlagChannels, NewCache[discord.GuildChannel](c.CacheFlags, FlagChannels, c.ChannelCachePolicy)))
 	}
 	if c.StageInstanceCache == nil {
-		c.StageInstanceCache = NewStageInstanceCache(NewGroupedCache[discord.StageInstance](c.CacheFlags, FlagStageInstances, c.StageInstanceCachePolicy))
+		c.StageInstanceCache = NewStageInstanceCache(observeGroupedCache(c, FlagStageInstances, NewGroupedCache[discord.StageInstance](c.CacheFlags, FlagStageInstances, c.StageInstanceCachePolicy)))
 	}
 	if c.GuildScheduledEventCache == nil {
-		c.GuildScheduledEventCache = NewGuildScheduledEventCache(NewGroupedCache[discord.GuildScheduledEvent](c.CacheFlags, FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy))
+		c.GuildScheduledEventCache = NewGuildScheduledEventCache(observeGroupedCache(c, FlagGuildScheduledEvents, NewGroupedCache[discord.GuildScheduledEvent](c.CacheFlags, FlagGuildScheduledEvents, c.GuildScheduledEventCachePolicy)))
 	}
 	if c.RoleCache == nil {
-		c.RoleCache = NewRoleCache(NewGroupedCache[discord.Role](c.CacheFlags, FlagRoles, c.RoleCachePolicy))
+		c.RoleCache = NewRoleCache(observeGroupedCache(c, FlagRoles, NewGroupedCache[discord.Role](c.CacheFlags, FlagRoles, c.RoleCachePolicy)))
 	}
 	if c.MemberCache == nil {
-		c.MemberCache = NewMemberCache(NewGroupedCache[discord.Member](c.CacheFlags, FlagMembers, c.MemberCachePolicy))
+		c.MemberCache = NewMemberCache(observeGroupedCache(c, FlagMembers, NewGroupedCache[discord.Member](c.CacheFlags, FlagMembers, c.MemberCachePolicy)))
 	}
 	if c.ThreadMemberCache == nil {
-		c.ThreadMemberCache = NewThreadMemberCache(NewGroupedCache[discord.ThreadMember](c.CacheFlags, FlagThreadMembers, c.ThreadMemberCachePolicy))
+		c.ThreadMemberCache = NewThreadMemberCache(observeGroupedCache(c, FlagThreadMembers, NewGroupedCache[discord.ThreadMember](c.CacheFlags, FlagThreadMembers, c.ThreadMemberCachePolicy)))
 	}
 	if c.PresenceCache == nil {
-		c.PresenceCache = NewPresenceCache(NewGroupedCache[discord.Presence](c.CacheFlags, FlagPresences, c.PresenceCachePolicy))
+		c.PresenceCache = NewPresenceCache(observeGroupedCache(c, FlagPresences, NewGroupedCache[discord.Presence](c.CacheFlags, FlagPresences, c.PresenceCachePolicy)))
 	}
 	if c.VoiceStateCache == nil {
-		c.VoiceStateCache = NewVoiceStateCache(NewGroupedCache[discord.VoiceState](c.CacheFlags, FlagVoiceStates, c.VoiceStateCachePolicy))
+		c.VoiceStateCache = NewVoiceStateCache(observeGroupedCache(c, FlagVoiceStates, NewGroupedCache[discord.VoiceState](c.CacheFlags, FlagVoiceStates, c.VoiceStateCachePolicy)))
 	}
 	if c.MessageCache == nil {
-		c.MessageCache = NewMessageCache(NewGroupedCache[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy))
+		if len(c.MessageCacheEviction) > 0 {
+			c.MessageCache = NewMessageCache(observeGroupedCache(c, FlagMessages, NewEvictingGroupedCache[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy, c.MessageCacheEvictFunc, c.MessageCacheEviction...)))
+		} else {
+			c.MessageCache = NewMessageCache(observeGroupedCache(c, FlagMessages, NewGroupedCache[discord.Message](c.CacheFlags, FlagMessages, c.MessageCachePolicy)))
+		}
 	}
 	if c.EmojiCache == nil {
-		c.EmojiCache = NewEmojiCache(NewGroupedCache[discord.Emoji](c.CacheFlags, FlagEmojis, c.EmojiCachePolicy))
+		c.EmojiCache = NewEmojiCache(observeGroupedCache(c, FlagEmojis, NewGroupedCache[discord.Emoji](c.CacheFlags, FlagEmojis, c.EmojiCachePolicy)))
 	}
 	if c.StickerCache == nil {
-		c.StickerCache = NewStickerCache(NewGroupedCache[discord.Sticker](c.CacheFlags, FlagStickers, c.StickerCachePolicy))
+		c.StickerCache = NewStickerCache(observeGroupedCache(c, FlagStickers, NewGroupedCache[discord.Sticker](c.CacheFlags, FlagStickers, c.StickerCachePolicy)))
+	}
+}
+
+// observeCache wraps the default cache into an ObservableCache, so observers can be added with Caches.
+func observeCache[T any](c *Config, flag Flags, cache Cache[T]) Cache[T] {
+	observable, ok := cache.(ObservableCache[T])
+	if !ok {
+		observable = NewObservableCache(cache)
+	}
+	if c.observables == nil {
+		c.observables = map[Flags]any{}
+	}
+	c.observables[flag] = observable
+	return observable
+}
+
+// observeGroupedCache wraps the default cache into an ObservableGroupedCache, so observers can be added with Caches.
+func observeGroupedCache[T any](c *Config, flag Flags, cache GroupedCache[T]) GroupedCache[T] {
+	observable, ok := cache.(ObservableGroupedCache[T])
+	if !ok {
+		observable = NewObservableGroupedCache(cache)
+	}
+	if c.observables == nil {
+		c.observables = map[Flags]any{}
 	}
+	c.observables[flag] = observable
+	return observable
 }
 
 // WithCaches sets the Flags of the Config.
@@ -123,6 +159,14 @@ func WithCaches(flags ...Flags) ConfigOpt {
 	}
 }
 
+// WithIndexes enables the given built-in Indexes of the Caches.
+// Indexes are only built for the default caches.
+func WithIndexes(indexes ...Indexes) ConfigOpt {
+	return func(config *Config) {
+		config.Indexes = config.Indexes.Add(indexes...)
+	}
+}
+
 // WithGuildCachePolicy sets the Policy[discord.Guild] of the Config.
 func WithGuildCachePolicy(policy Policy[discord.Guild]) ConfigOpt {
 	return func(config *Config) {
@@ -263,6 +307,16 @@ func WithMessageCache(messageCache MessageCache) ConfigOpt {
 	}
 }
 
+// WithMessageCacheEviction lets the default MessageCache evict messages after the given EvictionConfigOpt(s).
+// Messages are grouped by channel, so WithMaxGroupSize limits the messages per channel and WithMaxSize limits all messages.
+// The given EvictFunc is called for each evicted message and may be nil.
+func WithMessageCacheEviction(onEvict EvictFunc[discord.Message], opts ...EvictionConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.MessageCacheEvictFunc = onEvict
+		config.MessageCacheEviction = append(config.MessageCacheEviction, opts...)
+	}
+}
+
 // WithEmojiCachePolicy sets the Policy[discord.Emoji] of the Config.
 func WithEmojiCachePolicy(policy Policy[discord.Emoji]) ConfigOpt {
 	return func(config *Config) {
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// EvictionReason describes why an entity was evicted from a cache.
+type EvictionReason int
+
+const (
+	// EvictionReasonSize means the entity was the least recently used one when the cache or its group exceeded its maximum size.
+	EvictionReasonSize EvictionReason = iota
+	// EvictionReasonExpired means the entity was not updated within the TTL of the cache.
+	EvictionReasonExpired
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictionReasonSize:
+		return "size"
+	case EvictionReasonExpired:
+		return "expired"
+	default:
+		return "unknown"
+	}
+}
+
+// EvictFunc is called for each entity which was evicted from a cache.
+// Entities removed with Remove, RemoveIf or GroupRemove are not reported, which allows telling evictions apart from Discord deletes.
+// The groupID is always 0 for a Cache.
+type EvictFunc[T any] func(groupID snowflake.ID, id snowflake.ID, entity T, reason EvictionReason)
+
+// DefaultEvictionConfig returns an EvictionConfig without any limits.
+func DefaultEvictionConfig() *EvictionConfig {
+	return &EvictionConfig{}
+}
+
+// EvictionConfig lets you configure when entities are evicted from a cache created with NewEvictingCache or NewEvictingGroupedCache.
+type EvictionConfig struct {
+	// MaxSize is the maximum number of entities in the cache. 0 means unlimited.
+	MaxSize int
+	// MaxGroupSize is the maximum number of entities per group in a GroupedCache. 0 means unlimited.
+	MaxGroupSize int
+	// TTL is the time after which an entity expires if it was not put again. 0 means entities never expire.
+	TTL time.Duration
+}
+
+// EvictionConfigOpt is a type alias for a function that takes an EvictionConfig and is used to configure eviction.
+type EvictionConfigOpt func(config *EvictionConfig)
+
+// Apply applies the given EvictionConfigOpt(s) to the EvictionConfig
+func (c *EvictionConfig) Apply(opts []EvictionConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithMaxSize sets the maximum number of entities in the cache. The least recently used entity is evicted first.
+func WithMaxSize(maxSize int) EvictionConfigOpt {
+	return func(config *EvictionConfig) {
+		config.MaxSize = maxSize
+	}
+}
+
+// WithMaxGroupSize sets the maximum number of entities per group, like messages per channel. The least recently used entity of the group is evicted first.
+func WithMaxGroupSize(maxGroupSize int) EvictionConfigOpt {
+	return func(config *EvictionConfig) {
+		config.MaxGroupSize = maxGroupSize
+	}
+}
+
+// WithTTL sets the time after which an entity expires if it was not put again.
+func WithTTL(ttl time.Duration) EvictionConfigOpt {
+	return func(config *EvictionConfig) {
+		config.TTL = ttl
+	}
+}
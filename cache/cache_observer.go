@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ErrCacheNotObservable is returned when adding an Observer to a cache which was replaced via the Config.
+// Wrap your own cache with NewObservableCache or NewObservableGroupedCache to observe it.
+var ErrCacheNotObservable = errors.New("cache was replaced via the config and can't be observed")
+
+// ChangeType describes how an entity in a cache changed.
+type ChangeType int
+
+const (
+	// ChangeTypePut means a new entity was added. Change.New is set.
+	ChangeTypePut ChangeType = iota
+	// ChangeTypeUpdate means an existing entity was overwritten, even if it is equal to the new one. Change.Old & Change.New are set.
+	ChangeTypeUpdate
+	// ChangeTypeRemove means an entity was removed. Change.Old is set.
+	ChangeTypeRemove
+	// ChangeTypeEvict means an entity was evicted by an eviction policy. Change.Old is set.
+	ChangeTypeEvict
+)
+
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeTypePut:
+		return "put"
+	case ChangeTypeUpdate:
+		return "update"
+	case ChangeTypeRemove:
+		return "remove"
+	case ChangeTypeEvict:
+		return "evict"
+	default:
+		return "unknown"
+	}
+}
+
+// Change is passed to an Observer for each change of a cache.
+// The ID of the entity can be read from the entity itself.
+type Change[T any] struct {
+	Type ChangeType
+	// GroupID is the group of the entity in a GroupedCache and always 0 for a Cache.
+	GroupID snowflake.ID
+	Old     T
+	New     T
+}
+
+// Observer is called after an entity in a cache changed. Observers are called synchronously and should return quickly.
+// Changes are reported while the cache is locked, so they arrive in order and an Observer must not change the cache it observes.
+type Observer[T any] func(change Change[T])
+
+// Stats are counters of a cache which can be exported to a monitoring system.
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+	// Evictions is only counted for caches created with NewEvictingCache or NewEvictingGroupedCache.
+	Evictions uint64
+	Size      int
+}
+
+// observers is a thread safe list of Observer(s) together with the Stats counters of a cache.
+type observers[T any] struct {
+	mu        sync.RWMutex
+	observers map[int]Observer[T]
+	nextID    int
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+func (o *observers[T]) add(observer Observer[T]) func() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.observers == nil {
+		o.observers = map[int]Observer[T]{}
+	}
+	id := o.nextID
+	o.nextID++
+	o.observers[id] = observer
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		delete(o.observers, id)
+	}
+}
+
+func (o *observers[T]) active() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return len(o.observers) > 0
+}
+
+func (o *observers[T]) notify(change Change[T]) {
+	o.mu.RLock()
+	observers := make([]Observer[T], 0, len(o.observers))
+	for _, observer := range o.observers {
+		observers = append(observers, observer)
+	}
+	o.mu.RUnlock()
+
+	for _, observer := range observers {
+		observer(change)
+	}
+}
+
+func (o *observers[T]) hit(ok bool) {
+	if ok {
+		o.hits.Add(1)
+	} else {
+		o.misses.Add(1)
+	}
+}
+
+func (o *observers[T]) evicted(groupID snowflake.ID, entity T) {
+	o.evictions.Add(1)
+	if o.active() {
+		o.notify(Change[T]{Type: ChangeTypeEvict, GroupID: groupID, Old: entity})
+	}
+}
+
+func (o *observers[T]) stats(size int) Stats {
+	return Stats{
+		Hits:      o.hits.Load(),
+		Misses:    o.misses.Load(),
+		Evictions: o.evictions.Load(),
+		Size:      size,
+	}
+}
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"slices"
 	"sync"
 	"time"
 
@@ -95,9 +96,9 @@ func (c *guildCacheImpl) IsGuildUnavailable(guildID snowflake.ID) bool {
 }
 
 func (c *guildCacheImpl) SetGuildUnavailable(guildID snowflake.ID, unavailable bool) {
-	if c.unavailableGuilds.Has(guildID) && unavailable {
+	if c.unavailableGuilds.Has(guildID) && !unavailable {
 		c.unavailableGuilds.Remove(guildID)
-	} else if !c.unavailableGuilds.Has(guildID) && !unavailable {
+	} else if !c.unavailableGuilds.Has(guildID) && unavailable {
 		c.unavailableGuilds.Add(guildID)
 	}
 }
@@ -690,6 +691,58 @@ type Caches interface {
 	// CacheFlags returns the current configured FLags of the caches.
 	CacheFlags() Flags
 
+	// Stats returns the Stats of each default cache by its Flags.
+	// Caches which were replaced via the Config are not included.
+	Stats() map[Flags]Stats
+
+	// AddGuildObserver adds an Observer for the default GuildCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the GuildCache was replaced via the Config.
+	AddGuildObserver(observer Observer[discord.Guild]) (func(), error)
+
+	// AddChannelObserver adds an Observer for the default ChannelCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the ChannelCache was replaced via the Config.
+	AddChannelObserver(observer Observer[discord.GuildChannel]) (func(), error)
+
+	// AddStageInstanceObserver adds an Observer for the default StageInstanceCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the StageInstanceCache was replaced via the Config.
+	AddStageInstanceObserver(observer Observer[discord.StageInstance]) (func(), error)
+
+	// AddGuildScheduledEventObserver adds an Observer for the default GuildScheduledEventCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the GuildScheduledEventCache was replaced via the Config.
+	AddGuildScheduledEventObserver(observer Observer[discord.GuildScheduledEvent]) (func(), error)
+
+	// AddRoleObserver adds an Observer for the default RoleCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the RoleCache was replaced via the Config.
+	AddRoleObserver(observer Observer[discord.Role]) (func(), error)
+
+	// AddMemberObserver adds an Observer for the default MemberCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the MemberCache was replaced via the Config.
+	AddMemberObserver(observer Observer[discord.Member]) (func(), error)
+
+	// AddThreadMemberObserver adds an Observer for the default ThreadMemberCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the ThreadMemberCache was replaced via the Config.
+	AddThreadMemberObserver(observer Observer[discord.ThreadMember]) (func(), error)
+
+	// AddPresenceObserver adds an Observer for the default PresenceCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the PresenceCache was replaced via the Config.
+	AddPresenceObserver(observer Observer[discord.Presence]) (func(), error)
+
+	// AddVoiceStateObserver adds an Observer for the default VoiceStateCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the VoiceStateCache was replaced via the Config.
+	AddVoiceStateObserver(observer Observer[discord.VoiceState]) (func(), error)
+
+	// AddMessageObserver adds an Observer for the default MessageCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the MessageCache was replaced via the Config.
+	AddMessageObserver(observer Observer[discord.Message]) (func(), error)
+
+	// AddEmojiObserver adds an Observer for the default EmojiCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the EmojiCache was replaced via the Config.
+	AddEmojiObserver(observer Observer[discord.Emoji]) (func(), error)
+
+	// AddStickerObserver adds an Observer for the default StickerCache and returns a function to remove it again.
+	// It returns ErrCacheNotObservable if the StickerCache was replaced via the Config.
+	AddStickerObserver(observer Observer[discord.Sticker]) (func(), error)
+
 	// MemberPermissions returns the calculated permissions of the given member.
 	// This requires the FlagRoles to be set.
 	MemberPermissions(member discord.Member) discord.Permissions
@@ -710,6 +763,18 @@ type Caches interface {
 	// This is only available after we received the gateway.EventTypeGuildCreate event for the given guildID.
 	SelfMember(guildID snowflake.ID) (discord.Member, bool)
 
+	// MembersWithRole returns all members of the given guild which have the given role.
+	// This uses IndexMemberRoles if enabled and iterates all members of the guild otherwise.
+	MembersWithRole(guildID snowflake.ID, roleID snowflake.ID) []discord.Member
+
+	// ChannelsByParentID returns all channels with the given parent, like the channels in a category or the threads in a channel.
+	// This uses IndexChannelParents if enabled and iterates all channels otherwise.
+	ChannelsByParentID(parentID snowflake.ID) []discord.GuildChannel
+
+	// MemberGuildIDs returns the ids of all guilds in which the given user is cached as member.
+	// This uses IndexMemberGuilds if enabled and iterates all guilds otherwise.
+	MemberGuildIDs(userID snowflake.ID) []snowflake.ID
+
 	// GuildThreadsInChannel returns all discord.GuildThread from the ChannelCache and a bool indicating if it exists.
 	GuildThreadsInChannel(channelID snowflake.ID) []discord.GuildThread
 
@@ -758,7 +823,7 @@ func New(opts ...ConfigOpt) Caches {
 	config := DefaultConfig()
 	config.Apply(opts)
 
-	return &cachesImpl{
+	c := &cachesImpl{
 		config:                   *config,
 		SelfUserCache:            config.SelfUserCache,
 		GuildCache:               config.GuildCache,
@@ -774,11 +839,41 @@ func New(opts ...ConfigOpt) Caches {
 		EmojiCache:               config.EmojiCache,
 		StickerCache:             config.StickerCache,
 	}
+
+	if members, ok := config.observables[FlagMembers].(ObservableGroupedCache[discord.Member]); ok {
+		if config.Indexes.Has(IndexMemberRoles) {
+			c.memberRoleIndex = NewGroupedIndex(members, memberID, func(member discord.Member) []snowflake.ID {
+				return member.RoleIDs
+			})
+		}
+		if config.Indexes.Has(IndexMemberGuilds) {
+			c.memberGuildIndex = NewGroupedIndex(members, memberID, func(member discord.Member) []snowflake.ID {
+				return []snowflake.ID{member.User.ID}
+			})
+		}
+	}
+	if channels, ok := config.observables[FlagChannels].(ObservableCache[discord.GuildChannel]); ok && config.Indexes.Has(IndexChannelParents) {
+		c.channelParentIndex = NewIndex(channels, discord.GuildChannel.ID, func(channel discord.GuildChannel) []snowflake.ID {
+			if parentID := channel.ParentID(); parentID != nil {
+				return []snowflake.ID{*parentID}
+			}
+			return nil
+		})
+	}
+	return c
+}
+
+func memberID(member discord.Member) snowflake.ID {
+	return member.User.ID
 }
 
 type cachesImpl struct {
 	config Config
 
+	memberRoleIndex    Index[discord.Member]
+	memberGuildIndex   Index[discord.Member]
+	channelParentIndex Index[discord.GuildChannel]
+
 	GuildCache
 	ChannelCache
 	StageInstanceCache
@@ -798,6 +893,73 @@ func (c *cachesImpl) CacheFlags() Flags {
 	return c.config.CacheFlags
 }
 
+func (c *cachesImpl) Stats() map[Flags]Stats {
+	stats := make(map[Flags]Stats, len(c.config.observables))
+	for flag, observable := range c.config.observables {
+		if s, ok := observable.(interface{ Stats() Stats }); ok {
+			stats[flag] = s.Stats()
+		}
+	}
+	return stats
+}
+
+// addObserver adds the Observer to the default cache with the given Flags.
+func addObserver[T any](c *cachesImpl, flag Flags, observer Observer[T]) (func(), error) {
+	observable, ok := c.config.observables[flag].(interface{ AddObserver(Observer[T]) func() })
+	if !ok {
+		return nil, ErrCacheNotObservable
+	}
+	return observable.AddObserver(observer), nil
+}
+
+func (c *cachesImpl) AddGuildObserver(observer Observer[discord.Guild]) (func(), error) {
+	return addObserver(c, FlagGuilds, observer)
+}
+
+func (c *cachesImpl) AddChannelObserver(observer Observer[discord.GuildChannel]) (func(), error) {
+	return addObserver(c, FlagChannels, observer)
+}
+
+func (c *cachesImpl) AddStageInstanceObserver(observer Observer[discord.StageInstance]) (func(), error) {
+	return addObserver(c, FlagStageInstances, observer)
+}
+
+func (c *cachesImpl) AddGuildScheduledEventObserver(observer Observer[discord.GuildScheduledEvent]) (func(), error) {
+	return addObserver(c, FlagGuildScheduledEvents, observer)
+}
+
+func (c *cachesImpl) AddRoleObserver(observer Observer[discord.Role]) (func(), error) {
+	return addObserver(c, FlagRoles, observer)
+}
+
+func (c *cachesImpl) AddMemberObserver(observer Observer[discord.Member]) (func(), error) {
+	return addObserver(c, FlagMembers, observer)
+}
+
+func (c *cachesImpl) AddThreadMemberObserver(observer Observer[discord.ThreadMember]) (func(), error) {
+	return addObserver(c, FlagThreadMembers, observer)
+}
+
+func (c *cachesImpl) AddPresenceObserver(observer Observer[discord.Presence]) (func(), error) {
+	return addObserver(c, FlagPresences, observer)
+}
+
+func (c *cachesImpl) AddVoiceStateObserver(observer Observer[discord.VoiceState]) (func(), error) {
+	return addObserver(c, FlagVoiceStates, observer)
+}
+
+func (c *cachesImpl) AddMessageObserver(observer Observer[discord.Message]) (func(), error) {
+	return addObserver(c, FlagMessages, observer)
+}
+
+func (c *cachesImpl) AddEmojiObserver(observer Observer[discord.Emoji]) (func(), error) {
+	return addObserver(c, FlagEmojis, observer)
+}
+
+func (c *cachesImpl) AddStickerObserver(observer Observer[discord.Sticker]) (func(), error) {
+	return addObserver(c, FlagStickers, observer)
+}
+
 func (c *cachesImpl) MemberPermissions(member discord.Member) discord.Permissions {
 	if guild, ok := c.Guild(member.GuildID); ok && guild.OwnerID == member.User.ID {
 		return discord.PermissionsAll
@@ -892,6 +1054,65 @@ func (c *cachesImpl) SelfMember(guildID snowflake.ID) (discord.Member, bool) {
 	return c.Member(guildID, selfUser.ID)
 }
 
+func (c *cachesImpl) MembersWithRole(guildID snowflake.ID, roleID snowflake.ID) []discord.Member {
+	var members []discord.Member
+	// every member has the @everyone role, which has the same id as the guild
+	if c.memberRoleIndex == nil || roleID == guildID {
+		c.MembersForEach(guildID, func(member discord.Member) {
+			if roleID == guildID || slices.Contains(member.RoleIDs, roleID) {
+				members = append(members, member)
+			}
+		})
+		return members
+	}
+
+	for _, entry := range c.memberRoleIndex.Get(roleID) {
+		if entry.GroupID != guildID {
+			continue
+		}
+		if member, ok := c.Member(entry.GroupID, entry.ID); ok {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func (c *cachesImpl) ChannelsByParentID(parentID snowflake.ID) []discord.GuildChannel {
+	var channels []discord.GuildChannel
+	if c.channelParentIndex == nil {
+		c.ChannelsForEach(func(channel discord.GuildChannel) {
+			if channelParentID := channel.ParentID(); channelParentID != nil && *channelParentID == parentID {
+				channels = append(channels, channel)
+			}
+		})
+		return channels
+	}
+
+	for _, entry := range c.channelParentIndex.Get(parentID) {
+		if channel, ok := c.Channel(entry.ID); ok {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+func (c *cachesImpl) MemberGuildIDs(userID snowflake.ID) []snowflake.ID {
+	var guildIDs []snowflake.ID
+	if c.memberGuildIndex == nil {
+		c.GuildsForEach(func(guild discord.Guild) {
+			if _, ok := c.Member(guild.ID, userID); ok {
+				guildIDs = append(guildIDs, guild.ID)
+			}
+		})
+		return guildIDs
+	}
+
+	for _, entry := range c.memberGuildIndex.Get(userID) {
+		guildIDs = append(guildIDs, entry.GroupID)
+	}
+	return guildIDs
+}
+
 func (c *cachesImpl) GuildThreadsInChannel(channelID snowflake.ID) []discord.GuildThread {
 	var threads []discord.GuildThread
 	c.ChannelsForEach(func(channel discord.GuildChannel) {
@@ -0,0 +1,364 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	_ Cache[any]              = (*evictingCache[any])(nil)
+	_ GroupedCache[any]       = (*evictingGroupedCache[any])(nil)
+	_ evictionObservable[any] = (*evictingCache[any])(nil)
+	_ evictionObservable[any] = (*evictingGroupedCache[any])(nil)
+)
+
+// NewEvictingCache returns a new thread safe Cache which evicts entities after the given EvictionConfigOpt(s).
+// The given EvictFunc is called for each evicted entity and may be nil. EvictionConfig.MaxGroupSize is ignored.
+func NewEvictingCache[T any](flags Flags, neededFlags Flags, policy Policy[T], onEvict EvictFunc[T], opts ...EvictionConfigOpt) Cache[T] {
+	config := DefaultEvictionConfig()
+	config.Apply(opts)
+	config.MaxGroupSize = 0
+
+	return &evictingCache[T]{
+		cache: newEvictingGroupedCache(flags, neededFlags, policy, onEvict, *config),
+	}
+}
+
+type evictingCache[T any] struct {
+	cache *evictingGroupedCache[T]
+}
+
+func (c *evictingCache[T]) observeEvictions(onEvict EvictFunc[T]) {
+	c.cache.observeEvictions(onEvict)
+}
+
+func (c *evictingCache[T]) Get(id snowflake.ID) (T, bool) {
+	return c.cache.Get(0, id)
+}
+
+func (c *evictingCache[T]) Put(id snowflake.ID, entity T) {
+	c.cache.Put(0, id, entity)
+}
+
+func (c *evictingCache[T]) swap(id snowflake.ID, entity T) (T, bool, bool) {
+	return c.cache.swap(0, id, entity)
+}
+
+func (c *evictingCache[T]) Remove(id snowflake.ID) (T, bool) {
+	return c.cache.Remove(0, id)
+}
+
+func (c *evictingCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	c.cache.RemoveIf(func(_ snowflake.ID, entity T) bool {
+		return filterFunc(entity)
+	})
+}
+
+func (c *evictingCache[T]) Len() int {
+	return c.cache.Len()
+}
+
+func (c *evictingCache[T]) ForEach(forEachFunc func(entity T)) {
+	c.cache.GroupForEach(0, forEachFunc)
+}
+
+// NewEvictingGroupedCache returns a new thread safe GroupedCache which evicts entities after the given EvictionConfigOpt(s).
+// The given EvictFunc is called for each evicted entity and may be nil.
+func NewEvictingGroupedCache[T any](flags Flags, neededFlags Flags, policy Policy[T], onEvict EvictFunc[T], opts ...EvictionConfigOpt) GroupedCache[T] {
+	config := DefaultEvictionConfig()
+	config.Apply(opts)
+
+	return newEvictingGroupedCache(flags, neededFlags, policy, onEvict, *config)
+}
+
+func newEvictingGroupedCache[T any](flags Flags, neededFlags Flags, policy Policy[T], onEvict EvictFunc[T], config EvictionConfig) *evictingGroupedCache[T] {
+	return &evictingGroupedCache[T]{
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+		onEvict:     onEvict,
+		config:      config,
+		now:         time.Now,
+		entries:     map[evictionKey]*evictionEntry[T]{},
+		groups:      map[snowflake.ID]*list.List{},
+		lru:         list.New(),
+		expiry:      list.New(),
+	}
+}
+
+type evictionKey struct {
+	groupID snowflake.ID
+	id      snowflake.ID
+}
+
+type evictionEntry[T any] struct {
+	key     evictionKey
+	entity  T
+	expires time.Time
+
+	// the entry is in three lists: the global lru list, the lru list of its group & the expiry list ordered by the last put
+	lruElem    *list.Element
+	groupElem  *list.Element
+	expiryElem *list.Element
+}
+
+type evictedEntry[T any] struct {
+	key    evictionKey
+	entity T
+	reason EvictionReason
+}
+
+type evictingGroupedCache[T any] struct {
+	flags       Flags
+	neededFlags Flags
+	policy      Policy[T]
+	onEvict     EvictFunc[T]
+	config      EvictionConfig
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[evictionKey]*evictionEntry[T]
+	groups  map[snowflake.ID]*list.List
+	lru     *list.List
+	expiry  *list.List
+}
+
+// notify calls the EvictFunc for the evicted entries. It must be called without holding the lock, so the EvictFunc can access the cache.
+func (c *evictingGroupedCache[T]) notify(evicted []evictedEntry[T]) {
+	if c.onEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		c.onEvict(e.key.groupID, e.key.id, e.entity, e.reason)
+	}
+}
+
+// observeEvictions calls the given EvictFunc before the EvictFunc of the cache. It must be called before the cache is used.
+func (c *evictingGroupedCache[T]) observeEvictions(onEvict EvictFunc[T]) {
+	next := c.onEvict
+	c.onEvict = func(groupID snowflake.ID, id snowflake.ID, entity T, reason EvictionReason) {
+		onEvict(groupID, id, entity, reason)
+		if next != nil {
+			next(groupID, id, entity, reason)
+		}
+	}
+}
+
+func (c *evictingGroupedCache[T]) remove(entry *evictionEntry[T]) {
+	delete(c.entries, entry.key)
+	c.lru.Remove(entry.lruElem)
+	c.expiry.Remove(entry.expiryElem)
+	group := c.groups[entry.key.groupID]
+	group.Remove(entry.groupElem)
+	if group.Len() == 0 {
+		delete(c.groups, entry.key.groupID)
+	}
+}
+
+// expire removes all expired entries and returns them.
+func (c *evictingGroupedCache[T]) expire(evicted []evictedEntry[T]) []evictedEntry[T] {
+	if c.config.TTL <= 0 {
+		return evicted
+	}
+	now := c.now()
+	for elem := c.expiry.Front(); elem != nil; elem = c.expiry.Front() {
+		entry := elem.Value.(*evictionEntry[T])
+		if entry.expires.After(now) {
+			break
+		}
+		c.remove(entry)
+		evicted = append(evicted, evictedEntry[T]{key: entry.key, entity: entry.entity, reason: EvictionReasonExpired})
+	}
+	return evicted
+}
+
+func (c *evictingGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	c.mu.Lock()
+	evicted := c.expire(nil)
+	entry, ok := c.entries[evictionKey{groupID: groupID, id: id}]
+	var entity T
+	if ok {
+		entity = entry.entity
+		c.lru.MoveToBack(entry.lruElem)
+		c.groups[groupID].MoveToBack(entry.groupElem)
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return entity, ok
+}
+
+func (c *evictingGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	c.swap(groupID, id, entity)
+}
+
+func (c *evictingGroupedCache[T]) swap(groupID snowflake.ID, id snowflake.ID, entity T) (old T, existed bool, stored bool) {
+	if c.flags.Missing(c.neededFlags) {
+		return old, false, false
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return old, false, false
+	}
+
+	c.mu.Lock()
+	evicted := c.expire(nil)
+
+	key := evictionKey{groupID: groupID, id: id}
+	expires := c.now().Add(c.config.TTL)
+	if entry, ok := c.entries[key]; ok {
+		old, existed = entry.entity, true
+		entry.entity = entity
+		entry.expires = expires
+		c.lru.MoveToBack(entry.lruElem)
+		c.groups[groupID].MoveToBack(entry.groupElem)
+		c.expiry.MoveToBack(entry.expiryElem)
+	} else {
+		group, ok := c.groups[groupID]
+		if !ok {
+			group = list.New()
+			c.groups[groupID] = group
+		}
+		entry = &evictionEntry[T]{
+			key:     key,
+			entity:  entity,
+			expires: expires,
+		}
+		entry.lruElem = c.lru.PushBack(entry)
+		entry.groupElem = group.PushBack(entry)
+		entry.expiryElem = c.expiry.PushBack(entry)
+		c.entries[key] = entry
+
+		if c.config.MaxGroupSize > 0 {
+			for group.Len() > c.config.MaxGroupSize {
+				evicted = c.evictFront(evicted, group)
+			}
+		}
+		if c.config.MaxSize > 0 {
+			for c.lru.Len() > c.config.MaxSize {
+				evicted = c.evictFront(evicted, c.lru)
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return old, existed, true
+}
+
+func (c *evictingGroupedCache[T]) evictFront(evicted []evictedEntry[T], l *list.List) []evictedEntry[T] {
+	entry := l.Front().Value.(*evictionEntry[T])
+	c.remove(entry)
+	return append(evicted, evictedEntry[T]{key: entry.key, entity: entry.entity, reason: EvictionReasonSize})
+}
+
+func (c *evictingGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	c.mu.Lock()
+	evicted := c.expire(nil)
+	entry, ok := c.entries[evictionKey{groupID: groupID, id: id}]
+	var entity T
+	if ok {
+		entity = entry.entity
+		c.remove(entry)
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return entity, ok
+}
+
+func (c *evictingGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	c.GroupRemoveIf(groupID, func(_ snowflake.ID, _ T) bool {
+		return true
+	})
+}
+
+func (c *evictingGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	c.mu.Lock()
+	evicted := c.expire(nil)
+	for _, entry := range c.entries {
+		if filterFunc(entry.key.groupID, entry.entity) {
+			c.remove(entry)
+		}
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+}
+
+func (c *evictingGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	c.mu.Lock()
+	evicted := c.expire(nil)
+	if group, ok := c.groups[groupID]; ok {
+		for elem := group.Front(); elem != nil; {
+			next := elem.Next()
+			entry := elem.Value.(*evictionEntry[T])
+			if filterFunc(groupID, entry.entity) {
+				c.remove(entry)
+			}
+			elem = next
+		}
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+}
+
+func (c *evictingGroupedCache[T]) Len() int {
+	c.mu.Lock()
+	evicted := c.expire(nil)
+	n := len(c.entries)
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return n
+}
+
+func (c *evictingGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	c.mu.Lock()
+	evicted := c.expire(nil)
+	var n int
+	if group, ok := c.groups[groupID]; ok {
+		n = group.Len()
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return n
+}
+
+func (c *evictingGroupedCache[T]) ForEach(forEachFunc func(groupID snowflake.ID, entity T)) {
+	c.mu.Lock()
+	evicted := c.expire(nil)
+	entries := make([]evictionEntry[T], 0, len(c.entries))
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*evictionEntry[T])
+		entries = append(entries, evictionEntry[T]{key: entry.key, entity: entry.entity})
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	for _, entry := range entries {
+		forEachFunc(entry.key.groupID, entry.entity)
+	}
+}
+
+func (c *evictingGroupedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
+	c.mu.Lock()
+	evicted := c.expire(nil)
+	var entities []T
+	if group, ok := c.groups[groupID]; ok {
+		entities = make([]T, 0, group.Len())
+		for elem := group.Front(); elem != nil; elem = elem.Next() {
+			entities = append(entities, elem.Value.(*evictionEntry[T]).entity)
+		}
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	for _, entity := range entities {
+		forEachFunc(entity)
+	}
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type evicted struct {
+	groupID snowflake.ID
+	id      snowflake.ID
+	reason  EvictionReason
+}
+
+func TestEvictingGroupedCacheSize(t *testing.T) {
+	var got []evicted
+	c := NewEvictingGroupedCache[int](FlagsAll, FlagMessages, nil, func(groupID snowflake.ID, id snowflake.ID, _ int, reason EvictionReason) {
+		got = append(got, evicted{groupID: groupID, id: id, reason: reason})
+	}, WithMaxGroupSize(2), WithMaxSize(3))
+
+	c.Put(1, 1, 1)
+	c.Put(1, 2, 2)
+	c.Get(1, 1)
+	c.Put(1, 3, 3)
+	assert.Equal(t, []evicted{{groupID: 1, id: 2, reason: EvictionReasonSize}}, got)
+	assert.Equal(t, 2, c.GroupLen(1))
+
+	c.Put(2, 4, 4)
+	c.Put(2, 5, 5)
+	assert.Equal(t, evicted{groupID: 1, id: 1, reason: EvictionReasonSize}, got[1])
+	assert.Equal(t, 3, c.Len())
+
+	_, ok := c.Remove(1, 3)
+	assert.True(t, ok)
+	assert.Len(t, got, 2)
+}
+
+func TestEvictingCacheTTL(t *testing.T) {
+	now := time.Now()
+	var got []evicted
+	c := NewEvictingCache[int](FlagsAll, FlagGuilds, nil, func(groupID snowflake.ID, id snowflake.ID, _ int, reason EvictionReason) {
+		got = append(got, evicted{groupID: groupID, id: id, reason: reason})
+	}, WithTTL(time.Minute))
+	c.(*evictingCache[int]).cache.now = func() time.Time { return now }
+
+	c.Put(1, 1)
+	now = now.Add(30 * time.Second)
+	c.Put(2, 2)
+	now = now.Add(45 * time.Second)
+
+	_, ok := c.Get(1)
+	assert.False(t, ok)
+	_, ok = c.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, []evicted{{id: 1, reason: EvictionReasonExpired}}, got)
+}
@@ -78,11 +78,15 @@ func (c *defaultGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T,
 }
 
 func (c *defaultGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	c.swap(groupID, id, entity)
+}
+
+func (c *defaultGroupedCache[T]) swap(groupID snowflake.ID, id snowflake.ID, entity T) (old T, existed bool, stored bool) {
 	if c.flags.Missing(c.neededFlags) {
-		return
+		return old, false, false
 	}
 	if c.policy != nil && !c.policy(entity) {
-		return
+		return old, false, false
 	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -92,12 +96,14 @@ func (c *defaultGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, enti
 	}
 
 	if groupEntities, ok := c.cache[groupID]; ok {
+		old, existed = groupEntities[id]
 		groupEntities[id] = entity
 	} else {
 		groupEntities = make(map[snowflake.ID]T)
 		groupEntities[id] = entity
 		c.cache[groupID] = groupEntities
 	}
+	return old, existed, true
 }
 
 func (c *defaultGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (entity T, ok bool) {
@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/internal/flags"
+)
+
+// Indexes are used to enable the built-in secondary indexes of Caches.
+type Indexes int
+
+// values for Indexes
+const (
+	// IndexMemberRoles indexes members by their role ids and backs Caches.MembersWithRole.
+	IndexMemberRoles Indexes = 1 << iota
+	// IndexChannelParents indexes channels by their parent id and backs Caches.ChannelsByParentID.
+	IndexChannelParents
+	// IndexMemberGuilds indexes members by their user id and backs Caches.MemberGuildIDs.
+	IndexMemberGuilds
+
+	IndexesNone Indexes = 0
+	IndexesAll          = IndexMemberRoles |
+		IndexChannelParents |
+		IndexMemberGuilds
+)
+
+// Add allows you to add multiple bits together, producing a new bit
+func (i Indexes) Add(bits ...Indexes) Indexes {
+	return flags.Add(i, bits...)
+}
+
+// Has will ensure that the bit includes all the bits entered
+func (i Indexes) Has(bits ...Indexes) bool {
+	return flags.Has(i, bits...)
+}
+
+// IndexKeysFunc returns the keys an entity is indexed by.
+type IndexKeysFunc[T any] func(entity T) []snowflake.ID
+
+// IndexEntry identifies an indexed entity by its group id and id. The group id is always 0 for entities of a Cache.
+type IndexEntry struct {
+	GroupID snowflake.ID
+	ID      snowflake.ID
+}
+
+// Index is a secondary index over an ObservableCache or ObservableGroupedCache which stays consistent with Put and Remove.
+type Index[T any] interface {
+	// Get returns the entries of all entities indexed by the given key.
+	Get(key snowflake.ID) []IndexEntry
+
+	// Len returns the number of entities indexed by the given key.
+	Len(key snowflake.ID) int
+
+	// Close stops updating the Index.
+	Close()
+}
+
+var _ Index[any] = (*indexImpl[any])(nil)
+
+// NewIndex returns a new Index over the given ObservableCache.
+// idFunc returns the id of an entity and keysFunc returns the keys the entity is indexed by.
+func NewIndex[T any](cache ObservableCache[T], idFunc func(entity T) snowflake.ID, keysFunc IndexKeysFunc[T]) Index[T] {
+	i := newIndex(idFunc, keysFunc)
+	i.removeObserver = cache.AddObserver(i.observe)
+	cache.ForEach(func(entity T) {
+		i.add(0, entity)
+	})
+	return i
+}
+
+// NewGroupedIndex returns a new Index over the given ObservableGroupedCache.
+// idFunc returns the id of an entity within its group and keysFunc returns the keys the entity is indexed by.
+func NewGroupedIndex[T any](cache ObservableGroupedCache[T], idFunc func(entity T) snowflake.ID, keysFunc IndexKeysFunc[T]) Index[T] {
+	i := newIndex(idFunc, keysFunc)
+	i.removeObserver = cache.AddObserver(i.observe)
+	cache.ForEach(i.add)
+	return i
+}
+
+func newIndex[T any](idFunc func(entity T) snowflake.ID, keysFunc IndexKeysFunc[T]) *indexImpl[T] {
+	return &indexImpl[T]{
+		idFunc:   idFunc,
+		keysFunc: keysFunc,
+		entries:  map[snowflake.ID]map[IndexEntry]struct{}{},
+	}
+}
+
+type indexImpl[T any] struct {
+	idFunc         func(entity T) snowflake.ID
+	keysFunc       IndexKeysFunc[T]
+	removeObserver func()
+
+	mu      sync.RWMutex
+	entries map[snowflake.ID]map[IndexEntry]struct{}
+}
+
+func (i *indexImpl[T]) observe(change Change[T]) {
+	switch change.Type {
+	case ChangeTypePut:
+		i.add(change.GroupID, change.New)
+	case ChangeTypeUpdate:
+		i.remove(change.GroupID, change.Old)
+		i.add(change.GroupID, change.New)
+	case ChangeTypeRemove, ChangeTypeEvict:
+		i.remove(change.GroupID, change.Old)
+	}
+}
+
+func (i *indexImpl[T]) add(groupID snowflake.ID, entity T) {
+	entry := IndexEntry{GroupID: groupID, ID: i.idFunc(entity)}
+	keys := i.keysFunc(entity)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, key := range keys {
+		entries, ok := i.entries[key]
+		if !ok {
+			entries = map[IndexEntry]struct{}{}
+			i.entries[key] = entries
+		}
+		entries[entry] = struct{}{}
+	}
+}
+
+func (i *indexImpl[T]) remove(groupID snowflake.ID, entity T) {
+	entry := IndexEntry{GroupID: groupID, ID: i.idFunc(entity)}
+	keys := i.keysFunc(entity)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, key := range keys {
+		entries, ok := i.entries[key]
+		if !ok {
+			continue
+		}
+		delete(entries, entry)
+		if len(entries) == 0 {
+			delete(i.entries, key)
+		}
+	}
+}
+
+func (i *indexImpl[T]) Get(key snowflake.ID) []IndexEntry {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	entries := make([]IndexEntry, 0, len(i.entries[key]))
+	for entry := range i.entries[key] {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (i *indexImpl[T]) Len(key snowflake.ID) int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.entries[key])
+}
+
+func (i *indexImpl[T]) Close() {
+	i.removeObserver()
+}
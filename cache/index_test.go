@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestCachesIndexes(t *testing.T) {
+	caches := New(WithCaches(FlagMembers, FlagChannels, FlagGuilds), WithIndexes(IndexesAll))
+	caches.AddGuild(discord.Guild{ID: 1})
+	caches.AddGuild(discord.Guild{ID: 2})
+
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 10}, RoleIDs: []snowflake.ID{100}})
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 11}, RoleIDs: []snowflake.ID{100, 101}})
+	caches.AddMember(discord.Member{GuildID: 2, User: discord.User{ID: 10}})
+
+	assert.Len(t, caches.MembersWithRole(1, 100), 2)
+	assert.Len(t, caches.MembersWithRole(1, 101), 1)
+	assert.Len(t, caches.MembersWithRole(1, 1), 2)
+	assert.ElementsMatch(t, []snowflake.ID{1, 2}, caches.MemberGuildIDs(10))
+
+	// updating the member removes the old index keys
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 11}, RoleIDs: []snowflake.ID{101}})
+	assert.Len(t, caches.MembersWithRole(1, 100), 1)
+
+	caches.RemoveMembersByGuildID(2)
+	assert.Equal(t, []snowflake.ID{1}, caches.MemberGuildIDs(10))
+
+	for _, data := range []string{
+		`{"id":"20","type":4,"name":"category","guild_id":"1"}`,
+		`{"id":"21","type":0,"name":"text","guild_id":"1","parent_id":"20"}`,
+		`{"id":"22","type":2,"name":"voice","guild_id":"1","parent_id":"20"}`,
+	} {
+		var v discord.UnmarshalChannel
+		require.NoError(t, json.Unmarshal([]byte(data), &v))
+		caches.AddChannel(v.Channel.(discord.GuildChannel))
+	}
+	assert.Len(t, caches.ChannelsByParentID(20), 2)
+
+	caches.RemoveChannel(22)
+	assert.Len(t, caches.ChannelsByParentID(20), 1)
+}
+
+func TestCachesQueriesWithoutIndexes(t *testing.T) {
+	caches := New(WithCaches(FlagMembers, FlagGuilds))
+	caches.AddGuild(discord.Guild{ID: 1})
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 10}, RoleIDs: []snowflake.ID{100}})
+
+	assert.Len(t, caches.MembersWithRole(1, 100), 1)
+	assert.Equal(t, []snowflake.ID{1}, caches.MemberGuildIDs(10))
+}
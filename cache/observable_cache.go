@@ -0,0 +1,306 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ObservableCache is a Cache which reports its changes to Observer(s) and counts its Stats.
+type ObservableCache[T any] interface {
+	Cache[T]
+
+	// AddObserver adds the Observer and returns a function to remove it again.
+	AddObserver(observer Observer[T]) func()
+
+	// Stats returns the current Stats of the cache.
+	Stats() Stats
+}
+
+// ObservableGroupedCache is a GroupedCache which reports its changes to Observer(s) and counts its Stats.
+type ObservableGroupedCache[T any] interface {
+	GroupedCache[T]
+
+	// AddObserver adds the Observer and returns a function to remove it again.
+	AddObserver(observer Observer[T]) func()
+
+	// Stats returns the current Stats of the cache.
+	Stats() Stats
+}
+
+var (
+	_ ObservableCache[any]        = (*observableCache[any])(nil)
+	_ ObservableGroupedCache[any] = (*observableGroupedCache[any])(nil)
+)
+
+// evictionObservable is implemented by the caches of NewEvictingCache & NewEvictingGroupedCache.
+type evictionObservable[T any] interface {
+	observeEvictions(onEvict EvictFunc[T])
+}
+
+// swapper is implemented by the caches of this package.
+// swap puts the entity and returns the replaced one & whether the entity was stored or rejected by the Flags or Policy of the cache.
+type swapper[T any] interface {
+	swap(id snowflake.ID, entity T) (old T, existed bool, stored bool)
+}
+
+// groupedSwapper is the swapper of the grouped caches of this package.
+type groupedSwapper[T any] interface {
+	swap(groupID snowflake.ID, id snowflake.ID, entity T) (old T, existed bool, stored bool)
+}
+
+// NewObservableCache wraps the given Cache into an ObservableCache.
+// Changes which don't go through the returned ObservableCache are not observed.
+// Evictions are only observed & counted for caches created with NewEvictingCache.
+func NewObservableCache[T any](cache Cache[T]) ObservableCache[T] {
+	c := &observableCache[T]{
+		cache: cache,
+	}
+	if evicting, ok := cache.(evictionObservable[T]); ok {
+		evicting.observeEvictions(func(groupID snowflake.ID, _ snowflake.ID, entity T, _ EvictionReason) {
+			c.observers.evicted(groupID, entity)
+		})
+	}
+	return c
+}
+
+type observableCache[T any] struct {
+	cache     Cache[T]
+	observers observers[T]
+
+	// mu makes the changes of the cache & their notifications atomic, so observers see them in order
+	mu sync.Mutex
+}
+
+func (c *observableCache[T]) AddObserver(observer Observer[T]) func() {
+	return c.observers.add(observer)
+}
+
+func (c *observableCache[T]) Stats() Stats {
+	return c.observers.stats(c.cache.Len())
+}
+
+func (c *observableCache[T]) Get(id snowflake.ID) (T, bool) {
+	entity, ok := c.cache.Get(id)
+	c.observers.hit(ok)
+	return entity, ok
+}
+
+func (c *observableCache[T]) Put(id snowflake.ID, entity T) {
+	if !c.observers.active() {
+		c.cache.Put(id, entity)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		old             T
+		existed, stored bool
+	)
+	if s, ok := c.cache.(swapper[T]); ok {
+		old, existed, stored = s.swap(id, entity)
+	} else {
+		old, existed = c.cache.Get(id)
+		c.cache.Put(id, entity)
+		entity, stored = c.cache.Get(id)
+	}
+	if change, changed := putChange(old, existed, entity, stored); changed {
+		c.observers.notify(change)
+	}
+}
+
+func (c *observableCache[T]) Remove(id snowflake.ID) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entity, ok := c.cache.Remove(id)
+	if ok && c.observers.active() {
+		c.observers.notify(Change[T]{Type: ChangeTypeRemove, Old: entity})
+	}
+	return entity, ok
+}
+
+func (c *observableCache[T]) RemoveIf(filterFunc FilterFunc[T]) {
+	if !c.observers.active() {
+		c.cache.RemoveIf(filterFunc)
+		return
+	}
+	var removed []T
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.RemoveIf(func(entity T) bool {
+		if filterFunc(entity) {
+			removed = append(removed, entity)
+			return true
+		}
+		return false
+	})
+	for _, entity := range removed {
+		c.observers.notify(Change[T]{Type: ChangeTypeRemove, Old: entity})
+	}
+}
+
+func (c *observableCache[T]) Len() int {
+	return c.cache.Len()
+}
+
+func (c *observableCache[T]) ForEach(forEachFunc func(entity T)) {
+	c.cache.ForEach(forEachFunc)
+}
+
+// NewObservableGroupedCache wraps the given GroupedCache into an ObservableGroupedCache.
+// Changes which don't go through the returned ObservableGroupedCache are not observed.
+// Evictions are only observed & counted for caches created with NewEvictingGroupedCache.
+func NewObservableGroupedCache[T any](cache GroupedCache[T]) ObservableGroupedCache[T] {
+	c := &observableGroupedCache[T]{
+		cache: cache,
+	}
+	if evicting, ok := cache.(evictionObservable[T]); ok {
+		evicting.observeEvictions(func(groupID snowflake.ID, _ snowflake.ID, entity T, _ EvictionReason) {
+			c.observers.evicted(groupID, entity)
+		})
+	}
+	return c
+}
+
+type observableGroupedCache[T any] struct {
+	cache     GroupedCache[T]
+	observers observers[T]
+
+	// mu makes the changes of the cache & their notifications atomic, so observers see them in order
+	mu sync.Mutex
+}
+
+func (c *observableGroupedCache[T]) AddObserver(observer Observer[T]) func() {
+	return c.observers.add(observer)
+}
+
+func (c *observableGroupedCache[T]) Stats() Stats {
+	return c.observers.stats(c.cache.Len())
+}
+
+func (c *observableGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	entity, ok := c.cache.Get(groupID, id)
+	c.observers.hit(ok)
+	return entity, ok
+}
+
+func (c *observableGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	if !c.observers.active() {
+		c.cache.Put(groupID, id, entity)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		old             T
+		existed, stored bool
+	)
+	if s, ok := c.cache.(groupedSwapper[T]); ok {
+		old, existed, stored = s.swap(groupID, id, entity)
+	} else {
+		old, existed = c.cache.Get(groupID, id)
+		c.cache.Put(groupID, id, entity)
+		entity, stored = c.cache.Get(groupID, id)
+	}
+	if change, changed := putChange(old, existed, entity, stored); changed {
+		change.GroupID = groupID
+		c.observers.notify(change)
+	}
+}
+
+func (c *observableGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entity, ok := c.cache.Remove(groupID, id)
+	if ok && c.observers.active() {
+		c.observers.notify(Change[T]{Type: ChangeTypeRemove, GroupID: groupID, Old: entity})
+	}
+	return entity, ok
+}
+
+func (c *observableGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	if !c.observers.active() {
+		c.cache.GroupRemove(groupID)
+		return
+	}
+	var removed []T
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.GroupForEach(groupID, func(entity T) {
+		removed = append(removed, entity)
+	})
+	c.cache.GroupRemove(groupID)
+	for _, entity := range removed {
+		c.observers.notify(Change[T]{Type: ChangeTypeRemove, GroupID: groupID, Old: entity})
+	}
+}
+
+func (c *observableGroupedCache[T]) RemoveIf(filterFunc GroupedFilterFunc[T]) {
+	if !c.observers.active() {
+		c.cache.RemoveIf(filterFunc)
+		return
+	}
+	var removed []Change[T]
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.RemoveIf(func(groupID snowflake.ID, entity T) bool {
+		if filterFunc(groupID, entity) {
+			removed = append(removed, Change[T]{Type: ChangeTypeRemove, GroupID: groupID, Old: entity})
+			return true
+		}
+		return false
+	})
+	for _, change := range removed {
+		c.observers.notify(change)
+	}
+}
+
+func (c *observableGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc GroupedFilterFunc[T]) {
+	if !c.observers.active() {
+		c.cache.GroupRemoveIf(groupID, filterFunc)
+		return
+	}
+	var removed []T
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache.GroupRemoveIf(groupID, func(groupID snowflake.ID, entity T) bool {
+		if filterFunc(groupID, entity) {
+			removed = append(removed, entity)
+			return true
+		}
+		return false
+	})
+	for _, entity := range removed {
+		c.observers.notify(Change[T]{Type: ChangeTypeRemove, GroupID: groupID, Old: entity})
+	}
+}
+
+func (c *observableGroupedCache[T]) Len() int {
+	return c.cache.Len()
+}
+
+func (c *observableGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	return c.cache.GroupLen(groupID)
+}
+
+func (c *observableGroupedCache[T]) ForEach(forEachFunc func(groupID snowflake.ID, entity T)) {
+	c.cache.ForEach(forEachFunc)
+}
+
+func (c *observableGroupedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
+	c.cache.GroupForEach(groupID, forEachFunc)
+}
+
+// putChange returns the Change of a put from the entity before & after it.
+// Nothing changed if the entity was rejected by the Flags or Policy of the cache.
+func putChange[T any](old T, existed bool, current T, stored bool) (Change[T], bool) {
+	if !stored {
+		return Change[T]{}, false
+	}
+	if !existed {
+		return Change[T]{Type: ChangeTypePut, New: current}, true
+	}
+	return Change[T]{Type: ChangeTypeUpdate, Old: old, New: current}, true
+}
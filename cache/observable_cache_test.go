@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestCachesObserver(t *testing.T) {
+	caches := New(WithCaches(FlagRoles))
+
+	var changes []Change[discord.Role]
+	remove, err := caches.AddRoleObserver(func(change Change[discord.Role]) {
+		changes = append(changes, change)
+	})
+	require.NoError(t, err)
+
+	caches.AddRole(discord.Role{ID: 1, GuildID: 10, Name: "a"})
+	caches.AddRole(discord.Role{ID: 1, GuildID: 10, Name: "b"})
+	caches.AddRole(discord.Role{ID: 2, GuildID: 10, Name: "c"})
+	caches.RemoveRolesByGuildID(10)
+
+	if assert.Len(t, changes, 5) {
+		assert.Equal(t, ChangeTypePut, changes[0].Type)
+		assert.Equal(t, "a", changes[0].New.Name)
+		assert.Equal(t, ChangeTypeUpdate, changes[1].Type)
+		assert.Equal(t, "a", changes[1].Old.Name)
+		assert.Equal(t, "b", changes[1].New.Name)
+		assert.Equal(t, ChangeTypeRemove, changes[3].Type)
+		assert.Equal(t, ChangeTypeRemove, changes[4].Type)
+	}
+
+	remove()
+	caches.AddRole(discord.Role{ID: 3, GuildID: 10})
+	assert.Len(t, changes, 5)
+
+	// the member cache is disabled, so puts are rejected and not reported
+	_, err = caches.AddMemberObserver(func(change Change[discord.Member]) {
+		t.Fatal("unexpected member change")
+	})
+	require.NoError(t, err)
+	caches.AddMember(discord.Member{GuildID: 10})
+
+	caches = New(WithCaches(FlagGuilds), WithGuildCache(NewGuildCache(NewCache[discord.Guild](FlagGuilds, FlagGuilds, nil), NewSet[snowflake.ID](), NewSet[snowflake.ID]())))
+	_, err = caches.AddGuildObserver(func(change Change[discord.Guild]) {})
+	assert.ErrorIs(t, err, ErrCacheNotObservable)
+}
+
+func TestObservableCache_Policy(t *testing.T) {
+	cache := NewObservableCache(NewCache[discord.Role](FlagRoles, FlagRoles, func(role discord.Role) bool {
+		return role.Name != "rejected"
+	}))
+
+	var changes []Change[discord.Role]
+	cache.AddObserver(func(change Change[discord.Role]) {
+		changes = append(changes, change)
+	})
+
+	cache.Put(1, discord.Role{ID: 1, Name: "a"})
+	cache.Put(1, discord.Role{ID: 1, Name: "rejected"})
+
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, ChangeTypePut, changes[0].Type)
+	}
+}
+
+func TestObservableCache_Evictions(t *testing.T) {
+	var evicted []snowflake.ID
+	cache := NewObservableCache(NewEvictingCache[discord.Role](FlagRoles, FlagRoles, nil, func(_ snowflake.ID, id snowflake.ID, _ discord.Role, _ EvictionReason) {
+		evicted = append(evicted, id)
+	}, WithMaxSize(1)))
+
+	cache.Put(1, discord.Role{ID: 1})
+	cache.Put(2, discord.Role{ID: 2})
+
+	assert.Equal(t, uint64(1), cache.Stats().Evictions)
+	assert.Equal(t, []snowflake.ID{1}, evicted)
+}
+
+func TestCachesStats(t *testing.T) {
+	caches := New(WithCaches(FlagMessages), WithMessageCacheEviction(nil, WithMaxGroupSize(1)))
+
+	caches.AddMessage(discord.Message{ID: 1, ChannelID: 10})
+	caches.AddMessage(discord.Message{ID: 2, ChannelID: 10})
+	caches.Message(10, 2)
+	caches.Message(10, 1)
+
+	assert.Equal(t, Stats{Hits: 1, Misses: 1, Evictions: 1, Size: 1}, caches.Stats()[FlagMessages])
+}
@@ -0,0 +1,141 @@
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/cache"
+)
+
+var _ cache.Cache[any] = (*redisCache[any])(nil)
+
+// NewCache returns a cache.Cache which stores all entities in a single hash at the given key.
+// The entities are filtered after the given cache.Flags and cache.Policy like in cache.NewCache.
+//
+// As the cache.Cache interface does not return errors, errors are logged with the Logger of the Client
+// and the entity is treated as not found.
+func NewCache[T any](client Client, key string, serializer Serializer[T], flags cache.Flags, neededFlags cache.Flags, policy cache.Policy[T]) cache.Cache[T] {
+	return &redisCache[T]{
+		client:      client,
+		key:         key,
+		serializer:  serializer,
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+	}
+}
+
+type redisCache[T any] struct {
+	client      Client
+	key         string
+	serializer  Serializer[T]
+	flags       cache.Flags
+	neededFlags cache.Flags
+	policy      cache.Policy[T]
+}
+
+func (c *redisCache[T]) do(args ...string) (any, bool) {
+	return do(c.client, c.key, args...)
+}
+
+func (c *redisCache[T]) Get(id snowflake.ID) (T, bool) {
+	reply, ok := c.do("HGET", c.key, id.String())
+	if !ok {
+		var entity T
+		return entity, false
+	}
+	return deserialize(c.client, c.key, c.serializer, reply)
+}
+
+func (c *redisCache[T]) Put(id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	data, err := c.serializer.Serialize(entity)
+	if err != nil {
+		c.client.Logger().Error("failed to serialize entity", slog.Any("err", err), slog.String("key", c.key), slog.String("id", id.String()))
+		return
+	}
+	c.do("HSET", c.key, id.String(), string(data))
+}
+
+func (c *redisCache[T]) Remove(id snowflake.ID) (T, bool) {
+	entity, ok := c.Get(id)
+	if !ok {
+		return entity, false
+	}
+	reply, _ := c.do("HDEL", c.key, id.String())
+	return entity, replyInt(reply) > 0
+}
+
+func (c *redisCache[T]) RemoveIf(filterFunc cache.FilterFunc[T]) {
+	var ids []string
+	c.forEach(func(id string, entity T) {
+		if filterFunc(entity) {
+			ids = append(ids, id)
+		}
+	})
+	if len(ids) > 0 {
+		c.do(append([]string{"HDEL", c.key}, ids...)...)
+	}
+}
+
+func (c *redisCache[T]) Len() int {
+	reply, _ := c.do("HLEN", c.key)
+	return replyInt(reply)
+}
+
+func (c *redisCache[T]) ForEach(forEachFunc func(entity T)) {
+	c.forEach(func(_ string, entity T) {
+		forEachFunc(entity)
+	})
+}
+
+func (c *redisCache[T]) forEach(forEachFunc func(id string, entity T)) {
+	reply, ok := c.do("HGETALL", c.key)
+	if !ok {
+		return
+	}
+	hashForEach(c.client, c.key, c.serializer, reply, forEachFunc)
+}
+
+// do runs the command and logs any error except nil replies.
+func do(client Client, key string, args ...string) (any, bool) {
+	reply, err := client.Do(context.Background(), args...)
+	if errors.Is(err, ErrNil) {
+		return nil, false
+	}
+	if err != nil {
+		client.Logger().Error("failed to run command", slog.Any("err", err), slog.String("command", args[0]), slog.String("key", key))
+		return nil, false
+	}
+	return reply, true
+}
+
+func deserialize[T any](client Client, key string, serializer Serializer[T], reply any) (T, bool) {
+	data, _ := reply.([]byte)
+	entity, err := serializer.Deserialize(data)
+	if err != nil {
+		client.Logger().Error("failed to deserialize entity", slog.Any("err", err), slog.String("key", key))
+		return entity, false
+	}
+	return entity, true
+}
+
+// hashForEach calls forEachFunc for each field & deserialized value of a HGETALL reply.
+func hashForEach[T any](client Client, key string, serializer Serializer[T], reply any, forEachFunc func(field string, entity T)) {
+	values := replyBytesSlice(reply)
+	for i := 0; i+1 < len(values); i += 2 {
+		entity, ok := deserialize(client, key, serializer, values[i+1])
+		if !ok {
+			continue
+		}
+		forEachFunc(string(values[i]), entity)
+	}
+}
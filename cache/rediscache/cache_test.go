@@ -0,0 +1,139 @@
+package rediscache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+)
+
+func newTestClient(t *testing.T) Client {
+	server, err := NewFakeServer()
+	require.NoError(t, err)
+	client := NewClient(server.Addr())
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+	return client
+}
+
+func TestCache(t *testing.T) {
+	client := newTestClient(t)
+	c := NewCache[discord.GuildChannel](client, "channels", GuildChannelSerializer(), cache.FlagsAll, cache.FlagChannels, nil)
+
+	for _, data := range []string{
+		`{"id":"1","type":0,"name":"general","guild_id":"10"}`,
+		`{"id":"2","type":2,"name":"voice","guild_id":"10"}`,
+	} {
+		var v discord.UnmarshalChannel
+		require.NoError(t, json.Unmarshal([]byte(data), &v))
+		c.Put(v.ID(), v.Channel.(discord.GuildChannel))
+	}
+	assert.Equal(t, 2, c.Len())
+
+	channel, ok := c.Get(1)
+	require.True(t, ok)
+	textChannel, ok := channel.(discord.GuildTextChannel)
+	require.True(t, ok)
+	assert.Equal(t, "general", textChannel.Name())
+	assert.Equal(t, snowflake.ID(10), textChannel.GuildID())
+
+	_, ok = c.Get(3)
+	assert.False(t, ok)
+
+	c.RemoveIf(func(channel discord.GuildChannel) bool {
+		return channel.Type() == discord.ChannelTypeGuildVoice
+	})
+	assert.Equal(t, 1, c.Len())
+
+	_, ok = c.Remove(1)
+	assert.True(t, ok)
+	_, ok = c.Remove(1)
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestGroupedCache(t *testing.T) {
+	client := newTestClient(t)
+	c := NewGroupedCache[discord.Role](client, "roles", JSONSerializer[discord.Role](), cache.FlagsAll, cache.FlagRoles, nil)
+
+	c.Put(10, 1, discord.Role{ID: 1, Name: "a", GuildID: 10})
+	c.Put(10, 2, discord.Role{ID: 2, Name: "b", GuildID: 10})
+	c.Put(20, 3, discord.Role{ID: 3, Name: "c", GuildID: 20})
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, 2, c.GroupLen(10))
+
+	role, ok := c.Get(10, 2)
+	require.True(t, ok)
+	assert.Equal(t, "b", role.Name)
+
+	var names []string
+	c.GroupForEach(20, func(role discord.Role) {
+		names = append(names, role.Name)
+	})
+	assert.Equal(t, []string{"c"}, names)
+
+	c.GroupRemove(10)
+	assert.Equal(t, 1, c.Len())
+	_, ok = c.Get(10, 1)
+	assert.False(t, ok)
+}
+
+func TestCachePolicy(t *testing.T) {
+	client := newTestClient(t)
+	c := NewCache[discord.Guild](client, "guilds", JSONSerializer[discord.Guild](), cache.FlagsNone, cache.FlagGuilds, nil)
+
+	c.Put(1, discord.Guild{ID: 1})
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestSet(t *testing.T) {
+	client := newTestClient(t)
+	s := NewSet(client, "unready")
+
+	s.Add(1)
+	s.Add(2)
+	s.Add(2)
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Has(1))
+
+	s.Remove(1)
+	assert.False(t, s.Has(1))
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+}
+
+func TestClient_CloseConcurrent(t *testing.T) {
+	client := newTestClient(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := client.Do(context.Background(), "SADD", "set", strconv.Itoa(j)); errors.Is(err, ErrClientClosed) {
+					return
+				}
+			}
+		}()
+	}
+	time.Sleep(time.Millisecond)
+	assert.NoError(t, client.Close())
+	wg.Wait()
+
+	_, err := client.Do(context.Background(), "SADD", "set", "1")
+	assert.ErrorIs(t, err, ErrClientClosed)
+}
@@ -0,0 +1,295 @@
+// Package rediscache implements cache.Cache, cache.GroupedCache and cache.Set on top of a Redis compatible server speaking the RESP protocol.
+// This allows multiple processes to share one gateway fed cache.
+//
+// The caches are plugged into cache.New like any other implementation:
+//
+//	client := rediscache.NewClient("localhost:6379")
+//	caches := cache.New(
+//		cache.WithGuildCache(cache.NewGuildCache(
+//			rediscache.NewCache(client, "guilds", rediscache.JSONSerializer[discord.Guild](), cache.FlagsAll, cache.FlagGuilds, nil),
+//			rediscache.NewSet(client, "guilds:unready"),
+//			rediscache.NewSet(client, "guilds:unavailable"),
+//		)),
+//		cache.WithChannelCache(cache.NewChannelCache(
+//			rediscache.NewCache(client, "channels", rediscache.GuildChannelSerializer(), cache.FlagsAll, cache.FlagChannels, nil),
+//		)),
+//		cache.WithMemberCache(cache.NewMemberCache(
+//			rediscache.NewGroupedCache(client, "members", rediscache.JSONSerializer[discord.Member](), cache.FlagsAll, cache.FlagMembers, nil),
+//		)),
+//	)
+package rediscache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNil is returned when the server replies with a nil bulk string or array.
+	ErrNil = errors.New("rediscache: nil reply")
+	// ErrClientClosed is returned when a command is sent with a closed Client.
+	ErrClientClosed = errors.New("rediscache: client closed")
+)
+
+// Error is an error reply sent by the server.
+type Error string
+
+func (e Error) Error() string {
+	return "rediscache: " + string(e)
+}
+
+// Client is a minimal RESP2 client with a connection pool.
+type Client interface {
+	// Do sends the command with the given args and returns the reply.
+	// The reply is either nil, string, int64, []byte, []any or an Error.
+	Do(ctx context.Context, args ...string) (any, error)
+
+	// Logger returns the logger used to report errors which can't be returned, like in cache.Cache.Put.
+	Logger() *slog.Logger
+
+	// Close closes all idle connections. Connections which are in use are closed once their command finished.
+	// Do returns ErrClientClosed after the Client was closed.
+	Close() error
+}
+
+// NewClient returns a new Client for the server at the given address with the given ClientConfigOpt(s).
+func NewClient(address string, opts ...ClientConfigOpt) Client {
+	config := DefaultClientConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "rediscache"))
+
+	return &clientImpl{
+		address: address,
+		config:  *config,
+	}
+}
+
+type clientImpl struct {
+	address string
+	config  ClientConfig
+
+	mu     sync.Mutex
+	idle   []*conn
+	closed bool
+}
+
+type conn struct {
+	netConn net.Conn
+	r       *bufio.Reader
+	w       *bufio.Writer
+}
+
+func (c *clientImpl) Logger() *slog.Logger {
+	return c.config.Logger
+}
+
+func (c *clientImpl) dial(ctx context.Context) (*conn, error) {
+	netConn, err := c.config.Dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return nil, err
+	}
+	cn := &conn{
+		netConn: netConn,
+		r:       bufio.NewReader(netConn),
+		w:       bufio.NewWriter(netConn),
+	}
+	if c.config.Password != "" {
+		if _, err = cn.do(ctx, c.config.Timeout, "AUTH", c.config.Password); err != nil {
+			_ = netConn.Close()
+			return nil, err
+		}
+	}
+	if c.config.DB != 0 {
+		if _, err = cn.do(ctx, c.config.Timeout, "SELECT", strconv.Itoa(c.config.DB)); err != nil {
+			_ = netConn.Close()
+			return nil, err
+		}
+	}
+	return cn, nil
+}
+
+func (c *clientImpl) get(ctx context.Context) (*conn, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, ErrClientClosed
+	}
+	if n := len(c.idle); n > 0 {
+		cn := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return cn, nil
+	}
+	c.mu.Unlock()
+	return c.dial(ctx)
+}
+
+func (c *clientImpl) put(cn *conn) {
+	c.mu.Lock()
+	if c.closed || len(c.idle) >= c.config.MaxIdleConns {
+		c.mu.Unlock()
+		_ = cn.netConn.Close()
+		return
+	}
+	c.idle = append(c.idle, cn)
+	c.mu.Unlock()
+}
+
+func (c *clientImpl) Do(ctx context.Context, args ...string) (any, error) {
+	cn, err := c.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := cn.do(ctx, c.config.Timeout, args...)
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) && !errors.Is(err, ErrNil) {
+		// the connection is in an unknown state, don't reuse it
+		_ = cn.netConn.Close()
+		return nil, err
+	}
+	c.put(cn)
+	return reply, err
+}
+
+func (c *clientImpl) Close() error {
+	c.mu.Lock()
+	idle := c.idle
+	c.idle = nil
+	c.closed = true
+	c.mu.Unlock()
+
+	for _, cn := range idle {
+		_ = cn.netConn.Close()
+	}
+	return nil
+}
+
+func (cn *conn) do(ctx context.Context, timeout time.Duration, args ...string) (any, error) {
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := cn.netConn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if err := writeCommand(cn.w, args); err != nil {
+		return nil, err
+	}
+	if err := cn.w.Flush(); err != nil {
+		return nil, err
+	}
+	reply, err := readReply(cn.r)
+	if err != nil {
+		return nil, err
+	}
+	switch r := reply.(type) {
+	case Error:
+		return nil, r
+	case nil:
+		return nil, ErrNil
+	}
+	return reply, nil
+}
+
+func writeCommand(w *bufio.Writer, args []string) error {
+	if _, err := fmt.Fprintf(w, "*%d\r\n", len(args)); err != nil {
+		return err
+	}
+	for _, arg := range args {
+		if _, err := fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("rediscache: invalid line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
+
+// readReply reads a single RESP2 reply. Nil bulk strings and arrays are returned as nil.
+func readReply(r *bufio.Reader) (any, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return nil, err
+	}
+	if line == "" {
+		return nil, errors.New("rediscache: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+
+	case '-':
+		return Error(line[1:]), nil
+
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err = io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return data[:n], nil
+
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		values := make([]any, n)
+		for i := range values {
+			if values[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+
+	default:
+		return nil, fmt.Errorf("rediscache: unknown reply type %q", line[0])
+	}
+}
+
+func replyInt(reply any) int {
+	i, _ := reply.(int64)
+	return int(i)
+}
+
+func replyBytesSlice(reply any) [][]byte {
+	values, _ := reply.([]any)
+	b := make([][]byte, 0, len(values))
+	for _, value := range values {
+		if v, ok := value.([]byte); ok {
+			b = append(b, v)
+		}
+	}
+	return b
+}
@@ -0,0 +1,79 @@
+package rediscache
+
+import (
+	"log/slog"
+	"net"
+	"time"
+)
+
+// DefaultClientConfig returns a ClientConfig with sensible defaults.
+func DefaultClientConfig() *ClientConfig {
+	return &ClientConfig{
+		Logger:       slog.Default(),
+		Dialer:       &net.Dialer{Timeout: 5 * time.Second},
+		MaxIdleConns: 10,
+		Timeout:      5 * time.Second,
+	}
+}
+
+// ClientConfig lets you configure your Client instance.
+type ClientConfig struct {
+	Logger       *slog.Logger
+	Dialer       *net.Dialer
+	Password     string
+	DB           int
+	MaxIdleConns int
+	Timeout      time.Duration
+}
+
+// ClientConfigOpt is a type alias for a function that takes a ClientConfig and is used to configure your Client.
+type ClientConfigOpt func(config *ClientConfig)
+
+// Apply applies the given ClientConfigOpt(s) to the ClientConfig
+func (c *ClientConfig) Apply(opts []ClientConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger of the ClientConfig.
+func WithLogger(logger *slog.Logger) ClientConfigOpt {
+	return func(config *ClientConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithDialer sets the net.Dialer of the ClientConfig.
+func WithDialer(dialer *net.Dialer) ClientConfigOpt {
+	return func(config *ClientConfig) {
+		config.Dialer = dialer
+	}
+}
+
+// WithPassword sets the password which is sent with AUTH on each new connection.
+func WithPassword(password string) ClientConfigOpt {
+	return func(config *ClientConfig) {
+		config.Password = password
+	}
+}
+
+// WithDB sets the database which is selected with SELECT on each new connection.
+func WithDB(db int) ClientConfigOpt {
+	return func(config *ClientConfig) {
+		config.DB = db
+	}
+}
+
+// WithMaxIdleConns sets the maximum number of idle connections kept in the pool.
+func WithMaxIdleConns(maxIdleConns int) ClientConfigOpt {
+	return func(config *ClientConfig) {
+		config.MaxIdleConns = maxIdleConns
+	}
+}
+
+// WithTimeout sets the timeout of a single command.
+func WithTimeout(timeout time.Duration) ClientConfigOpt {
+	return func(config *ClientConfig) {
+		config.Timeout = timeout
+	}
+}
@@ -0,0 +1,273 @@
+package rediscache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// FakeServer is an in-process server speaking the RESP protocol.
+// It supports the commands used by this package and is meant to be used in tests.
+type FakeServer interface {
+	// Addr returns the address the server listens on, which can be passed to NewClient.
+	Addr() string
+
+	// Close stops the server and closes all connections.
+	Close() error
+}
+
+// NewFakeServer starts a new FakeServer listening on a random local port.
+func NewFakeServer() (FakeServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &fakeServer{
+		listener: listener,
+		hashes:   map[string]map[string]string{},
+		sets:     map[string]map[string]struct{}{},
+		conns:    map[net.Conn]struct{}{},
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+type fakeServer struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu     sync.Mutex
+	hashes map[string]map[string]string
+	sets   map[string]map[string]struct{}
+	conns  map[net.Conn]struct{}
+}
+
+func (s *fakeServer) Addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeServer) Close() error {
+	err := s.listener.Close()
+	s.mu.Lock()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *fakeServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.handleConn(conn)
+	}
+}
+
+func (s *fakeServer) handleConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		request, err := readReply(r)
+		if err != nil {
+			return
+		}
+		values, ok := request.([]any)
+		if !ok || len(values) == 0 {
+			writeFakeReply(w, errors.New("ERR invalid request"))
+		} else {
+			args := make([]string, len(values))
+			for i, value := range values {
+				b, _ := value.([]byte)
+				args[i] = string(b)
+			}
+			writeFakeReply(w, s.exec(args))
+		}
+		if err = w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+type fakeStatus string
+
+func (s *fakeServer) exec(args []string) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cmd := strings.ToUpper(args[0])
+	args = args[1:]
+	switch cmd {
+	case "PING":
+		return fakeStatus("PONG")
+
+	case "AUTH", "SELECT":
+		return fakeStatus("OK")
+
+	case "DEL":
+		var n int64
+		for _, key := range args {
+			if _, ok := s.hashes[key]; ok {
+				n++
+			}
+			if _, ok := s.sets[key]; ok {
+				n++
+			}
+			delete(s.hashes, key)
+			delete(s.sets, key)
+		}
+		return n
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("ERR wrong number of arguments for '%s' command", strings.ToLower(cmd))
+	}
+	key := args[0]
+	args = args[1:]
+
+	switch cmd {
+	case "HGET":
+		if len(args) != 1 {
+			return errors.New("ERR wrong number of arguments for 'hget' command")
+		}
+		value, ok := s.hashes[key][args[0]]
+		if !ok {
+			return nil
+		}
+		return []byte(value)
+
+	case "HSET":
+		if len(args) == 0 || len(args)%2 != 0 {
+			return errors.New("ERR wrong number of arguments for 'hset' command")
+		}
+		hash, ok := s.hashes[key]
+		if !ok {
+			hash = map[string]string{}
+			s.hashes[key] = hash
+		}
+		var n int64
+		for i := 0; i < len(args); i += 2 {
+			if _, ok = hash[args[i]]; !ok {
+				n++
+			}
+			hash[args[i]] = args[i+1]
+		}
+		return n
+
+	case "HDEL":
+		hash := s.hashes[key]
+		var n int64
+		for _, field := range args {
+			if _, ok := hash[field]; ok {
+				delete(hash, field)
+				n++
+			}
+		}
+		if hash != nil && len(hash) == 0 {
+			delete(s.hashes, key)
+		}
+		return n
+
+	case "HLEN":
+		return int64(len(s.hashes[key]))
+
+	case "HGETALL":
+		values := make([]any, 0, len(s.hashes[key])*2)
+		for field, value := range s.hashes[key] {
+			values = append(values, []byte(field), []byte(value))
+		}
+		return values
+
+	case "SADD":
+		set, ok := s.sets[key]
+		if !ok {
+			set = map[string]struct{}{}
+			s.sets[key] = set
+		}
+		var n int64
+		for _, member := range args {
+			if _, ok = set[member]; !ok {
+				set[member] = struct{}{}
+				n++
+			}
+		}
+		return n
+
+	case "SREM":
+		set := s.sets[key]
+		var n int64
+		for _, member := range args {
+			if _, ok := set[member]; ok {
+				delete(set, member)
+				n++
+			}
+		}
+		if set != nil && len(set) == 0 {
+			delete(s.sets, key)
+		}
+		return n
+
+	case "SISMEMBER":
+		if len(args) != 1 {
+			return errors.New("ERR wrong number of arguments for 'sismember' command")
+		}
+		if _, ok := s.sets[key][args[0]]; ok {
+			return int64(1)
+		}
+		return int64(0)
+
+	case "SCARD":
+		return int64(len(s.sets[key]))
+
+	case "SMEMBERS":
+		values := make([]any, 0, len(s.sets[key]))
+		for member := range s.sets[key] {
+			values = append(values, []byte(member))
+		}
+		return values
+
+	default:
+		return fmt.Errorf("ERR unknown command '%s'", strings.ToLower(cmd))
+	}
+}
+
+func writeFakeReply(w *bufio.Writer, reply any) {
+	switch r := reply.(type) {
+	case nil:
+		_, _ = w.WriteString("$-1\r\n")
+	case fakeStatus:
+		_, _ = fmt.Fprintf(w, "+%s\r\n", r)
+	case error:
+		_, _ = fmt.Fprintf(w, "-%s\r\n", r)
+	case int64:
+		_, _ = fmt.Fprintf(w, ":%d\r\n", r)
+	case []byte:
+		_, _ = fmt.Fprintf(w, "$%d\r\n%s\r\n", len(r), r)
+	case []any:
+		_, _ = fmt.Fprintf(w, "*%d\r\n", len(r))
+		for _, value := range r {
+			writeFakeReply(w, value)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package rediscache
+
+import (
+	"log/slog"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/cache"
+)
+
+var _ cache.GroupedCache[any] = (*redisGroupedCache[any])(nil)
+
+// NewGroupedCache returns a cache.GroupedCache which stores the entities of each group in a hash at "{key}:{groupID}"
+// and keeps track of all groups in a set at "{key}:groups".
+// The entities are filtered after the given cache.Flags and cache.Policy like in cache.NewGroupedCache.
+//
+// As the cache.GroupedCache interface does not return errors, errors are logged with the Logger of the Client
+// and the entity is treated as not found.
+func NewGroupedCache[T any](client Client, key string, serializer Serializer[T], flags cache.Flags, neededFlags cache.Flags, policy cache.Policy[T]) cache.GroupedCache[T] {
+	return &redisGroupedCache[T]{
+		client:      client,
+		key:         key,
+		serializer:  serializer,
+		flags:       flags,
+		neededFlags: neededFlags,
+		policy:      policy,
+	}
+}
+
+type redisGroupedCache[T any] struct {
+	client      Client
+	key         string
+	serializer  Serializer[T]
+	flags       cache.Flags
+	neededFlags cache.Flags
+	policy      cache.Policy[T]
+}
+
+func (c *redisGroupedCache[T]) groupsKey() string {
+	return c.key + ":groups"
+}
+
+func (c *redisGroupedCache[T]) groupKey(groupID snowflake.ID) string {
+	return c.key + ":" + groupID.String()
+}
+
+func (c *redisGroupedCache[T]) groupIDs() []snowflake.ID {
+	reply, ok := do(c.client, c.groupsKey(), "SMEMBERS", c.groupsKey())
+	if !ok {
+		return nil
+	}
+	values := replyBytesSlice(reply)
+	groupIDs := make([]snowflake.ID, 0, len(values))
+	for _, value := range values {
+		groupID, err := snowflake.Parse(string(value))
+		if err != nil {
+			continue
+		}
+		groupIDs = append(groupIDs, groupID)
+	}
+	return groupIDs
+}
+
+func (c *redisGroupedCache[T]) Get(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	key := c.groupKey(groupID)
+	reply, ok := do(c.client, key, "HGET", key, id.String())
+	if !ok {
+		var entity T
+		return entity, false
+	}
+	return deserialize(c.client, key, c.serializer, reply)
+}
+
+func (c *redisGroupedCache[T]) Put(groupID snowflake.ID, id snowflake.ID, entity T) {
+	if c.flags.Missing(c.neededFlags) {
+		return
+	}
+	if c.policy != nil && !c.policy(entity) {
+		return
+	}
+	key := c.groupKey(groupID)
+	data, err := c.serializer.Serialize(entity)
+	if err != nil {
+		c.client.Logger().Error("failed to serialize entity", slog.Any("err", err), slog.String("key", key), slog.String("id", id.String()))
+		return
+	}
+	if _, ok := do(c.client, key, "HSET", key, id.String(), string(data)); !ok {
+		return
+	}
+	do(c.client, c.groupsKey(), "SADD", c.groupsKey(), groupID.String())
+}
+
+func (c *redisGroupedCache[T]) Remove(groupID snowflake.ID, id snowflake.ID) (T, bool) {
+	entity, ok := c.Get(groupID, id)
+	if !ok {
+		return entity, false
+	}
+	key := c.groupKey(groupID)
+	reply, _ := do(c.client, key, "HDEL", key, id.String())
+	return entity, replyInt(reply) > 0
+}
+
+func (c *redisGroupedCache[T]) GroupRemove(groupID snowflake.ID) {
+	key := c.groupKey(groupID)
+	do(c.client, key, "DEL", key)
+	do(c.client, c.groupsKey(), "SREM", c.groupsKey(), groupID.String())
+}
+
+func (c *redisGroupedCache[T]) RemoveIf(filterFunc cache.GroupedFilterFunc[T]) {
+	for _, groupID := range c.groupIDs() {
+		c.GroupRemoveIf(groupID, filterFunc)
+	}
+}
+
+func (c *redisGroupedCache[T]) GroupRemoveIf(groupID snowflake.ID, filterFunc cache.GroupedFilterFunc[T]) {
+	var ids []string
+	c.groupForEach(groupID, func(id string, entity T) {
+		if filterFunc(groupID, entity) {
+			ids = append(ids, id)
+		}
+	})
+	if len(ids) > 0 {
+		key := c.groupKey(groupID)
+		do(c.client, key, append([]string{"HDEL", key}, ids...)...)
+	}
+}
+
+func (c *redisGroupedCache[T]) Len() int {
+	var totalLen int
+	for _, groupID := range c.groupIDs() {
+		totalLen += c.GroupLen(groupID)
+	}
+	return totalLen
+}
+
+func (c *redisGroupedCache[T]) GroupLen(groupID snowflake.ID) int {
+	key := c.groupKey(groupID)
+	reply, _ := do(c.client, key, "HLEN", key)
+	return replyInt(reply)
+}
+
+func (c *redisGroupedCache[T]) ForEach(forEachFunc func(groupID snowflake.ID, entity T)) {
+	for _, groupID := range c.groupIDs() {
+		c.groupForEach(groupID, func(_ string, entity T) {
+			forEachFunc(groupID, entity)
+		})
+	}
+}
+
+func (c *redisGroupedCache[T]) GroupForEach(groupID snowflake.ID, forEachFunc func(entity T)) {
+	c.groupForEach(groupID, func(_ string, entity T) {
+		forEachFunc(entity)
+	})
+}
+
+func (c *redisGroupedCache[T]) groupForEach(groupID snowflake.ID, forEachFunc func(id string, entity T)) {
+	key := c.groupKey(groupID)
+	reply, ok := do(c.client, key, "HGETALL", key)
+	if !ok {
+		return
+	}
+	hashForEach(c.client, key, c.serializer, reply, forEachFunc)
+}
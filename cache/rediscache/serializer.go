@@ -0,0 +1,63 @@
+package rediscache
+
+import (
+	"fmt"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// Serializer converts entities to and from the bytes stored in the server.
+type Serializer[T any] interface {
+	// Serialize returns the stored representation of the entity.
+	Serialize(entity T) ([]byte, error)
+
+	// Deserialize returns the entity from its stored representation.
+	Deserialize(data []byte) (T, error)
+}
+
+var _ Serializer[any] = (*jsonSerializer[any])(nil)
+
+// JSONSerializer returns a Serializer which stores entities as JSON.
+// This works for all discord entities which are not interfaces. Use GuildChannelSerializer for discord.GuildChannel.
+func JSONSerializer[T any]() Serializer[T] {
+	return jsonSerializer[T]{}
+}
+
+type jsonSerializer[T any] struct{}
+
+func (jsonSerializer[T]) Serialize(entity T) ([]byte, error) {
+	return json.Marshal(entity)
+}
+
+func (jsonSerializer[T]) Deserialize(data []byte) (T, error) {
+	var entity T
+	err := json.Unmarshal(data, &entity)
+	return entity, err
+}
+
+var _ Serializer[discord.GuildChannel] = (*guildChannelSerializer)(nil)
+
+// GuildChannelSerializer returns a Serializer which stores discord.GuildChannel(s) as JSON and restores the concrete channel type.
+func GuildChannelSerializer() Serializer[discord.GuildChannel] {
+	return guildChannelSerializer{}
+}
+
+type guildChannelSerializer struct{}
+
+func (guildChannelSerializer) Serialize(channel discord.GuildChannel) ([]byte, error) {
+	return json.Marshal(channel)
+}
+
+func (guildChannelSerializer) Deserialize(data []byte) (discord.GuildChannel, error) {
+	var v discord.UnmarshalChannel
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	channel, ok := v.Channel.(discord.GuildChannel)
+	if !ok {
+		return nil, fmt.Errorf("rediscache: channel of type %d is not a guild channel", v.Channel.Type())
+	}
+	return channel, nil
+}
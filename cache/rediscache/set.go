@@ -0,0 +1,59 @@
+package rediscache
+
+import (
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/cache"
+)
+
+var _ cache.Set[snowflake.ID] = (*redisSet)(nil)
+
+// NewSet returns a cache.Set of snowflake.ID(s) which is stored in a set at the given key.
+// It can be used for the unready & unavailable guilds of cache.NewGuildCache.
+func NewSet(client Client, key string) cache.Set[snowflake.ID] {
+	return &redisSet{
+		client: client,
+		key:    key,
+	}
+}
+
+type redisSet struct {
+	client Client
+	key    string
+}
+
+func (s *redisSet) Add(item snowflake.ID) {
+	do(s.client, s.key, "SADD", s.key, item.String())
+}
+
+func (s *redisSet) Remove(item snowflake.ID) {
+	do(s.client, s.key, "SREM", s.key, item.String())
+}
+
+func (s *redisSet) Has(item snowflake.ID) bool {
+	reply, _ := do(s.client, s.key, "SISMEMBER", s.key, item.String())
+	return replyInt(reply) == 1
+}
+
+func (s *redisSet) Len() int {
+	reply, _ := do(s.client, s.key, "SCARD", s.key)
+	return replyInt(reply)
+}
+
+func (s *redisSet) Clear() {
+	do(s.client, s.key, "DEL", s.key)
+}
+
+func (s *redisSet) ForEach(f func(item snowflake.ID)) {
+	reply, ok := do(s.client, s.key, "SMEMBERS", s.key)
+	if !ok {
+		return
+	}
+	for _, value := range replyBytesSlice(reply) {
+		item, err := snowflake.Parse(string(value))
+		if err != nil {
+			continue
+		}
+		f(item)
+	}
+}
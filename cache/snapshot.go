@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// SnapshotVersion is the version of the snapshot format written by WriteSnapshot.
+const SnapshotVersion = 1
+
+// ErrUnsupportedSnapshotVersion is returned by ReadSnapshot when the snapshot was written with a different SnapshotVersion.
+var ErrUnsupportedSnapshotVersion = errors.New("unsupported cache snapshot version")
+
+type snapshot struct {
+	Version           int                  `json:"version"`
+	CreatedAt         time.Time            `json:"created_at"`
+	SelfUser          *discord.OAuth2User  `json:"self_user,omitempty"`
+	Guilds            []discord.Guild      `json:"guilds"`
+	UnreadyGuilds     []snowflake.ID       `json:"unready_guilds"`
+	UnavailableGuilds []snowflake.ID       `json:"unavailable_guilds"`
+	Channels          []json.RawMessage    `json:"channels"`
+	Roles             []discord.Role       `json:"roles"`
+	Members           []discord.Member     `json:"members"`
+	Emojis            []discord.Emoji      `json:"emojis"`
+	Stickers          []discord.Sticker    `json:"stickers"`
+	VoiceStates       []discord.VoiceState `json:"voice_states"`
+}
+
+// WriteSnapshot writes the self user, guilds, unready & unavailable guilds, channels, roles, members, emojis, stickers & voice states of the Caches to w.
+// Roles, members, emojis, stickers & voice states are only written for guilds which are in the GuildCache or marked as unready or unavailable.
+func WriteSnapshot(w io.Writer, caches Caches) error {
+	s := snapshot{
+		Version:           SnapshotVersion,
+		CreatedAt:         time.Now(),
+		UnreadyGuilds:     caches.UnreadyGuildIDs(),
+		UnavailableGuilds: caches.UnavailableGuildIDs(),
+	}
+	if selfUser, ok := caches.SelfUser(); ok {
+		s.SelfUser = &selfUser
+	}
+
+	guildIDs := map[snowflake.ID]struct{}{}
+	caches.GuildsForEach(func(guild discord.Guild) {
+		s.Guilds = append(s.Guilds, guild)
+		guildIDs[guild.ID] = struct{}{}
+	})
+	for _, guildID := range s.UnreadyGuilds {
+		guildIDs[guildID] = struct{}{}
+	}
+	for _, guildID := range s.UnavailableGuilds {
+		guildIDs[guildID] = struct{}{}
+	}
+
+	var err error
+	caches.ChannelsForEach(func(channel discord.GuildChannel) {
+		if err != nil {
+			return
+		}
+		var data []byte
+		if data, err = json.Marshal(channel); err != nil {
+			err = fmt.Errorf("failed to marshal channel %s: %w", channel.ID(), err)
+			return
+		}
+		s.Channels = append(s.Channels, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	for guildID := range guildIDs {
+		caches.RolesForEach(guildID, func(role discord.Role) {
+			s.Roles = append(s.Roles, role)
+		})
+		caches.MembersForEach(guildID, func(member discord.Member) {
+			s.Members = append(s.Members, member)
+		})
+		caches.EmojisForEach(guildID, func(emoji discord.Emoji) {
+			s.Emojis = append(s.Emojis, emoji)
+		})
+		caches.StickersForEach(guildID, func(sticker discord.Sticker) {
+			s.Stickers = append(s.Stickers, sticker)
+		})
+		caches.VoiceStatesForEach(guildID, func(voiceState discord.VoiceState) {
+			s.VoiceStates = append(s.VoiceStates, voiceState)
+		})
+	}
+
+	return json.NewEncoder(w).Encode(s)
+}
+
+// ReadSnapshot reads a snapshot written by WriteSnapshot from r and adds all entities to the Caches.
+// The entities still go through the Flags & Policy of each cache.
+func ReadSnapshot(r io.Reader, caches Caches) error {
+	var s snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return fmt.Errorf("failed to decode cache snapshot: %w", err)
+	}
+	if s.Version != SnapshotVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, s.Version)
+	}
+
+	if s.SelfUser != nil {
+		caches.SetSelfUser(*s.SelfUser)
+	}
+	for _, guild := range s.Guilds {
+		caches.AddGuild(guild)
+	}
+	for _, guildID := range s.UnreadyGuilds {
+		caches.SetGuildUnready(guildID, true)
+	}
+	for _, guildID := range s.UnavailableGuilds {
+		caches.SetGuildUnavailable(guildID, true)
+	}
+	for _, data := range s.Channels {
+		var v discord.UnmarshalChannel
+		if err := json.Unmarshal(data, &v); err != nil {
+			return fmt.Errorf("failed to decode channel: %w", err)
+		}
+		channel, ok := v.Channel.(discord.GuildChannel)
+		if !ok {
+			continue
+		}
+		caches.AddChannel(channel)
+	}
+	for _, role := range s.Roles {
+		caches.AddRole(role)
+	}
+	for _, member := range s.Members {
+		caches.AddMember(member)
+	}
+	for _, emoji := range s.Emojis {
+		caches.AddEmoji(emoji)
+	}
+	for _, sticker := range s.Stickers {
+		caches.AddSticker(sticker)
+	}
+	for _, voiceState := range s.VoiceStates {
+		caches.AddVoiceState(voiceState)
+	}
+	return nil
+}
+
+// SaveSnapshot writes a snapshot of the Caches to the file at path.
+// The file is replaced atomically, so a crash while saving never leaves a partial snapshot behind.
+func SaveSnapshot(path string, caches Caches) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err = WriteSnapshot(f, caches); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// LoadSnapshot reads the snapshot at path into the Caches. A missing file is reported as an error matching os.ErrNotExist.
+func LoadSnapshot(path string, caches Caches) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return ReadSnapshot(f, caches)
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestSnapshot(t *testing.T) {
+	caches := New(WithCaches(FlagsAll))
+	caches.AddGuild(discord.Guild{ID: 1, Name: "guild", OwnerID: 2})
+	caches.SetGuildUnready(3, true)
+	caches.SetGuildUnavailable(4, true)
+	caches.AddRole(discord.Role{ID: 1, GuildID: 1, Permissions: discord.PermissionSendMessages})
+	caches.AddMember(discord.Member{GuildID: 1, User: discord.User{ID: 5}})
+	caches.AddEmoji(discord.Emoji{ID: 6, GuildID: 1})
+	caches.AddVoiceState(discord.VoiceState{GuildID: 1, UserID: 5})
+
+	var v discord.UnmarshalChannel
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"7","type":0,"name":"general","guild_id":"1"}`), &v))
+	caches.AddChannel(v.Channel.(discord.GuildChannel))
+
+	path := filepath.Join(t.TempDir(), "cache.json")
+	require.NoError(t, SaveSnapshot(path, caches))
+
+	restored := New(WithCaches(FlagsAll))
+	require.NoError(t, LoadSnapshot(path, restored))
+
+	guild, ok := restored.Guild(1)
+	require.True(t, ok)
+	assert.Equal(t, "guild", guild.Name)
+	assert.True(t, restored.IsGuildUnready(3))
+	assert.True(t, restored.IsGuildUnavailable(4))
+	assert.Equal(t, 1, restored.RolesLen(1))
+	assert.Equal(t, 1, restored.EmojisLen(1))
+	assert.Equal(t, 1, restored.VoiceStatesLen(1))
+
+	member, ok := restored.Member(1, 5)
+	require.True(t, ok)
+	channel, ok := restored.Channel(7)
+	require.True(t, ok)
+	assert.Equal(t, snowflake.ID(1), channel.GuildID())
+	assert.True(t, restored.MemberPermissionsInChannel(channel, member).Has(discord.PermissionSendMessages))
+}
+
+func TestSnapshotVersion(t *testing.T) {
+	err := ReadSnapshot(bytes.NewBufferString(`{"version":0}`), New())
+	assert.ErrorIs(t, err, ErrUnsupportedSnapshotVersion)
+}
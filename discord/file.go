@@ -2,10 +2,13 @@ package discord
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/textproto"
+	"os"
+	"path/filepath"
 
 	"github.com/disgoorg/json"
 
@@ -46,18 +49,18 @@ func PayloadWithFiles(v any, files ...*File) (*MultipartBuffer, error) {
 	}
 
 	for i, file := range files {
-		var name string
-		if file.Flags.Has(FileFlagSpoiler) {
-			name = "SPOILER_" + file.Name
-		} else {
-			name = file.Name
-		}
-		part, err = writer.CreatePart(partHeader(fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, name), "application/octet-stream"))
+		part, err = writer.CreatePart(partHeader(fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, file.fileName()), "application/octet-stream"))
 		if err != nil {
 			return nil, err
 		}
 
-		if _, err = io.Copy(part, file.Reader); err != nil {
+		reader, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		_, err = io.Copy(part, reader)
+		_ = reader.Close()
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -68,6 +71,102 @@ func PayloadWithFiles(v any, files ...*File) (*MultipartBuffer, error) {
 	}, nil
 }
 
+// payloadWithFiles returns the given payload as streamed multipart body if all files have an OpenFunc, so they can be reopened for retries.
+// Otherwise, the payload is buffered in memory, so the request can still be retried.
+func payloadWithFiles(v any, files ...*File) (any, error) {
+	for _, file := range files {
+		if file.OpenFunc == nil {
+			return PayloadWithFiles(v, files...)
+		}
+	}
+	return StreamPayloadWithFiles(v, files...)
+}
+
+// MultipartStream is a multipart body which streams its files instead of buffering them in memory.
+// It can be opened multiple times, for example to retry a rate limited request, as each file is opened with its OpenFunc again.
+type MultipartStream struct {
+	ContentType string
+
+	boundary string
+	payload  []byte
+	files    []*File
+}
+
+// StreamPayloadWithFiles returns the given payload as streamed multipart body with all files in it.
+// It returns ErrFileNotReopenable if a file has no OpenFunc.
+func StreamPayloadWithFiles(v any, files ...*File) (*MultipartStream, error) {
+	for _, file := range files {
+		if file.OpenFunc == nil {
+			return nil, fmt.Errorf("failed to stream file %s: %w", file.Name, ErrFileNotReopenable)
+		}
+	}
+
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	writer := multipart.NewWriter(io.Discard)
+	return &MultipartStream{
+		ContentType: writer.FormDataContentType(),
+		boundary:    writer.Boundary(),
+		payload:     payload,
+		files:       files,
+	}, nil
+}
+
+// Open opens all files and returns a reader which streams the multipart body. The files are closed once the body is read or the reader is closed.
+func (s *MultipartStream) Open() (io.ReadCloser, error) {
+	readers := make([]io.ReadCloser, 0, len(s.files))
+	for _, file := range s.files {
+		reader, err := file.OpenFunc()
+		if err != nil {
+			for _, r := range readers {
+				_ = r.Close()
+			}
+			return nil, fmt.Errorf("failed to open file %s: %w", file.Name, err)
+		}
+		readers = append(readers, reader)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer func() {
+			for _, r := range readers {
+				_ = r.Close()
+			}
+		}()
+		_ = pw.CloseWithError(s.write(pw, readers))
+	}()
+	return pr, nil
+}
+
+func (s *MultipartStream) write(w io.Writer, readers []io.ReadCloser) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(s.boundary); err != nil {
+		return err
+	}
+
+	part, err := writer.CreatePart(partHeader(`form-data; name="payload_json"`, "application/json"))
+	if err != nil {
+		return err
+	}
+	if _, err = part.Write(s.payload); err != nil {
+		return err
+	}
+
+	for i, file := range s.files {
+		part, err = writer.CreatePart(partHeader(fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, file.fileName()), "application/octet-stream"))
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(part, readers[i]); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
 func partHeader(contentDisposition string, contentType string) textproto.MIMEHeader {
 	return textproto.MIMEHeader{
 		"Content-Disposition": []string{contentDisposition},
@@ -99,12 +198,51 @@ func NewFile(name string, description string, reader io.Reader, flags ...FileFla
 	}
 }
 
+// NewFileFromOpener returns a new File struct with the given name, open func & FileFlags.
+// The open func is called each time the file is uploaded, which allows retrying the upload.
+func NewFileFromOpener(name string, description string, open func() (io.ReadCloser, error), flags ...FileFlags) *File {
+	return &File{
+		Name:        name,
+		Description: description,
+		OpenFunc:    open,
+		Flags:       FileFlagsNone.Add(flags...),
+	}
+}
+
+// NewFileFromPath returns a new File struct which opens the file at the given path each time it is uploaded
+func NewFileFromPath(path string, description string, flags ...FileFlags) *File {
+	return NewFileFromOpener(filepath.Base(path), description, func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}, flags...)
+}
+
+// ErrFileNotReopenable is returned by StreamPayloadWithFiles when a File has no OpenFunc, so it can't be opened again for each attempt
+var ErrFileNotReopenable = errors.New("file can not be reopened")
+
 // File holds all information about a given io.Reader
 type File struct {
 	Name        string
 	Description string
 	Reader      io.Reader
-	Flags       FileFlags
+	// OpenFunc opens the file for reading. If set, it is used instead of Reader
+	OpenFunc func() (io.ReadCloser, error)
+	Flags    FileFlags
+}
+
+// Open returns a reader for the content of the file.
+// It calls OpenFunc if set. Otherwise, it returns Reader, which can only be read once.
+func (f *File) Open() (io.ReadCloser, error) {
+	if f.OpenFunc != nil {
+		return f.OpenFunc()
+	}
+	return io.NopCloser(f.Reader), nil
+}
+
+func (f *File) fileName() string {
+	if f.Flags.Has(FileFlagSpoiler) {
+		return "SPOILER_" + f.Name
+	}
+	return f.Name
 }
 
 // FileFlags are used to mark Attachments as Spoiler
@@ -0,0 +1,65 @@
+package discord
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipartStream(t *testing.T) {
+	files := []*File{
+		NewFileFromOpener("reader.txt", "", func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte("reader"))), nil
+		}),
+		NewFileFromOpener("opener.txt", "", func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader("opener")), nil
+		}, FileFlagSpoiler),
+	}
+	stream, err := StreamPayloadWithFiles(MessageCreate{Content: "test"}, files...)
+	require.NoError(t, err)
+
+	// the stream can be read multiple times, like when a request is retried
+	for i := 0; i < 2; i++ {
+		body, err := stream.Open()
+		require.NoError(t, err)
+
+		_, params, err := mime.ParseMediaType(stream.ContentType)
+		require.NoError(t, err)
+		reader := multipart.NewReader(body, params["boundary"])
+
+		parts := map[string]string{}
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			data, err := io.ReadAll(part)
+			require.NoError(t, err)
+			parts[part.FormName()+":"+part.FileName()] = string(data)
+		}
+		require.NoError(t, body.Close())
+
+		assert.Equal(t, map[string]string{
+			"payload_json:":               `{"content":"test"}`,
+			"files[0]:reader.txt":         "reader",
+			"files[1]:SPOILER_opener.txt": "opener",
+		}, parts)
+	}
+}
+
+func TestPayloadWithFiles_Buffered(t *testing.T) {
+	// files without an OpenFunc are buffered, even if their Reader is an io.Seeker
+	body, err := payloadWithFiles(MessageCreate{}, NewFile("test.txt", "", strings.NewReader("test")))
+	require.NoError(t, err)
+	assert.IsType(t, &MultipartBuffer{}, body)
+
+	_, err = StreamPayloadWithFiles(MessageCreate{}, NewFile("test.txt", "", strings.NewReader("test")))
+	assert.ErrorIs(t, err, ErrFileNotReopenable)
+}
@@ -22,7 +22,7 @@ type WebhookMessageCreate struct {
 func (m WebhookMessageCreate) ToBody() (any, error) {
 	if len(m.Files) > 0 {
 		m.Attachments = parseAttachments(m.Files)
-		return PayloadWithFiles(m, m.Files...)
+		return payloadWithFiles(m, m.Files...)
 	}
 	return m, nil
 }
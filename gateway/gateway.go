@@ -83,6 +83,7 @@ type Gateway interface {
 	Open(ctx context.Context) error
 
 	// Close gracefully closes the Gateway with the websocket.CloseNormalClosure code.
+	// If a SessionStore is configured, the websocket.CloseServiceRestart code is used instead, so the stored session can still be resumed.
 	// If the context is done, the Gateway connection will be killed.
 	Close(ctx context.Context)
 
@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// CompressionType is the transport compression the Gateway should request from Discord.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#transport-compression
+type CompressionType string
+
+const (
+	// CompressionNone disables transport compression. Payload compression can still be enabled via Config.Compress.
+	CompressionNone CompressionType = ""
+	// CompressionZlibStream enables zlib-stream transport compression.
+	// All messages of a connection share a single zlib context.
+	CompressionZlibStream CompressionType = "zlib-stream"
+)
+
+// zlibHeader is the first byte of a zlib stream using deflate with a 32KiB window.
+const zlibHeader = 0x78
+
+// zlibSuffix is the Z_SYNC_FLUSH suffix Discord appends to the last frame of every zlib-stream message.
+var zlibSuffix = []byte{0x00, 0x00, 0xff, 0xff}
+
+// zlibWindowSize is the maximum distance a deflate back-reference can reach.
+const zlibWindowSize = 32 * 1024
+
+// decompressor decompresses transport compressed messages of a single connection.
+type decompressor interface {
+	// Decompress buffers the given frame and returns the decompressed message once it is complete.
+	// If the message is not complete yet, nil is returned.
+	Decompress(frame []byte) ([]byte, error)
+}
+
+// newDecompressor returns a fresh decompressor for the given CompressionType or nil if the CompressionType does not need one.
+func newDecompressor(compression CompressionType) decompressor {
+	switch compression {
+	case CompressionZlibStream:
+		return &zlibStreamDecompressor{}
+	default:
+		return nil
+	}
+}
+
+// zlibStreamDecompressor implements zlib-stream decompression.
+// Every message ends with a sync flush which leaves the deflate stream on a byte & block boundary.
+// This allows us to restart the inflater for every message with the last 32KiB of output as dictionary,
+// which is equivalent to keeping one inflate context alive for the whole connection.
+type zlibStreamDecompressor struct {
+	buf        bytes.Buffer
+	window     []byte
+	reader     io.ReadCloser
+	headerRead bool
+}
+
+func (d *zlibStreamDecompressor) Decompress(frame []byte) ([]byte, error) {
+	d.buf.Write(frame)
+	if !bytes.HasSuffix(d.buf.Bytes(), zlibSuffix) {
+		return nil, nil
+	}
+	defer d.buf.Reset()
+
+	data := d.buf.Bytes()
+	if !d.headerRead {
+		if len(data) < 2 || (uint16(data[0])<<8|uint16(data[1]))%31 != 0 || data[0]&0x0f != 8 {
+			return nil, errors.New("invalid zlib-stream header")
+		}
+		if data[1]&0x20 != 0 {
+			return nil, errors.New("zlib-stream with preset dictionary is not supported")
+		}
+		data = data[2:]
+		d.headerRead = true
+	}
+
+	src := bytes.NewReader(data)
+	if d.reader == nil {
+		d.reader = flate.NewReaderDict(src, d.window)
+	} else if err := d.reader.(flate.Resetter).Reset(src, d.window); err != nil {
+		return nil, fmt.Errorf("failed to reset inflater: %w", err)
+	}
+
+	out, err := io.ReadAll(d.reader)
+	// the stream never ends, so the inflater always reports an unexpected EOF after the sync flush
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to decompress zlib-stream: %w", err)
+	}
+	if src.Len() > 0 {
+		return nil, fmt.Errorf("failed to decompress zlib-stream: %d trailing bytes", src.Len())
+	}
+
+	d.window = append(d.window, out...)
+	if len(d.window) > zlibWindowSize {
+		d.window = append(d.window[:0], d.window[len(d.window)-zlibWindowSize:]...)
+	}
+	return out, nil
+}
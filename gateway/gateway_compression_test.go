@@ -0,0 +1,49 @@
+package gateway
+
+import (
+	"bytes"
+	"compress/zlib"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZlibStreamDecompressor(t *testing.T) {
+	messages := []string{
+		`{"op":10,"d":{"heartbeat_interval":41250}}`,
+		`{"op":11}`,
+		`{"op":0,"s":1,"t":"READY","d":{"session_id":"` + strings.Repeat("a", 40000) + `"}}`,
+		`{"op":0,"s":2,"t":"RESUMED","d":null}`,
+		`{"op":0,"s":3,"t":"RESUMED","d":null}`,
+	}
+
+	var (
+		compressed bytes.Buffer
+		w          = zlib.NewWriter(&compressed)
+		d          = newDecompressor(CompressionZlibStream)
+	)
+	for _, message := range messages {
+		compressed.Reset()
+		_, err := w.Write([]byte(message))
+		assert.NoError(t, err)
+		assert.NoError(t, w.Flush())
+
+		// split every message into two frames to make sure we buffer until the suffix
+		data := compressed.Bytes()
+		half := len(data) / 2
+
+		out, err := d.Decompress(append([]byte(nil), data[:half]...))
+		assert.NoError(t, err)
+		assert.Nil(t, out)
+
+		out, err = d.Decompress(append([]byte(nil), data[half:]...))
+		assert.NoError(t, err)
+		assert.Equal(t, message, string(out))
+	}
+}
+
+func TestZlibStreamDecompressor_InvalidHeader(t *testing.T) {
+	_, err := newDecompressor(CompressionZlibStream).Decompress([]byte{0x01, 0x02, 0x00, 0x00, 0xff, 0xff})
+	assert.Error(t, err)
+}
@@ -14,6 +14,7 @@ func DefaultConfig() *Config {
 		LargeThreshold:  50,
 		Intents:         IntentsDefault,
 		Compress:        true,
+		Encoding:        EncodingJSON,
 		URL:             "wss://gateway.discord.gg",
 		ShardID:         0,
 		ShardCount:      1,
@@ -34,7 +35,13 @@ type Config struct {
 	// Intents is the Intents for the Gateway. Defaults to IntentsNone.
 	Intents Intents
 	// Compress is whether the Gateway should compress payloads. Defaults to true.
+	// This is ignored when Compression is set, as Discord does not allow both at the same time.
 	Compress bool
+	// Compression is the transport compression of the Gateway. Defaults to CompressionNone.
+	// See here for more information: https://discord.com/developers/docs/topics/gateway#transport-compression
+	Compression CompressionType
+	// Encoding is the payload Encoding of the Gateway. Defaults to EncodingJSON.
+	Encoding Encoding
 	// URL is the URL of the Gateway. Defaults to fetch from Discord.
 	URL string
 	// ShardID is the shardID of the Gateway. Defaults to 0.
@@ -47,6 +54,9 @@ type Config struct {
 	ResumeURL *string
 	// LastSequenceReceived is the last sequence received by the Gateway. Defaults to nil (no resume).
 	LastSequenceReceived *int
+	// SessionStore is used to persist the session so it can be resumed after a restart. Defaults to nil (no persistence).
+	// If set, SessionID, ResumeURL and LastSequenceReceived are loaded from the SessionStore on Open if they are not set.
+	SessionStore SessionStore
 	// AutoReconnect is whether the Gateway should automatically reconnect or call the CloseHandlerFunc. Defaults to true.
 	AutoReconnect bool
 	// EnableRawEvents is whether the Gateway should emit EventRaw. Defaults to false.
@@ -118,6 +128,22 @@ func WithCompress(compress bool) ConfigOpt {
 	}
 }
 
+// WithCompression sets the transport compression for the Gateway.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#transport-compression
+func WithCompression(compression CompressionType) ConfigOpt {
+	return func(config *Config) {
+		config.Compression = compression
+	}
+}
+
+// WithEncoding sets the payload Encoding for the Gateway.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#encoding-and-compression
+func WithEncoding(encoding Encoding) ConfigOpt {
+	return func(config *Config) {
+		config.Encoding = encoding
+	}
+}
+
 // WithURL sets the Gateway URL for the Gateway.
 func WithURL(url string) ConfigOpt {
 	return func(config *Config) {
@@ -157,6 +183,14 @@ func WithSequence(sequence int) ConfigOpt {
 	}
 }
 
+// WithSessionStore sets the SessionStore for the Gateway.
+// The Gateway loads its session from the SessionStore on Open and keeps it updated with every received event.
+func WithSessionStore(sessionStore SessionStore) ConfigOpt {
+	return func(config *Config) {
+		config.SessionStore = sessionStore
+	}
+}
+
 // WithAutoReconnect sets whether the Gateway should automatically reconnect to Discord.
 func WithAutoReconnect(autoReconnect bool) ConfigOpt {
 	return func(config *Config) {
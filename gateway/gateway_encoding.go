@@ -0,0 +1,79 @@
+package gateway
+
+import (
+	"fmt"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/internal/etf"
+)
+
+// Encoding is the payload encoding the Gateway uses to talk to Discord.
+// See here for more information: https://discord.com/developers/docs/topics/gateway#encoding-and-compression
+type Encoding string
+
+const (
+	// EncodingJSON encodes payloads as JSON.
+	EncodingJSON Encoding = "json"
+	// EncodingETF encodes payloads as Erlang External Term Format.
+	// ETF payloads are decoded directly into the same structs as with EncodingJSON. Types with a custom json.Unmarshaler, like most types of package discord, receive their part of the payload transcoded to JSON,
+	// and sent payloads are transcoded from JSON, so ETF is not cheaper to decode or encode than JSON. Compare both with BenchmarkUnmarshalMessage & BenchmarkMarshalMessage for your payloads.
+	// Integers which don't fit into a float64, such as snowflakes, are handled like the quoted values Discord sends in JSON.
+	EncodingETF Encoding = "etf"
+)
+
+// MarshalMessage encodes the Message with the given Encoding.
+func MarshalMessage(encoding Encoding, message Message) ([]byte, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	if encoding == EncodingETF {
+		return etf.FromJSON(data)
+	}
+	return data, nil
+}
+
+// UnmarshalMessage decodes a Message encoded with the given Encoding.
+func UnmarshalMessage(encoding Encoding, data []byte) (Message, error) {
+	if encoding == EncodingETF {
+		return unmarshalETFMessage(data)
+	}
+	var message Message
+	err := json.Unmarshal(data, &message)
+	return message, err
+}
+
+func unmarshalETFMessage(data []byte) (Message, error) {
+	var v struct {
+		Op Opcode      `json:"op"`
+		S  int         `json:"s"`
+		T  EventType   `json:"t"`
+		D  etf.RawTerm `json:"d"`
+	}
+	if err := etf.Unmarshal(data, &v); err != nil {
+		return Message{}, err
+	}
+
+	messageData, err := unmarshalMessageData(v.Op, v.T, func(d any) error {
+		return etf.Unmarshal(v.D, d)
+	})
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to unmarshal message data: %w", err)
+	}
+	return Message{
+		Op:      v.Op,
+		S:       v.S,
+		T:       v.T,
+		D:       messageData,
+		rawTerm: v.D,
+	}, nil
+}
+
+// rawData returns the data of the Message as JSON. ETF data is only transcoded to JSON when it is requested.
+func (e Message) rawData() ([]byte, error) {
+	if e.rawTerm != nil {
+		return etf.ToJSON(e.rawTerm)
+	}
+	return e.RawD, nil
+}
@@ -0,0 +1,122 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/etf"
+)
+
+func TestUnmarshalMessage_ETF(t *testing.T) {
+	// snowflakes are sent as integers over etf
+	data, err := etf.FromJSON([]byte(`{"op":0,"s":1,"t":"READY","d":{"v":10,"session_id":"abc","resume_gateway_url":"wss://example.com","user":{"id":81384788765712384,"username":"disgo","avatar":null,"bot":true},"guilds":[{"id":81384788765712385,"unavailable":true}],"shard":[0,1]}}`))
+	assert.NoError(t, err)
+
+	message, err := UnmarshalMessage(EncodingETF, data)
+	assert.NoError(t, err)
+	assert.Equal(t, OpcodeDispatch, message.Op)
+	assert.Equal(t, EventTypeReady, message.T)
+
+	ready, ok := message.D.(EventReady)
+	assert.True(t, ok)
+	assert.Equal(t, snowflake.ID(81384788765712384), ready.User.ID)
+	assert.Equal(t, "disgo", ready.User.Username)
+	assert.Nil(t, ready.User.Avatar)
+	assert.True(t, ready.User.Bot)
+	assert.Equal(t, []discord.UnavailableGuild{{ID: 81384788765712385, Unavailable: true}}, ready.Guilds)
+	assert.Equal(t, [2]int{0, 1}, ready.Shard)
+}
+
+func TestMarshalMessage_ETF(t *testing.T) {
+	data, err := MarshalMessage(EncodingETF, Message{
+		Op: OpcodeResume,
+		D: MessageDataResume{
+			Token:     "token",
+			SessionID: "session",
+			Seq:       1337,
+		},
+	})
+	assert.NoError(t, err)
+
+	message, err := UnmarshalMessage(EncodingETF, data)
+	assert.NoError(t, err)
+	assert.Equal(t, OpcodeResume, message.Op)
+	assert.Equal(t, MessageDataResume{Token: "token", SessionID: "session", Seq: 1337}, message.D)
+}
+
+func TestUnmarshalMessage_ETFString(t *testing.T) {
+	// term_to_binary(#{op => 0, s => 1, t => 'READY', d => #{session_id => <<"abc">>, shard => [0, 1]}})
+	// Erlang encodes the shard list of small integers as STRING_EXT.
+	data := []byte{
+		131, 116, 0, 0, 0, 4,
+		119, 1, 'd', 116, 0, 0, 0, 2,
+		119, 10, 's', 'e', 's', 's', 'i', 'o', 'n', '_', 'i', 'd', 109, 0, 0, 0, 3, 'a', 'b', 'c',
+		119, 5, 's', 'h', 'a', 'r', 'd', 107, 0, 2, 0, 1,
+		119, 2, 'o', 'p', 97, 0,
+		119, 1, 's', 97, 1,
+		119, 1, 't', 119, 5, 'R', 'E', 'A', 'D', 'Y',
+	}
+
+	message, err := UnmarshalMessage(EncodingETF, data)
+	assert.NoError(t, err)
+
+	ready, ok := message.D.(EventReady)
+	assert.True(t, ok)
+	assert.Equal(t, "abc", ready.SessionID)
+	assert.Equal(t, [2]int{0, 1}, ready.Shard)
+
+	rawData, err := message.rawData()
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"session_id":"abc","shard":[0,1]}`, string(rawData))
+}
+
+// messageCreatePayload is a typical MESSAGE_CREATE dispatch. discord.Message implements json.Unmarshaler, so most of it is transcoded to JSON when decoding ETF.
+const messageCreatePayload = `{"op":0,"s":42,"t":"MESSAGE_CREATE","d":{"id":"1234567890123456789","channel_id":"1234567890123456780","guild_id":"1234567890123456781","type":0,"content":"hello world, this is a message with some content","timestamp":"2024-01-01T00:00:00.000000+00:00","edited_timestamp":null,"tts":false,"mention_everyone":false,"mentions":[],"mention_roles":[],"attachments":[],"embeds":[{"type":"rich","title":"title","description":"description","fields":[{"name":"a","value":"b","inline":true}]}],"pinned":false,"flags":0,"author":{"id":"1234567890123456782","username":"user","discriminator":"0","global_name":"User","avatar":"a1b2c3d4e5f6"},"member":{"roles":["1234567890123456783"],"joined_at":"2023-01-01T00:00:00.000000+00:00","deaf":false,"mute":false,"flags":0}}}`
+
+func BenchmarkUnmarshalMessage(b *testing.B) {
+	etfPayload, err := etf.FromJSON([]byte(messageCreatePayload))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, bb := range []struct {
+		encoding Encoding
+		data     []byte
+	}{
+		{encoding: EncodingJSON, data: []byte(messageCreatePayload)},
+		{encoding: EncodingETF, data: etfPayload},
+	} {
+		b.Run(string(bb.encoding), func(b *testing.B) {
+			b.ReportMetric(float64(len(bb.data)), "payload-bytes")
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := UnmarshalMessage(bb.encoding, bb.data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMarshalMessage(b *testing.B) {
+	message := Message{
+		Op: OpcodeIdentify,
+		D: MessageDataIdentify{
+			Token:      "token",
+			Properties: IdentifyCommandDataProperties{OS: "linux", Browser: "disgo", Device: "disgo"},
+			Shard:      &[2]int{0, 1},
+		},
+	}
+	for _, encoding := range []Encoding{EncodingJSON, EncodingETF} {
+		b.Run(string(encoding), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := MarshalMessage(encoding, message); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package gateway
 
 import (
+	"bufio"
 	"bytes"
 	"compress/zlib"
 	"context"
@@ -17,6 +18,7 @@ import (
 	"github.com/gorilla/websocket"
 
 	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/etf"
 )
 
 var _ Gateway = (*gatewayImpl)(nil)
@@ -42,8 +44,9 @@ type gatewayImpl struct {
 	closeHandlerFunc CloseHandlerFunc
 	token            string
 
-	conn            *websocket.Conn
-	connMu          sync.Mutex
+	conn   *websocket.Conn
+	connMu sync.Mutex
+	// heartbeatCancel is guarded by connMu
 	heartbeatCancel context.CancelFunc
 	status          Status
 
@@ -73,9 +76,66 @@ func (g *gatewayImpl) Intents() Intents {
 }
 
 func (g *gatewayImpl) Open(ctx context.Context) error {
+	g.loadSession(ctx)
 	return g.reconnectTry(ctx, 0)
 }
 
+// loadSession loads the session from the SessionStore if we don't have one yet.
+func (g *gatewayImpl) loadSession(ctx context.Context) {
+	if g.config.SessionStore == nil || g.config.SessionID != nil {
+		return
+	}
+	session, err := g.config.SessionStore.Get(ctx, g.config.ShardID)
+	if err != nil {
+		g.config.Logger.Error("failed to load session", slog.Any("err", err))
+		return
+	}
+	if session == nil || session.ShardCount != g.config.ShardCount {
+		return
+	}
+	g.config.Logger.Debug("loaded session", slog.String("session_id", session.ID), slog.Int("sequence", session.Sequence))
+	g.config.SessionID = &session.ID
+	g.config.ResumeURL = &session.ResumeURL
+	g.config.LastSequenceReceived = &session.Sequence
+}
+
+// storeSession saves the current session in the SessionStore.
+func (g *gatewayImpl) storeSession() {
+	if g.config.SessionStore == nil || g.config.SessionID == nil || g.config.LastSequenceReceived == nil {
+		return
+	}
+	session := Session{
+		ID:         *g.config.SessionID,
+		Sequence:   *g.config.LastSequenceReceived,
+		ShardCount: g.config.ShardCount,
+	}
+	if g.config.ResumeURL != nil {
+		session.ResumeURL = *g.config.ResumeURL
+	}
+	if err := g.config.SessionStore.Put(context.Background(), g.config.ShardID, session); err != nil {
+		g.config.Logger.Error("failed to store session", slog.Any("err", err))
+	}
+}
+
+// resetSession clears the in memory resume information.
+func (g *gatewayImpl) resetSession() {
+	g.config.SessionID = nil
+	g.config.ResumeURL = nil
+	g.config.LastSequenceReceived = nil
+}
+
+// clearSession clears the resume information and removes it from the SessionStore.
+// It should only be called when Discord invalidated the session.
+func (g *gatewayImpl) clearSession() {
+	g.resetSession()
+	if g.config.SessionStore == nil {
+		return
+	}
+	if err := g.config.SessionStore.Delete(context.Background(), g.config.ShardID); err != nil {
+		g.config.Logger.Error("failed to delete session", slog.Any("err", err))
+	}
+}
+
 func (g *gatewayImpl) open(ctx context.Context) error {
 	g.config.Logger.Debug("opening gateway connection")
 
@@ -90,7 +150,10 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 	if g.config.ResumeURL != nil && g.config.EnableResumeURL {
 		wsURL = *g.config.ResumeURL
 	}
-	gatewayURL := fmt.Sprintf("%s?v=%d&encoding=json", wsURL, Version)
+	gatewayURL := fmt.Sprintf("%s?v=%d&encoding=%s", wsURL, Version, g.config.Encoding)
+	if g.config.Compression != CompressionNone {
+		gatewayURL += "&compress=" + string(g.config.Compression)
+	}
 	g.lastHeartbeatSent = time.Now().UTC()
 	conn, rs, err := g.config.Dialer.DialContext(ctx, gatewayURL, nil)
 	if err != nil {
@@ -121,23 +184,29 @@ func (g *gatewayImpl) open(ctx context.Context) error {
 
 	g.status = StatusWaitingForHello
 
-	go g.listen(conn)
+	// every connection starts a new compression context, this also applies to resumes
+	go g.listen(conn, newDecompressor(g.config.Compression))
 
 	return nil
 }
 
 func (g *gatewayImpl) Close(ctx context.Context) {
+	if g.config.SessionStore != nil {
+		// closing with a normal closure invalidates the session, keep it resumable for the next start instead
+		g.CloseWithCode(ctx, websocket.CloseServiceRestart, "Shutting down")
+		return
+	}
 	g.CloseWithCode(ctx, websocket.CloseNormalClosure, "Shutting down")
 }
 
 func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message string) {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
 	if g.heartbeatCancel != nil {
 		g.config.Logger.Debug("closing heartbeat goroutines...")
 		g.heartbeatCancel()
 	}
 
-	g.connMu.Lock()
-	defer g.connMu.Unlock()
 	if g.conn != nil {
 		g.config.RateLimiter.Close(ctx)
 		g.config.Logger.Debug("closing gateway connection", slog.Int("code", code), slog.String("message", message))
@@ -147,11 +216,9 @@ func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message strin
 		_ = g.conn.Close()
 		g.conn = nil
 
-		// clear resume data as we closed gracefully
+		// clear resume data as we closed gracefully, the SessionStore is only cleared when Discord invalidates the session
 		if code == websocket.CloseNormalClosure || code == websocket.CloseGoingAway {
-			g.config.SessionID = nil
-			g.config.ResumeURL = nil
-			g.config.LastSequenceReceived = nil
+			g.resetSession()
 		}
 	}
 }
@@ -163,14 +230,18 @@ func (g *gatewayImpl) Status() Status {
 }
 
 func (g *gatewayImpl) Send(ctx context.Context, op Opcode, d MessageData) error {
-	data, err := json.Marshal(Message{
+	data, err := MarshalMessage(g.config.Encoding, Message{
 		Op: op,
 		D:  d,
 	})
 	if err != nil {
 		return err
 	}
-	return g.send(ctx, websocket.TextMessage, data)
+	messageType := websocket.TextMessage
+	if g.config.Encoding == EncodingETF {
+		messageType = websocket.BinaryMessage
+	}
+	return g.send(ctx, messageType, data)
 }
 
 func (g *gatewayImpl) send(ctx context.Context, messageType int, data []byte) error {
@@ -234,7 +305,9 @@ func (g *gatewayImpl) reconnect() {
 
 func (g *gatewayImpl) heartbeat() {
 	ctx, cancel := context.WithCancel(context.Background())
+	g.connMu.Lock()
 	g.heartbeatCancel = cancel
+	g.connMu.Unlock()
 
 	heartbeatTicker := time.NewTicker(g.heartbeatInterval)
 	defer heartbeatTicker.Stop()
@@ -281,7 +354,7 @@ func (g *gatewayImpl) identify() {
 			Browser: g.config.Browser,
 			Device:  g.config.Device,
 		},
-		Compress:       g.config.Compress,
+		Compress:       g.config.Compress && g.config.Compression == CompressionNone,
 		LargeThreshold: g.config.LargeThreshold,
 		Intents:        g.config.Intents,
 		Presence:       g.config.Presence,
@@ -312,7 +385,7 @@ func (g *gatewayImpl) resume() {
 	}
 }
 
-func (g *gatewayImpl) listen(conn *websocket.Conn) {
+func (g *gatewayImpl) listen(conn *websocket.Conn, decompressor decompressor) {
 	defer g.config.Logger.Debug("exiting listen goroutine")
 loop:
 	for {
@@ -334,9 +407,7 @@ loop:
 				reconnect = closeCode.Reconnect
 
 				if closeCode == CloseEventCodeInvalidSeq {
-					g.config.LastSequenceReceived = nil
-					g.config.SessionID = nil
-					g.config.ResumeURL = nil
+					g.clearSession()
 				}
 				msg := "gateway close received"
 				args := []any{
@@ -369,11 +440,15 @@ loop:
 			break loop
 		}
 
-		message, err := g.parseMessage(mt, r)
+		message, err := g.parseMessage(mt, r, decompressor)
 		if err != nil {
 			g.config.Logger.Error("error while parsing gateway message", slog.Any("err", err))
 			continue
 		}
+		// the message is split across multiple frames, wait for the rest
+		if message == nil {
+			continue
+		}
 
 		switch message.Op {
 		case OpcodeHello:
@@ -404,6 +479,7 @@ loop:
 				g.status = StatusReady
 				g.config.Logger.Debug("ready message received")
 			}
+			g.storeSession()
 
 			if unknownEvent, ok := eventData.(EventUnknown); ok {
 				g.config.Logger.Debug("unknown event received", slog.String("event", string(message.T)), slog.String("data", string(unknownEvent)))
@@ -412,10 +488,14 @@ loop:
 
 			// push message to the command manager
 			if g.config.EnableRawEvents {
-				g.eventHandlerFunc(EventTypeRaw, message.S, g.config.ShardID, EventRaw{
-					EventType: message.T,
-					Payload:   bytes.NewReader(message.RawD),
-				})
+				if rawData, err := message.rawData(); err != nil {
+					g.config.Logger.Error("error while transcoding raw event data", slog.Any("err", err))
+				} else {
+					g.eventHandlerFunc(EventTypeRaw, message.S, g.config.ShardID, EventRaw{
+						EventType: message.T,
+						Payload:   bytes.NewReader(rawData),
+					})
+				}
 			}
 			g.eventHandlerFunc(message.T, message.S, g.config.ShardID, eventData)
 
@@ -437,9 +517,7 @@ loop:
 				code = websocket.CloseServiceRestart
 			} else {
 				// clear resume info
-				g.config.SessionID = nil
-				g.config.LastSequenceReceived = nil
-				g.config.ResumeURL = nil
+				g.clearSession()
 			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -463,16 +541,51 @@ loop:
 	}
 }
 
-func (g *gatewayImpl) parseMessage(mt int, r io.Reader) (Message, error) {
-	if mt == websocket.BinaryMessage {
-		g.config.Logger.Debug("binary message received. decompressing")
+func (g *gatewayImpl) parseMessage(mt int, r io.Reader, decompressor decompressor) (*Message, error) {
+	if decompressor != nil {
+		frame, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message: %w", err)
+		}
+		data, err := decompressor.Decompress(frame)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			return nil, nil
+		}
+		r = bytes.NewReader(data)
+	} else if mt == websocket.BinaryMessage {
+		br := bufio.NewReader(r)
+		r = br
+		// etf payloads are always sent as binary messages, so we need to check for the zlib header
+		if header, _ := br.Peek(1); g.config.Encoding != EncodingETF || (len(header) > 0 && header[0] == zlibHeader) {
+			g.config.Logger.Debug("binary message received. decompressing")
+
+			reader, err := zlib.NewReader(br)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decompress zlib: %w", err)
+			}
+			defer reader.Close()
+			r = reader
+		}
+	}
 
-		reader, err := zlib.NewReader(r)
+	if g.config.Encoding == EncodingETF {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message: %w", err)
+		}
+		if g.config.Logger.Enabled(context.Background(), slog.LevelDebug) {
+			if jsonData, err := etf.ToJSON(data); err == nil {
+				g.config.Logger.Debug("received gateway message", slog.String("data", string(jsonData)))
+			}
+		}
+		message, err := UnmarshalMessage(EncodingETF, data)
 		if err != nil {
-			return Message{}, fmt.Errorf("failed to decompress zlib: %w", err)
+			return nil, fmt.Errorf("failed to decode etf: %w", err)
 		}
-		defer reader.Close()
-		r = reader
+		return &message, nil
 	}
 
 	if g.config.Logger.Enabled(context.Background(), slog.LevelDebug) {
@@ -480,12 +593,15 @@ func (g *gatewayImpl) parseMessage(mt int, r io.Reader) (Message, error) {
 		tr := io.TeeReader(r, buff)
 		data, err := io.ReadAll(tr)
 		if err != nil {
-			return Message{}, fmt.Errorf("failed to read message: %w", err)
+			return nil, fmt.Errorf("failed to read message: %w", err)
 		}
 		g.config.Logger.Debug("received gateway message", slog.String("data", string(data)))
 		r = buff
 	}
 
 	var message Message
-	return message, json.NewDecoder(r).Decode(&message)
+	if err := json.NewDecoder(r).Decode(&message); err != nil {
+		return nil, err
+	}
+	return &message, nil
 }
@@ -7,15 +7,19 @@ import (
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/etf"
 )
 
 // Message raw Message type
 type Message struct {
-	Op   Opcode          `json:"op"`
-	S    int             `json:"s,omitempty"`
-	T    EventType       `json:"t,omitempty"`
-	D    MessageData     `json:"d,omitempty"`
+	Op Opcode      `json:"op"`
+	S  int         `json:"s,omitempty"`
+	T  EventType   `json:"t,omitempty"`
+	D  MessageData `json:"d,omitempty"`
+	// RawD is the raw JSON data of the Message. It is only set for messages received with EncodingJSON.
 	RawD json.RawMessage `json:"-"`
+
+	rawTerm etf.RawTerm
 }
 
 func (e *Message) UnmarshalJSON(data []byte) error {
@@ -29,73 +33,82 @@ func (e *Message) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	messageData, err := unmarshalMessageData(v.Op, v.T, func(d any) error {
+		return json.Unmarshal(v.D, d)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal message data: %s: %w", string(data), err)
+	}
+	e.Op = v.Op
+	e.S = v.S
+	e.T = v.T
+	e.D = messageData
+	e.RawD = v.D
+	return nil
+}
+
+// unmarshalMessageData decodes the data of a Message with the given Opcode using unmarshal.
+// It is shared by all Encoding(s), so every encoding decodes into the same types.
+func unmarshalMessageData(op Opcode, eventType EventType, unmarshal func(v any) error) (MessageData, error) {
 	var (
 		messageData MessageData
 		err         error
 	)
 
-	switch v.Op {
+	switch op {
 	case OpcodeDispatch:
-		messageData, err = UnmarshalEventData(v.D, v.T)
+		messageData, err = unmarshalEventData(eventType, unmarshal)
 
 	case OpcodeHeartbeat:
 		var d MessageDataHeartbeat
-		err = json.Unmarshal(v.D, &d)
+		err = unmarshal(&d)
 		messageData = d
 
 	case OpcodeIdentify:
 		var d MessageDataIdentify
-		err = json.Unmarshal(v.D, &d)
+		err = unmarshal(&d)
 		messageData = d
 
 	case OpcodePresenceUpdate:
 		var d MessageDataPresenceUpdate
-		err = json.Unmarshal(v.D, &d)
+		err = unmarshal(&d)
 		messageData = d
 
 	case OpcodeVoiceStateUpdate:
 		var d MessageDataVoiceStateUpdate
-		err = json.Unmarshal(v.D, &d)
+		err = unmarshal(&d)
 		messageData = d
 
 	case OpcodeResume:
 		var d MessageDataResume
-		err = json.Unmarshal(v.D, &d)
+		err = unmarshal(&d)
 		messageData = d
 
 	case OpcodeReconnect:
 
 	case OpcodeRequestGuildMembers:
 		var d MessageDataRequestGuildMembers
-		err = json.Unmarshal(v.D, &d)
+		err = unmarshal(&d)
 		messageData = d
 
 	case OpcodeInvalidSession:
 		var d MessageDataInvalidSession
-		err = json.Unmarshal(v.D, &d)
+		err = unmarshal(&d)
 		messageData = d
 
 	case OpcodeHello:
 		var d MessageDataHello
-		err = json.Unmarshal(v.D, &d)
+		err = unmarshal(&d)
 		messageData = d
 
 	case OpcodeHeartbeatACK:
 
 	default:
 		var d MessageDataUnknown
-		err = json.Unmarshal(v.D, &d)
+		err = unmarshal(&d)
 		messageData = d
 	}
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal message data: %s: %w", string(data), err)
-	}
-	e.Op = v.Op
-	e.S = v.S
-	e.T = v.T
-	e.D = messageData
-	e.RawD = v.D
-	return nil
+	return messageData, err
 }
 
 type MessageData interface {
@@ -103,6 +116,16 @@ type MessageData interface {
 }
 
 func UnmarshalEventData(data []byte, eventType EventType) (EventData, error) {
+	eventData, err := unmarshalEventData(eventType, func(v any) error {
+		return json.Unmarshal(data, v)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event data: %s: %w", string(data), err)
+	}
+	return eventData, nil
+}
+
+func unmarshalEventData(eventType EventType, unmarshal func(v any) error) (EventData, error) {
 	var (
 		eventData EventData
 		err       error
@@ -110,7 +133,7 @@ func UnmarshalEventData(data []byte, eventType EventType) (EventData, error) {
 	switch eventType {
 	case EventTypeReady:
 		var d EventReady
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeResumed:
@@ -118,324 +141,323 @@ func UnmarshalEventData(data []byte, eventType EventType) (EventData, error) {
 
 	case EventTypeApplicationCommandPermissionsUpdate:
 		var d EventApplicationCommandPermissionsUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeAutoModerationRuleCreate:
 		var d EventAutoModerationRuleCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeAutoModerationRuleUpdate:
 		var d EventAutoModerationRuleUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeAutoModerationRuleDelete:
 		var d EventAutoModerationRuleDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeAutoModerationActionExecution:
 		var d EventAutoModerationActionExecution
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeChannelCreate:
 		var d EventChannelCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeChannelUpdate:
 		var d EventChannelUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeChannelDelete:
 		var d EventChannelDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeChannelPinsUpdate:
 		var d EventChannelPinsUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeEntitlementCreate:
 		var d EventEntitlementCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeEntitlementUpdate:
 		var d EventEntitlementUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeEntitlementDelete:
 		var d EventEntitlementDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeThreadCreate:
 		var d EventThreadCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeThreadUpdate:
 		var d EventThreadUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeThreadDelete:
 		var d EventThreadDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeThreadListSync:
 		var d EventThreadListSync
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeThreadMemberUpdate:
 		var d EventThreadMemberUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeThreadMembersUpdate:
 		var d EventThreadMembersUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildCreate:
 		var d EventGuildCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildUpdate:
 		var d EventGuildUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildDelete:
 		var d EventGuildDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildAuditLogEntryCreate:
 		var d EventGuildAuditLogEntryCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildBanAdd:
 		var d EventGuildBanAdd
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildBanRemove:
 		var d EventGuildBanRemove
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildEmojisUpdate:
 		var d EventGuildEmojisUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildStickersUpdate:
 		var d EventGuildStickersUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildIntegrationsUpdate:
 		var d EventGuildIntegrationsUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildMemberAdd:
 		var d EventGuildMemberAdd
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildMemberRemove:
 		var d EventGuildMemberRemove
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildMemberUpdate:
 		var d EventGuildMemberUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildMembersChunk:
 		var d EventGuildMembersChunk
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildRoleCreate:
 		var d EventGuildRoleCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildRoleUpdate:
 		var d EventGuildRoleUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildRoleDelete:
 		var d EventGuildRoleDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildScheduledEventCreate:
 		var d EventGuildScheduledEventCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildScheduledEventUpdate:
 		var d EventGuildScheduledEventUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildScheduledEventDelete:
 		var d EventGuildScheduledEventDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildScheduledEventUserAdd:
 		var d EventGuildScheduledEventUserAdd
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeGuildScheduledEventUserRemove:
 		var d EventGuildScheduledEventUserRemove
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeIntegrationCreate:
 		var d EventIntegrationCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeIntegrationUpdate:
 		var d EventIntegrationUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeIntegrationDelete:
 		var d EventIntegrationDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeInteractionCreate:
 		var d EventInteractionCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeInviteCreate:
 		var d EventInviteCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeInviteDelete:
 		var d EventInviteDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeMessageCreate:
 		var d EventMessageCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeMessageUpdate:
 		var d EventMessageUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeMessageDelete:
 		var d EventMessageDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeMessageDeleteBulk:
 		var d EventMessageDeleteBulk
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeMessageReactionAdd:
 		var d EventMessageReactionAdd
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeMessageReactionRemove:
 		var d EventMessageReactionRemove
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeMessageReactionRemoveAll:
 		var d EventMessageReactionRemoveAll
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeMessageReactionRemoveEmoji:
 		var d EventMessageReactionRemoveEmoji
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypePresenceUpdate:
 		var d EventPresenceUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeStageInstanceCreate:
 		var d EventStageInstanceCreate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeStageInstanceUpdate:
 		var d EventStageInstanceUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeStageInstanceDelete:
 		var d EventStageInstanceDelete
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeTypingStart:
 		var d EventTypingStart
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeUserUpdate:
 		var d EventUserUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeVoiceStateUpdate:
 		var d EventVoiceStateUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeVoiceServerUpdate:
 		var d EventVoiceServerUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	case EventTypeWebhooksUpdate:
 		var d EventWebhooksUpdate
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 
 	default:
 		var d EventUnknown
-		err = json.Unmarshal(data, &d)
+		err = unmarshal(&d)
 		eventData = d
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal event data: %s: %w", string(data), err)
+		return nil, err
 	}
-
 	return eventData, nil
 }
 
@@ -0,0 +1,174 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+)
+
+// Session holds the information needed to resume a Gateway session.
+type Session struct {
+	// ID is the session ID received in the EventReady.
+	ID string `json:"id"`
+	// ResumeURL is the resume gateway URL received in the EventReady.
+	ResumeURL string `json:"resume_url"`
+	// Sequence is the last sequence received by the Gateway.
+	Sequence int `json:"sequence"`
+	// ShardCount is the shard count the session was created with. Sessions are only resumed with the same shard count.
+	ShardCount int `json:"shard_count"`
+}
+
+// SessionStore persists Session(s) so a Gateway can resume after a process restart.
+// The Gateway calls Put whenever its session or last sequence changes, so implementations should be cheap to call.
+// Only close the Gateway with a non-normal close code like websocket.CloseServiceRestart, as Discord invalidates sessions closed with websocket.CloseNormalClosure or websocket.CloseGoingAway.
+type SessionStore interface {
+	// Get returns the Session of the given shard or nil if there is none.
+	Get(ctx context.Context, shardID int) (*Session, error)
+
+	// Put stores the Session of the given shard.
+	Put(ctx context.Context, shardID int, session Session) error
+
+	// Delete removes the Session of the given shard.
+	Delete(ctx context.Context, shardID int) error
+}
+
+// FileSessionStore is a SessionStore which persists all Session(s) into a single JSON file.
+type FileSessionStore interface {
+	SessionStore
+
+	// Flush writes all pending changes to the file.
+	Flush() error
+
+	// Close flushes all pending changes.
+	Close() error
+}
+
+// NewFileSessionStore returns a new FileSessionStore which stores its Session(s) in the file at the given path.
+// Changes are written to the file at most once per flushInterval to avoid writing on every received event.
+// Resuming with a slightly older sequence is fine, as Discord replays all events after the sequence sent in the resume.
+func NewFileSessionStore(path string, flushInterval time.Duration) (FileSessionStore, error) {
+	s := &fileSessionStoreImpl{
+		path:          path,
+		flushInterval: flushInterval,
+		sessions:      map[int]Session{},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if len(data) > 0 {
+		if err = json.Unmarshal(data, &s.sessions); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+type fileSessionStoreImpl struct {
+	path          string
+	flushInterval time.Duration
+
+	sessions   map[int]Session
+	dirty      bool
+	flushTimer *time.Timer
+	mu         sync.Mutex
+	writeMu    sync.Mutex
+}
+
+func (s *fileSessionStoreImpl) Get(_ context.Context, shardID int) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[shardID]
+	if !ok {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+func (s *fileSessionStoreImpl) Put(_ context.Context, shardID int, session Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[shardID] = session
+	s.markDirty()
+	return nil
+}
+
+func (s *fileSessionStoreImpl) Delete(_ context.Context, shardID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sessions[shardID]; !ok {
+		return nil
+	}
+	delete(s.sessions, shardID)
+	s.markDirty()
+	return nil
+}
+
+// markDirty schedules a flush if none is pending. s.mu must be held.
+func (s *fileSessionStoreImpl) markDirty() {
+	s.dirty = true
+	if s.flushTimer != nil {
+		return
+	}
+	s.flushTimer = time.AfterFunc(s.flushInterval, func() {
+		_ = s.Flush()
+	})
+}
+
+func (s *fileSessionStoreImpl) Flush() error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.Lock()
+	if s.flushTimer != nil {
+		s.flushTimer.Stop()
+		s.flushTimer = nil
+	}
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	data, err := json.Marshal(s.sessions)
+	s.dirty = false
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err = s.write(data); err != nil {
+		// retry on the next flush
+		s.mu.Lock()
+		s.markDirty()
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *fileSessionStoreImpl) write(data []byte) error {
+	// write to a temporary file first, so we never leave a half written file behind
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *fileSessionStoreImpl) Close() error {
+	return s.Flush()
+}
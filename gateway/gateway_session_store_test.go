@@ -0,0 +1,124 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSessionStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	session := Session{
+		ID:         "session",
+		ResumeURL:  "wss://example.com",
+		Sequence:   42,
+		ShardCount: 2,
+	}
+
+	store, err := NewFileSessionStore(path, time.Hour)
+	assert.NoError(t, err)
+	assert.NoError(t, store.Put(context.Background(), 1, session))
+	assert.NoError(t, store.Close())
+
+	store, err = NewFileSessionStore(path, time.Hour)
+	assert.NoError(t, err)
+
+	got, err := store.Get(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &session, got)
+
+	got, err = store.Get(context.Background(), 0)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, store.Delete(context.Background(), 1))
+	assert.NoError(t, store.Flush())
+
+	store, err = NewFileSessionStore(path, time.Hour)
+	assert.NoError(t, err)
+	got, err = store.Get(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestGateway_CloseKeepsSession(t *testing.T) {
+	received := make(chan Message, 10)
+	closeCodes := make(chan int, 10)
+
+	var wsURL string
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"op":10,"d":{"heartbeat_interval":45000}}`))
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				var closeErr *websocket.CloseError
+				if errors.As(err, &closeErr) {
+					closeCodes <- closeErr.Code
+				}
+				return
+			}
+			var message Message
+			if err = json.Unmarshal(data, &message); err != nil || message.Op == OpcodeHeartbeat {
+				continue
+			}
+			received <- message
+
+			if message.Op == OpcodeIdentify {
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"op":0,"s":1,"t":"READY","d":{"v":10,"user":{"id":"1","username":"disgo"},"guilds":[],"session_id":"session","resume_gateway_url":"`+wsURL+`","shard":[0,1],"application":{"id":"1"}}}`))
+			}
+		}
+	}))
+	defer server.Close()
+	wsURL = "ws" + strings.TrimPrefix(server.URL, "http")
+
+	store, err := NewFileSessionStore(filepath.Join(t.TempDir(), "sessions.json"), time.Hour)
+	assert.NoError(t, err)
+
+	ready := make(chan struct{}, 1)
+	newGateway := func() Gateway {
+		return New("token", func(eventType EventType, _ int, _ int, _ EventData) {
+			if eventType == EventTypeReady {
+				ready <- struct{}{}
+			}
+		}, nil, WithURL(wsURL), WithSessionStore(store), WithAutoReconnect(false))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	gateway := newGateway()
+	assert.NoError(t, gateway.Open(ctx))
+	assert.Equal(t, OpcodeIdentify, (<-received).Op)
+	<-ready
+	gateway.Close(ctx)
+	assert.Equal(t, websocket.CloseServiceRestart, <-closeCodes)
+
+	session, err := store.Get(ctx, 0)
+	assert.NoError(t, err)
+	assert.NotNil(t, session)
+
+	// a new Gateway, like after a restart, resumes the stored session
+	gateway = newGateway()
+	assert.NoError(t, gateway.Open(ctx))
+	defer gateway.Close(ctx)
+
+	message := <-received
+	assert.Equal(t, OpcodeResume, message.Op)
+	assert.Equal(t, MessageDataResume{Token: "token", SessionID: "session", Seq: 1}, message.D)
+}
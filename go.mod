@@ -1,6 +1,6 @@
 module github.com/disgoorg/disgo
 
-go 1.21
+go 1.23
 
 require (
 	github.com/disgoorg/json v1.1.0
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// DefaultAckDeadline is the default deadline after which the ack watchdog defers an interaction.
+// Discord invalidates the interaction token if an interaction is not acknowledged within 3 seconds.
+const DefaultAckDeadline = 2500 * time.Millisecond
+
+// ErrAutoDeferred is returned when responding to an interaction with a response type which is not possible anymore, because the ack watchdog already deferred it.
+var ErrAutoDeferred = errors.New("interaction was already deferred by the ack watchdog")
+
+// AckWatchdog enables the ack watchdog for this router.
+// If an application command, component or modal interaction is not responded to within the given deadline, it is deferred automatically.
+// Application commands are deferred with discord.InteractionResponseTypeDeferredCreateMessage (ephemeral if ephemeral is true), components & modals with discord.InteractionResponseTypeDeferredUpdateMessage.
+//
+// Responses sent after the interaction was deferred are converted transparently:
+//   - CreateMessage edits the deferred message of application commands and creates a follow-up message for components & modals.
+//     If the ephemeral flag of the message doesn't match the deferred message, the deferred message is deleted and a follow-up message is created instead.
+//   - UpdateMessage edits the interaction response
+//   - further defers are ignored
+//   - all other responses return ErrAutoDeferred
+//
+// The watchdog is armed once the interaction reaches a route handler, so interactions without a matching route are never deferred.
+// It keeps running after the handler returns, so handlers which respond from another goroutine like with middleware.Go are covered too.
+// If the handler returns an error without having responded, the watchdog is stopped and the ErrorHandler is responsible for the response.
+// Responses sent with the original responder of the events.InteractionCreate, like by another event listener, are not seen by the watchdog,
+// so only enable it if the routes of this router respond to their interactions themselves.
+// A deadline of 0 disables the watchdog.
+// This only works for the root router and will be ignored for sub routers.
+func (r *Mux) AckWatchdog(deadline time.Duration, ephemeral bool) {
+	r.ackDeadline = deadline
+	r.ackEphemeral = ephemeral
+}
+
+// watchAck returns a copy of the given event whose responses go through the returned ack watchdog.
+// The watchdog is armed with ackWatchdog.start.
+func (r *Mux) watchAck(e *events.InteractionCreate) (*events.InteractionCreate, *ackWatchdog) {
+	var deferType discord.InteractionResponseType
+	switch e.Interaction.(type) {
+	case discord.ApplicationCommandInteraction:
+		deferType = discord.InteractionResponseTypeDeferredCreateMessage
+	case discord.ComponentInteraction, discord.ModalSubmitInteraction:
+		deferType = discord.InteractionResponseTypeDeferredUpdateMessage
+	default:
+		return e, nil
+	}
+
+	w := &ackWatchdog{
+		event:     e,
+		respond:   e.Respond,
+		deferType: deferType,
+		ephemeral: r.ackEphemeral,
+		deadline:  r.ackDeadline,
+	}
+
+	watched := *e
+	watched.Respond = w.Respond
+	return &watched, w
+}
+
+type ackWatchdog struct {
+	event     *events.InteractionCreate
+	respond   events.InteractionResponderFunc
+	deferType discord.InteractionResponseType
+	ephemeral bool
+	deadline  time.Duration
+
+	mu        sync.Mutex
+	timer     *time.Timer
+	responded bool
+	deferred  bool
+	stopped   bool
+}
+
+// start arms the watchdog if it is not armed yet. It is safe to call on a nil watchdog.
+func (w *ackWatchdog) start() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil || w.responded || w.stopped {
+		return
+	}
+	w.timer = time.AfterFunc(w.deadline, w.deferInteraction)
+}
+
+// stop stops the watchdog if it has not deferred the interaction yet. It is safe to call on a nil watchdog.
+func (w *ackWatchdog) stop() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopped = true
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+}
+
+func (w *ackWatchdog) deferInteraction() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.responded || w.stopped {
+		return
+	}
+
+	var data discord.InteractionResponseData
+	if w.ephemeral && w.deferType == discord.InteractionResponseTypeDeferredCreateMessage {
+		data = discord.MessageCreate{Flags: discord.MessageFlagEphemeral}
+	}
+	if err := w.respond(w.deferType, data); err != nil {
+		w.event.Client().Logger().Error("ack watchdog failed to defer interaction", slog.Any("err", err), slog.String("interaction_id", w.event.ID().String()))
+		return
+	}
+	w.responded = true
+	w.deferred = true
+}
+
+// Respond is the events.InteractionResponderFunc which replaces the responder of the watched event.
+func (w *ackWatchdog) Respond(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.deferred {
+		if err := w.respond(responseType, data, opts...); err != nil {
+			return err
+		}
+		w.responded = true
+		if w.timer != nil {
+			w.timer.Stop()
+		}
+		return nil
+	}
+
+	client := w.event.Client()
+	switch responseType {
+	case discord.InteractionResponseTypeDeferredCreateMessage, discord.InteractionResponseTypeDeferredUpdateMessage:
+		return nil
+
+	case discord.InteractionResponseTypeCreateMessage:
+		messageCreate, _ := data.(discord.MessageCreate)
+		if w.deferType == discord.InteractionResponseTypeDeferredCreateMessage {
+			// the flags of the deferred message can't be changed, so an ephemeral message must not become an edit of a public one & vice versa
+			if messageCreate.Flags.Has(discord.MessageFlagEphemeral) == w.ephemeral {
+				_, err := client.Rest().UpdateInteractionResponse(w.event.ApplicationID(), w.event.Token(), messageCreateToUpdate(messageCreate), opts...)
+				return err
+			}
+			if err := client.Rest().DeleteInteractionResponse(w.event.ApplicationID(), w.event.Token(), opts...); err != nil {
+				return fmt.Errorf("failed to delete deferred message: %w", err)
+			}
+		}
+		_, err := client.Rest().CreateFollowupMessage(w.event.ApplicationID(), w.event.Token(), messageCreate, opts...)
+		return err
+
+	case discord.InteractionResponseTypeUpdateMessage:
+		messageUpdate, _ := data.(discord.MessageUpdate)
+		_, err := client.Rest().UpdateInteractionResponse(w.event.ApplicationID(), w.event.Token(), messageUpdate, opts...)
+		return err
+	}
+	return ErrAutoDeferred
+}
+
+// messageCreateToUpdate converts a discord.MessageCreate to a discord.MessageUpdate of the deferred message.
+// The flags of a deferred message can't be changed, so they are dropped.
+func messageCreateToUpdate(messageCreate discord.MessageCreate) discord.MessageUpdate {
+	messageUpdate := discord.MessageUpdate{
+		Content:         &messageCreate.Content,
+		Files:           messageCreate.Files,
+		AllowedMentions: messageCreate.AllowedMentions,
+	}
+	if messageCreate.Embeds != nil {
+		messageUpdate.Embeds = &messageCreate.Embeds
+	}
+	if messageCreate.Components != nil {
+		messageUpdate.Components = &messageCreate.Components
+	}
+	if messageCreate.Attachments != nil {
+		attachments := make([]discord.AttachmentUpdate, len(messageCreate.Attachments))
+		for i, attachment := range messageCreate.Attachments {
+			attachments[i] = attachment
+		}
+		messageUpdate.Attachments = &attachments
+	}
+	return messageUpdate
+}
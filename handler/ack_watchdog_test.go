@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo"
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+)
+
+type responseRecorder struct {
+	mu        sync.Mutex
+	responses []discord.InteractionResponseType
+}
+
+func (r *responseRecorder) Respond(responseType discord.InteractionResponseType, _ discord.InteractionResponseData, _ ...rest.RequestOpt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.responses = append(r.responses, responseType)
+	return nil
+}
+
+func (r *responseRecorder) Responses() []discord.InteractionResponseType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.responses
+}
+
+func TestAckWatchdog(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		endpoints []*rest.Endpoint
+	)
+	client, err := disgo.New("MTIzNDU2Nzg5MA.x.y", bot.WithRestClientConfigOpts(rest.WithInterceptors(func(rq *rest.InterceptedRequest, next rest.RoundTrip) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		endpoints = append(endpoints, rq.Endpoint.Endpoint)
+		return rest.NewCannedResponse(rq.Request, http.StatusOK, []byte(`{}`)), nil
+	})))
+	require.NoError(t, err)
+
+	slashData, err := os.ReadFile("testdata/command/slash_command.json")
+	require.NoError(t, err)
+
+	mux := New()
+	mux.AckWatchdog(20*time.Millisecond, false)
+
+	var (
+		delay time.Duration
+		flags discord.MessageFlags
+	)
+	mux.SlashCommand("/foo", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		time.Sleep(delay)
+		return e.CreateMessage(discord.MessageCreate{Content: "bar", Flags: flags})
+	})
+
+	dispatch := func() *responseRecorder {
+		interaction, err := discord.UnmarshalInteraction(slashData)
+		require.NoError(t, err)
+
+		recorder := &responseRecorder{}
+		mux.OnEvent(&events.InteractionCreate{
+			GenericEvent: events.NewGenericEvent(client, 0, 0),
+			Interaction:  interaction,
+			Respond:      recorder.Respond,
+		})
+		return recorder
+	}
+
+	// fast handlers respond directly
+	recorder := dispatch()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []discord.InteractionResponseType{discord.InteractionResponseTypeCreateMessage}, recorder.Responses())
+
+	// slow handlers are deferred & their response edits the deferred message
+	delay = 100 * time.Millisecond
+	recorder = dispatch()
+	assert.Equal(t, []discord.InteractionResponseType{discord.InteractionResponseTypeDeferredCreateMessage}, recorder.Responses())
+
+	mu.Lock()
+	assert.Equal(t, []*rest.Endpoint{rest.UpdateInteractionResponse}, endpoints)
+	endpoints = nil
+	mu.Unlock()
+
+	// ephemeral responses to a public deferred message replace it with an ephemeral follow-up
+	flags = discord.MessageFlagEphemeral
+	recorder = dispatch()
+	assert.Equal(t, []discord.InteractionResponseType{discord.InteractionResponseTypeDeferredCreateMessage}, recorder.Responses())
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []*rest.Endpoint{rest.DeleteInteractionResponse, rest.CreateFollowupMessage}, endpoints)
+}
+
+func TestAckWatchdog_NotArmed(t *testing.T) {
+	client, err := disgo.New("MTIzNDU2Nzg5MA.x.y")
+	require.NoError(t, err)
+
+	slashData, err := os.ReadFile("testdata/command/slash_command.json")
+	require.NoError(t, err)
+
+	testErr := errors.New("test")
+	errorHandled := make(chan struct{}, 1)
+	mux := New()
+	mux.AckWatchdog(10*time.Millisecond, false)
+	mux.Error(func(e *InteractionEvent, err error) {
+		assert.ErrorIs(t, err, testErr)
+		errorHandled <- struct{}{}
+	})
+
+	dispatch := func() *responseRecorder {
+		interaction, err := discord.UnmarshalInteraction(slashData)
+		require.NoError(t, err)
+
+		recorder := &responseRecorder{}
+		mux.OnEvent(&events.InteractionCreate{
+			GenericEvent: events.NewGenericEvent(client, 0, 0),
+			Interaction:  interaction,
+			Respond:      recorder.Respond,
+		})
+		return recorder
+	}
+
+	// interactions without a route are left to other listeners
+	recorder := dispatch()
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, recorder.Responses())
+
+	// the error handler decides whether to respond
+	mux.SlashCommand("/foo", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		return testErr
+	})
+	recorder = dispatch()
+	<-errorHandled
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, recorder.Responses())
+}
+
+func TestAckWatchdog_Race(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		endpoints []*rest.Endpoint
+	)
+	client, err := disgo.New("MTIzNDU2Nzg5MA.x.y", bot.WithRestClientConfigOpts(rest.WithInterceptors(func(rq *rest.InterceptedRequest, next rest.RoundTrip) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		endpoints = append(endpoints, rq.Endpoint.Endpoint)
+		return rest.NewCannedResponse(rq.Request, http.StatusOK, []byte(`{}`)), nil
+	})))
+	require.NoError(t, err)
+
+	slashData, err := os.ReadFile("testdata/command/slash_command.json")
+	require.NoError(t, err)
+
+	const deadline = time.Millisecond
+	mux := New()
+	mux.AckWatchdog(deadline, false)
+	mux.SlashCommand("/foo", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		// respond right around the deadline, so the response races with the watchdog
+		time.Sleep(deadline)
+		return e.CreateMessage(discord.MessageCreate{Content: "bar"})
+	})
+
+	for i := 0; i < 50; i++ {
+		mu.Lock()
+		endpoints = nil
+		mu.Unlock()
+
+		interaction, err := discord.UnmarshalInteraction(slashData)
+		require.NoError(t, err)
+		recorder := &responseRecorder{}
+		mux.OnEvent(&events.InteractionCreate{
+			GenericEvent: events.NewGenericEvent(client, 0, 0),
+			Interaction:  interaction,
+			Respond:      recorder.Respond,
+		})
+
+		// the interaction is either answered directly or deferred & edited, never both
+		mu.Lock()
+		if responses := recorder.Responses(); len(responses) == 1 && responses[0] == discord.InteractionResponseTypeCreateMessage {
+			assert.Empty(t, endpoints)
+		} else {
+			assert.Equal(t, []discord.InteractionResponseType{discord.InteractionResponseTypeDeferredCreateMessage}, responses)
+			assert.Equal(t, []*rest.Endpoint{rest.UpdateInteractionResponse}, endpoints)
+		}
+		mu.Unlock()
+	}
+}
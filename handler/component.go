@@ -14,6 +14,8 @@ type ComponentEvent struct {
 	*events.ComponentInteractionCreate
 	Vars map[string]string
 	Ctx  context.Context
+	// State is the ComponentState if the custom id was created by ComponentStates
+	State *ComponentState
 }
 
 func (e *ComponentEvent) GetInteractionResponse(opts ...rest.RequestOpt) (*discord.Message, error) {
@@ -0,0 +1,366 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+)
+
+// ErrComponentStateExpired is passed to the ErrorHandler when a component or modal with an expired state is used.
+var ErrComponentStateExpired = errors.New("component state expired")
+
+const (
+	// componentStatePrefix is the prefix of all custom ids created by ComponentStates
+	componentStatePrefix = "$state:"
+
+	// componentStateExpireGrace is how much longer states are kept in the store, so they can be passed to the ComponentStateExpireFunc
+	componentStateExpireGrace = time.Minute
+)
+
+// ExpiredComponentState is passed to the ComponentStateExpireFunc when a state expires.
+// ChannelID & MessageID are only set if the message of the state is known, see ComponentStates.Track.
+type ExpiredComponentState struct {
+	CustomID  string
+	Path      string
+	ChannelID snowflake.ID
+	MessageID snowflake.ID
+	Data      json.RawMessage
+}
+
+// ComponentStateExpireFunc is called when a state expires.
+type ComponentStateExpireFunc func(state ExpiredComponentState)
+
+// ComponentStates stores state for components & modals server side and identifies it by opaque custom ids.
+// This allows keeping more state than fits into the 100 characters of a custom id.
+//
+// The custom ids are resolved by the Mux the ComponentStates are set on with Mux.ComponentStates.
+// Interactions are routed to the path the state was created with and the state is available as ComponentEvent.State & ModalEvent.State.
+//
+//	states := handler.NewComponentStates()
+//	r.ComponentStates(states)
+//
+//	customID, err := states.New(ctx, "/wizard/next", WizardState{Step: 1})
+//	...
+//	r.ButtonComponent("/wizard/next", func(data discord.ButtonInteractionData, e *handler.ComponentEvent) error {
+//		state, err := handler.StateAs[WizardState](e.State)
+//		...
+//	})
+type ComponentStates struct {
+	config ComponentStatesConfig
+
+	timersMu sync.Mutex
+	timers   map[string]*time.Timer
+}
+
+// NewComponentStates returns new ComponentStates with the given ComponentStatesConfigOpt(s).
+func NewComponentStates(opts ...ComponentStatesConfigOpt) *ComponentStates {
+	config := DefaultComponentStatesConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "handler_component_states"))
+
+	return &ComponentStates{
+		config: *config,
+		timers: map[string]*time.Timer{},
+	}
+}
+
+// IsComponentStateID returns true if the given custom id was created by ComponentStates.
+func IsComponentStateID(customID string) bool {
+	return strings.HasPrefix(customID, componentStatePrefix)
+}
+
+type componentStateEntry struct {
+	Path      string          `json:"path"`
+	Data      json.RawMessage `json:"data"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	ChannelID snowflake.ID    `json:"channel_id,omitempty"`
+	MessageID snowflake.ID    `json:"message_id,omitempty"`
+}
+
+// New stores the given state and returns a custom id which routes to the given path.
+func (s *ComponentStates) New(ctx context.Context, path string, state any) (string, error) {
+	if !strings.HasPrefix(path, "/") {
+		return "", fmt.Errorf("path must start with /")
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal component state: %w", err)
+	}
+
+	key := make([]byte, 12)
+	_, _ = rand.Read(key)
+	customID := componentStatePrefix + base64.RawURLEncoding.EncodeToString(key)
+
+	entry := componentStateEntry{
+		Path:      path,
+		Data:      data,
+		ExpiresAt: time.Now().Add(s.config.TTL),
+	}
+	if err = s.put(ctx, customID, entry); err != nil {
+		return "", err
+	}
+
+	if s.config.OnExpire != nil {
+		s.timersMu.Lock()
+		s.timers[customID] = time.AfterFunc(s.config.TTL, func() {
+			s.expire(customID)
+		})
+		s.timersMu.Unlock()
+	}
+	return customID, nil
+}
+
+func (s *ComponentStates) put(ctx context.Context, customID string, entry componentStateEntry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal component state: %w", err)
+	}
+	ttl := time.Until(entry.ExpiresAt)
+	if s.config.OnExpire != nil {
+		ttl += componentStateExpireGrace
+	}
+	if err = s.config.Store.Put(ctx, customID, value, ttl); err != nil {
+		return fmt.Errorf("failed to store component state: %w", err)
+	}
+	return nil
+}
+
+func (s *ComponentStates) get(ctx context.Context, customID string) (*componentStateEntry, error) {
+	value, err := s.config.Store.Get(ctx, customID)
+	if errors.Is(err, ErrComponentStateNotFound) {
+		return nil, ErrComponentStateExpired
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get component state: %w", err)
+	}
+	var entry componentStateEntry
+	if err = json.Unmarshal(value, &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal component state: %w", err)
+	}
+	return &entry, nil
+}
+
+// Get returns the state of the given custom id or ErrComponentStateExpired.
+func (s *ComponentStates) Get(ctx context.Context, customID string) (*ComponentState, error) {
+	if !IsComponentStateID(customID) {
+		return nil, ErrComponentStateExpired
+	}
+	entry, err := s.get(ctx, customID)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(entry.ExpiresAt) {
+		return nil, ErrComponentStateExpired
+	}
+	return &ComponentState{
+		states:   s,
+		customID: customID,
+		entry:    *entry,
+	}, nil
+}
+
+// Track remembers the message of all states used by the components of the given message, so the ComponentStateExpireFunc can update it.
+// States are tracked automatically once one of their components is used.
+func (s *ComponentStates) Track(ctx context.Context, message discord.Message) error {
+	for _, container := range message.Components {
+		for _, component := range container.Components() {
+			if !IsComponentStateID(component.ID()) {
+				continue
+			}
+			if err := s.track(ctx, component.ID(), message.ChannelID, message.ID); err != nil && !errors.Is(err, ErrComponentStateExpired) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *ComponentStates) track(ctx context.Context, customID string, channelID snowflake.ID, messageID snowflake.ID) error {
+	entry, err := s.get(ctx, customID)
+	if err != nil {
+		return err
+	}
+	if entry.MessageID == messageID {
+		return nil
+	}
+	entry.ChannelID = channelID
+	entry.MessageID = messageID
+	return s.put(ctx, customID, *entry)
+}
+
+// Delete removes the state of the given custom id.
+func (s *ComponentStates) Delete(ctx context.Context, customID string) error {
+	s.timersMu.Lock()
+	if timer, ok := s.timers[customID]; ok {
+		timer.Stop()
+		delete(s.timers, customID)
+	}
+	s.timersMu.Unlock()
+	return s.config.Store.Delete(ctx, customID)
+}
+
+func (s *ComponentStates) expire(customID string) {
+	s.timersMu.Lock()
+	delete(s.timers, customID)
+	s.timersMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	entry, err := s.get(ctx, customID)
+	if errors.Is(err, ErrComponentStateExpired) {
+		// the state was deleted in the meantime
+		return
+	}
+	if err != nil {
+		s.config.Logger.Error("failed to get expired component state", slog.Any("err", err), slog.String("custom_id", customID))
+		return
+	}
+	if err = s.config.Store.Delete(ctx, customID); err != nil {
+		s.config.Logger.Error("failed to delete expired component state", slog.Any("err", err), slog.String("custom_id", customID))
+	}
+
+	s.config.OnExpire(ExpiredComponentState{
+		CustomID:  customID,
+		Path:      entry.Path,
+		ChannelID: entry.ChannelID,
+		MessageID: entry.MessageID,
+		Data:      entry.Data,
+	})
+}
+
+// ComponentState is the state of a component or modal created with ComponentStates.
+type ComponentState struct {
+	states   *ComponentStates
+	customID string
+	entry    componentStateEntry
+}
+
+// CustomID returns the custom id of the state.
+func (s *ComponentState) CustomID() string {
+	return s.customID
+}
+
+// Path returns the path the state routes to.
+func (s *ComponentState) Path() string {
+	return s.entry.Path
+}
+
+// ExpiresAt returns when the state expires.
+func (s *ComponentState) ExpiresAt() time.Time {
+	return s.entry.ExpiresAt
+}
+
+// tracked sets the message the state is used by after it was tracked.
+func (s *ComponentState) tracked(message discord.Message) {
+	s.entry.ChannelID = message.ChannelID
+	s.entry.MessageID = message.ID
+}
+
+// Decode decodes the state into v.
+func (s *ComponentState) Decode(v any) error {
+	return json.Unmarshal(s.entry.Data, v)
+}
+
+// Set replaces the state with v. The expiry of the state is not changed.
+func (s *ComponentState) Set(ctx context.Context, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal component state: %w", err)
+	}
+	entry := s.entry
+	entry.Data = data
+	if err = s.states.put(ctx, s.customID, entry); err != nil {
+		return err
+	}
+	s.entry = entry
+	return nil
+}
+
+// Delete removes the state, so the components using it stop working.
+func (s *ComponentState) Delete(ctx context.Context) error {
+	return s.states.Delete(ctx, s.customID)
+}
+
+// StateAs decodes the given ComponentState into T. It returns ErrComponentStateExpired if the state is nil.
+func StateAs[T any](state *ComponentState) (T, error) {
+	var v T
+	if state == nil {
+		return v, ErrComponentStateExpired
+	}
+	err := state.Decode(&v)
+	return v, err
+}
+
+// DisableComponentsOnExpire returns a ComponentStateExpireFunc which disables all components using states of the message the expired state belongs to.
+// Only tracked states can be disabled, see ComponentStates.Track. Ephemeral messages can't be updated.
+func DisableComponentsOnExpire(client bot.Client) ComponentStateExpireFunc {
+	return func(state ExpiredComponentState) {
+		if state.MessageID == 0 {
+			return
+		}
+		message, err := client.Rest().GetMessage(state.ChannelID, state.MessageID)
+		if err != nil {
+			client.Logger().Error("failed to get message of expired component state", slog.Any("err", err), slog.String("custom_id", state.CustomID))
+			return
+		}
+
+		var changed bool
+		components := make([]discord.ContainerComponent, len(message.Components))
+		for i, container := range message.Components {
+			components[i] = container
+			row, ok := container.(discord.ActionRowComponent)
+			if !ok {
+				continue
+			}
+			newRow := make(discord.ActionRowComponent, len(row))
+			for j, component := range row {
+				newRow[j] = component
+				if IsComponentStateID(component.ID()) {
+					if disabled, ok := disableComponent(component); ok {
+						newRow[j] = disabled
+						changed = true
+					}
+				}
+			}
+			components[i] = newRow
+		}
+		if !changed {
+			return
+		}
+
+		if _, err = client.Rest().UpdateMessage(state.ChannelID, state.MessageID, discord.MessageUpdate{Components: &components}); err != nil {
+			client.Logger().Error("failed to disable components of expired component state", slog.Any("err", err), slog.String("custom_id", state.CustomID))
+		}
+	}
+}
+
+func disableComponent(component discord.InteractiveComponent) (discord.InteractiveComponent, bool) {
+	switch c := component.(type) {
+	case discord.ButtonComponent:
+		return c.AsDisabled(), !c.Disabled
+	case discord.StringSelectMenuComponent:
+		return c.AsDisabled(), !c.Disabled
+	case discord.UserSelectMenuComponent:
+		return c.AsDisabled(), !c.Disabled
+	case discord.RoleSelectMenuComponent:
+		return c.AsDisabled(), !c.Disabled
+	case discord.MentionableSelectMenuComponent:
+		return c.AsDisabled(), !c.Disabled
+	case discord.ChannelSelectMenuComponent:
+		return c.AsDisabled(), !c.Disabled
+	}
+	return component, false
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DefaultComponentStatesConfig returns the default configuration for ComponentStates.
+func DefaultComponentStatesConfig() *ComponentStatesConfig {
+	return &ComponentStatesConfig{
+		Logger: slog.Default(),
+		TTL:    15 * time.Minute,
+	}
+}
+
+// ComponentStatesConfig is the configuration for ComponentStates.
+type ComponentStatesConfig struct {
+	Logger *slog.Logger
+	// Store stores the states. Defaults to NewMemoryComponentStateStore
+	Store ComponentStateStore
+	// TTL is how long a state lives after it was created
+	TTL time.Duration
+	// OnExpire is called when a state created by this process expires
+	OnExpire ComponentStateExpireFunc
+}
+
+// ComponentStatesConfigOpt is a functional option for configuring ComponentStates.
+type ComponentStatesConfigOpt func(config *ComponentStatesConfig)
+
+// Apply applies the given ComponentStatesConfigOpt(s) to the ComponentStatesConfig.
+func (c *ComponentStatesConfig) Apply(opts []ComponentStatesConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Store == nil {
+		c.Store = NewMemoryComponentStateStore()
+	}
+}
+
+// WithComponentStatesLogger sets the logger of ComponentStates.
+func WithComponentStatesLogger(logger *slog.Logger) ComponentStatesConfigOpt {
+	return func(config *ComponentStatesConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithComponentStateStore sets the ComponentStateStore of ComponentStates.
+func WithComponentStateStore(store ComponentStateStore) ComponentStatesConfigOpt {
+	return func(config *ComponentStatesConfig) {
+		config.Store = store
+	}
+}
+
+// WithComponentStateTTL sets how long states live after they were created.
+func WithComponentStateTTL(ttl time.Duration) ComponentStatesConfigOpt {
+	return func(config *ComponentStatesConfig) {
+		config.TTL = ttl
+	}
+}
+
+// WithComponentStateExpireFunc sets the ComponentStateExpireFunc which is called when a state expires.
+// DisableComponentsOnExpire can be used to disable the components of the message the state belongs to.
+func WithComponentStateExpireFunc(onExpire ComponentStateExpireFunc) ComponentStatesConfigOpt {
+	return func(config *ComponentStatesConfig) {
+		config.OnExpire = onExpire
+	}
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/disgoorg/disgo/internal/ttlmap"
+)
+
+var _ ComponentStateStore = (*memoryComponentStateStore)(nil)
+
+// ErrComponentStateNotFound is returned by a ComponentStateStore when no value is stored for the key.
+var ErrComponentStateNotFound = errors.New("component state not found")
+
+// ComponentStateStore stores the serialized states of ComponentStates.
+// Implementations have to remove values after their ttl and return ErrComponentStateNotFound for missing or expired keys.
+type ComponentStateStore interface {
+	// Put stores the value for the given key, replacing any existing value.
+	Put(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Get returns the value for the given key.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Delete removes the value for the given key.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewMemoryComponentStateStore returns a new in-memory ComponentStateStore.
+func NewMemoryComponentStateStore() ComponentStateStore {
+	return &memoryComponentStateStore{
+		values: ttlmap.New[[]byte](),
+	}
+}
+
+type memoryComponentStateStore struct {
+	values *ttlmap.Map[[]byte]
+}
+
+func (s *memoryComponentStateStore) Put(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.values.Put(key, value, ttl)
+	return nil
+}
+
+func (s *memoryComponentStateStore) Get(_ context.Context, key string) ([]byte, error) {
+	value, ok := s.values.Get(key)
+	if !ok {
+		return nil, ErrComponentStateNotFound
+	}
+	return value, nil
+}
+
+func (s *memoryComponentStateStore) Delete(_ context.Context, key string) error {
+	s.values.Delete(key)
+	return nil
+}
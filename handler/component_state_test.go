@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+type wizardState struct {
+	Step int `json:"step"`
+}
+
+func TestComponentStates(t *testing.T) {
+	expired := make(chan ExpiredComponentState, 1)
+	states := NewComponentStates(
+		WithComponentStateTTL(100*time.Millisecond),
+		WithComponentStateExpireFunc(func(state ExpiredComponentState) {
+			expired <- state
+		}),
+	)
+
+	customID, err := states.New(context.Background(), "/wizard/next", wizardState{Step: 1})
+	require.NoError(t, err)
+	assert.True(t, IsComponentStateID(customID))
+	assert.LessOrEqual(t, len(customID), 100)
+
+	var handlerErr error
+	mux := New()
+	mux.ComponentStates(states)
+	mux.Error(func(e *InteractionEvent, err error) {
+		handlerErr = err
+	})
+	mux.ButtonComponent("/wizard/next", func(data discord.ButtonInteractionData, e *ComponentEvent) error {
+		state, err := StateAs[wizardState](e.State)
+		if err != nil {
+			return err
+		}
+		state.Step++
+		return e.State.Set(e.Ctx, state)
+	})
+
+	buttonData, err := os.ReadFile("testdata/component/button_component.json")
+	require.NoError(t, err)
+	buttonData = []byte(strings.ReplaceAll(string(buttonData), `"custom_id": "foo"`, `"custom_id": "`+customID+`"`))
+
+	dispatch := func() {
+		interaction, err := discord.UnmarshalInteraction(buttonData)
+		require.NoError(t, err)
+
+		handlerErr = nil
+		mux.OnEvent(&events.InteractionCreate{
+			GenericEvent: events.NewGenericEvent(nil, 0, 0),
+			Interaction:  interaction,
+			Respond:      NewRecorder().Respond,
+		})
+	}
+
+	dispatch()
+	require.NoError(t, handlerErr)
+
+	state, err := states.Get(context.Background(), customID)
+	require.NoError(t, err)
+	decoded, err := StateAs[wizardState](state)
+	require.NoError(t, err)
+	assert.Equal(t, 2, decoded.Step)
+
+	select {
+	case e := <-expired:
+		assert.Equal(t, customID, e.CustomID)
+		assert.Equal(t, "/wizard/next", e.Path)
+		assert.Equal(t, snowflake.ID(844397162624450620), e.MessageID)
+		assert.JSONEq(t, `{"step":2}`, string(e.Data))
+	case <-time.After(time.Second):
+		t.Fatal("state did not expire")
+	}
+
+	dispatch()
+	assert.ErrorIs(t, handlerErr, ErrComponentStateExpired)
+}
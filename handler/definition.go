@@ -0,0 +1,457 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var (
+	_ CommandDefinition = (*SlashCommandDefinition)(nil)
+	_ CommandDefinition = (*UserCommandDefinition)(nil)
+	_ CommandDefinition = (*MessageCommandDefinition)(nil)
+)
+
+// slashCommandNameRegex is the regex Discord uses to validate slash command, subcommand & option names.
+var slashCommandNameRegex = regexp.MustCompile(`^[-_\p{Ll}\p{Lo}\p{N}]{1,32}$`)
+
+// CommandDefinition declares an application command together with its handlers.
+// Definitions are registered with Define, which produces the discord.ApplicationCommandCreate(s) to pass to SyncCommands.
+type CommandDefinition interface {
+	// Create returns the discord.ApplicationCommandCreate of the definition.
+	Create() discord.ApplicationCommandCreate
+
+	// Validate returns an error if the definition is invalid or is missing handlers.
+	Validate() error
+
+	register(r Router)
+}
+
+// Define validates the given CommandDefinition(s), registers their handlers on the given Router and returns the discord.ApplicationCommandCreate(s) for SyncCommands.
+// Define panics if a definition is invalid, so mismatches between commands and handlers fail at startup.
+func Define(r Router, definitions ...CommandDefinition) []discord.ApplicationCommandCreate {
+	commands := make([]discord.ApplicationCommandCreate, 0, len(definitions))
+	names := map[string]struct{}{}
+	var errs []error
+	for _, definition := range definitions {
+		if err := definition.Validate(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		command := definition.Create()
+		key := fmt.Sprintf("%d:%s", command.Type(), command.CommandName())
+		if _, ok := names[key]; ok {
+			errs = append(errs, fmt.Errorf("command %q: defined multiple times", command.CommandName()))
+			continue
+		}
+		names[key] = struct{}{}
+		commands = append(commands, command)
+	}
+	if err := errors.Join(errs...); err != nil {
+		panic(err)
+	}
+
+	for _, definition := range definitions {
+		definition.register(r)
+	}
+	return commands
+}
+
+// ValidateCommands returns an error for each command, subcommand or autocomplete option of the given commands which the given Route does not handle.
+// This can be used to check hand written discord.ApplicationCommandCreate(s) against a Router before syncing them.
+func ValidateCommands(r Route, commands []discord.ApplicationCommandCreate) error {
+	var errs []error
+	for _, command := range commands {
+		switch c := command.(type) {
+		case discord.SlashCommandCreate:
+			errs = append(errs, validateSlashCommandRoutes(r, "/"+c.Name, c.Options)...)
+		default:
+			if !r.Match("/"+command.CommandName(), discord.InteractionTypeApplicationCommand, int(command.Type())) {
+				errs = append(errs, fmt.Errorf("command %q: no handler registered", "/"+command.CommandName()))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateSlashCommandRoutes(r Route, path string, options []discord.ApplicationCommandOption) []error {
+	var (
+		errs            []error
+		isLeaf          = true
+		hasAutoComplete bool
+	)
+	for _, option := range options {
+		switch o := option.(type) {
+		case discord.ApplicationCommandOptionSubCommandGroup:
+			isLeaf = false
+			for _, subCommand := range o.Options {
+				errs = append(errs, validateSlashCommandRoutes(r, path+"/"+o.Name+"/"+subCommand.Name, subCommand.Options)...)
+			}
+		case discord.ApplicationCommandOptionSubCommand:
+			isLeaf = false
+			errs = append(errs, validateSlashCommandRoutes(r, path+"/"+o.Name, o.Options)...)
+		default:
+			hasAutoComplete = hasAutoComplete || isAutocompleteOption(option)
+		}
+	}
+	if !isLeaf {
+		return errs
+	}
+	if !r.Match(path, discord.InteractionTypeApplicationCommand, int(discord.ApplicationCommandTypeSlash)) {
+		errs = append(errs, fmt.Errorf("command %q: no handler registered", path))
+	}
+	if hasAutoComplete && !r.Match(path, discord.InteractionTypeAutocomplete, 0) {
+		errs = append(errs, fmt.Errorf("command %q: no autocomplete handler registered", path))
+	}
+	return errs
+}
+
+func isAutocompleteOption(option discord.ApplicationCommandOption) bool {
+	switch o := option.(type) {
+	case discord.ApplicationCommandOptionString:
+		return o.Autocomplete
+	case discord.ApplicationCommandOptionInt:
+		return o.Autocomplete
+	case discord.ApplicationCommandOptionFloat:
+		return o.Autocomplete
+	}
+	return false
+}
+
+// validateOptions checks the names of the given options & whether autocomplete options have an AutocompleteHandler.
+func validateOptions(path string, options []discord.ApplicationCommandOption, autocomplete AutocompleteHandler) []error {
+	var errs []error
+	if len(options) > 25 {
+		errs = append(errs, fmt.Errorf("command %q: must not have more than 25 options", path))
+	}
+	var hasAutocomplete bool
+	names := map[string]struct{}{}
+	for _, option := range options {
+		switch option.(type) {
+		case discord.ApplicationCommandOptionSubCommand, discord.ApplicationCommandOptionSubCommandGroup:
+			errs = append(errs, fmt.Errorf("command %q: option %q: subcommands must be defined via Subcommands or Groups", path, option.OptionName()))
+			continue
+		}
+		if !slashCommandNameRegex.MatchString(option.OptionName()) {
+			errs = append(errs, fmt.Errorf("command %q: option %q: invalid name", path, option.OptionName()))
+		}
+		if _, ok := names[option.OptionName()]; ok {
+			errs = append(errs, fmt.Errorf("command %q: option %q: defined multiple times", path, option.OptionName()))
+		}
+		names[option.OptionName()] = struct{}{}
+		hasAutocomplete = hasAutocomplete || isAutocompleteOption(option)
+	}
+	if hasAutocomplete && autocomplete == nil {
+		errs = append(errs, fmt.Errorf("command %q: has autocomplete options but no Autocomplete handler", path))
+	}
+	if !hasAutocomplete && autocomplete != nil {
+		errs = append(errs, fmt.Errorf("command %q: has an Autocomplete handler but no autocomplete options", path))
+	}
+	return errs
+}
+
+func validateDescription(path string, description string) error {
+	if len(description) == 0 || len([]rune(description)) > 100 {
+		return fmt.Errorf("command %q: description must be between 1 and 100 characters", path)
+	}
+	return nil
+}
+
+// register registers the given handlers with the given middlewares in a new group of the Router.
+func register(r Router, middlewares []Middleware, fn func(r Router)) {
+	if len(middlewares) == 0 {
+		fn(r)
+		return
+	}
+	r.Group(func(r Router) {
+		r.Use(middlewares...)
+		fn(r)
+	})
+}
+
+// SlashCommandDefinition declares a slash command.
+// A slash command either has a Handler & Options or Subcommands and/or Groups.
+type SlashCommandDefinition struct {
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	Description              string
+	DescriptionLocalizations map[discord.Locale]string
+	DefaultMemberPermissions *json.Nullable[discord.Permissions]
+	IntegrationTypes         []discord.ApplicationIntegrationType
+	Contexts                 []discord.InteractionContextType
+	NSFW                     *bool
+
+	// Options must not contain subcommands or subcommand groups, use Subcommands & Groups instead
+	Options      []discord.ApplicationCommandOption
+	Handler      SlashCommandHandler
+	Autocomplete AutocompleteHandler
+
+	Subcommands []SubcommandDefinition
+	Groups      []SubcommandGroupDefinition
+
+	// Middlewares are applied to all handlers of the command
+	Middlewares []Middleware
+}
+
+func (d SlashCommandDefinition) Create() discord.ApplicationCommandCreate {
+	options := d.Options
+	if len(d.Subcommands) > 0 || len(d.Groups) > 0 {
+		options = make([]discord.ApplicationCommandOption, 0, len(d.Groups)+len(d.Subcommands))
+		for _, group := range d.Groups {
+			options = append(options, group.option())
+		}
+		for _, subcommand := range d.Subcommands {
+			options = append(options, subcommand.option())
+		}
+	}
+	return discord.SlashCommandCreate{
+		Name:                     d.Name,
+		NameLocalizations:        d.NameLocalizations,
+		Description:              d.Description,
+		DescriptionLocalizations: d.DescriptionLocalizations,
+		Options:                  options,
+		DefaultMemberPermissions: d.DefaultMemberPermissions,
+		IntegrationTypes:         d.IntegrationTypes,
+		Contexts:                 d.Contexts,
+		NSFW:                     d.NSFW,
+	}
+}
+
+func (d SlashCommandDefinition) Validate() error {
+	path := "/" + d.Name
+	var errs []error
+	if !slashCommandNameRegex.MatchString(d.Name) {
+		errs = append(errs, fmt.Errorf("command %q: invalid name", path))
+	}
+	if err := validateDescription(path, d.Description); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(d.Subcommands) == 0 && len(d.Groups) == 0 {
+		if d.Handler == nil {
+			errs = append(errs, fmt.Errorf("command %q: has no Handler", path))
+		}
+		errs = append(errs, validateOptions(path, d.Options, d.Autocomplete)...)
+		return errors.Join(errs...)
+	}
+
+	if d.Handler != nil || d.Autocomplete != nil || len(d.Options) > 0 {
+		errs = append(errs, fmt.Errorf("command %q: must not have a Handler, Autocomplete or Options when it has Subcommands or Groups", path))
+	}
+	if len(d.Subcommands)+len(d.Groups) > 25 {
+		errs = append(errs, fmt.Errorf("command %q: must not have more than 25 subcommands and groups", path))
+	}
+	names := map[string]struct{}{}
+	for _, group := range d.Groups {
+		if _, ok := names[group.Name]; ok {
+			errs = append(errs, fmt.Errorf("command %q: subcommand group %q: defined multiple times", path, group.Name))
+		}
+		names[group.Name] = struct{}{}
+		errs = append(errs, group.validate(path)...)
+	}
+	for _, subcommand := range d.Subcommands {
+		if _, ok := names[subcommand.Name]; ok {
+			errs = append(errs, fmt.Errorf("command %q: subcommand %q: defined multiple times", path, subcommand.Name))
+		}
+		names[subcommand.Name] = struct{}{}
+		errs = append(errs, subcommand.validate(path)...)
+	}
+	return errors.Join(errs...)
+}
+
+func (d SlashCommandDefinition) register(r Router) {
+	path := "/" + d.Name
+	register(r, d.Middlewares, func(r Router) {
+		if d.Handler != nil {
+			r.SlashCommand(path, d.Handler)
+		}
+		if d.Autocomplete != nil {
+			r.Autocomplete(path, d.Autocomplete)
+		}
+		for _, group := range d.Groups {
+			for _, subcommand := range group.Subcommands {
+				subcommand.register(r, path+"/"+group.Name)
+			}
+		}
+		for _, subcommand := range d.Subcommands {
+			subcommand.register(r, path)
+		}
+	})
+}
+
+// SubcommandGroupDefinition declares a subcommand group of a SlashCommandDefinition.
+type SubcommandGroupDefinition struct {
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	Description              string
+	DescriptionLocalizations map[discord.Locale]string
+	Subcommands              []SubcommandDefinition
+}
+
+func (d SubcommandGroupDefinition) option() discord.ApplicationCommandOptionSubCommandGroup {
+	subcommands := make([]discord.ApplicationCommandOptionSubCommand, len(d.Subcommands))
+	for i, subcommand := range d.Subcommands {
+		subcommands[i] = subcommand.option()
+	}
+	return discord.ApplicationCommandOptionSubCommandGroup{
+		Name:                     d.Name,
+		NameLocalizations:        d.NameLocalizations,
+		Description:              d.Description,
+		DescriptionLocalizations: d.DescriptionLocalizations,
+		Options:                  subcommands,
+	}
+}
+
+func (d SubcommandGroupDefinition) validate(parent string) []error {
+	path := parent + "/" + d.Name
+	var errs []error
+	if !slashCommandNameRegex.MatchString(d.Name) {
+		errs = append(errs, fmt.Errorf("command %q: invalid name", path))
+	}
+	if err := validateDescription(path, d.Description); err != nil {
+		errs = append(errs, err)
+	}
+	if len(d.Subcommands) == 0 || len(d.Subcommands) > 25 {
+		errs = append(errs, fmt.Errorf("command %q: must have between 1 and 25 subcommands", path))
+	}
+	names := map[string]struct{}{}
+	for _, subcommand := range d.Subcommands {
+		if _, ok := names[subcommand.Name]; ok {
+			errs = append(errs, fmt.Errorf("command %q: subcommand %q: defined multiple times", path, subcommand.Name))
+		}
+		names[subcommand.Name] = struct{}{}
+		errs = append(errs, subcommand.validate(path)...)
+	}
+	return errs
+}
+
+// SubcommandDefinition declares a subcommand of a SlashCommandDefinition or SubcommandGroupDefinition.
+type SubcommandDefinition struct {
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	Description              string
+	DescriptionLocalizations map[discord.Locale]string
+	Options                  []discord.ApplicationCommandOption
+	Handler                  SlashCommandHandler
+	Autocomplete             AutocompleteHandler
+
+	// Middlewares are applied to the handlers of the subcommand
+	Middlewares []Middleware
+}
+
+func (d SubcommandDefinition) option() discord.ApplicationCommandOptionSubCommand {
+	return discord.ApplicationCommandOptionSubCommand{
+		Name:                     d.Name,
+		NameLocalizations:        d.NameLocalizations,
+		Description:              d.Description,
+		DescriptionLocalizations: d.DescriptionLocalizations,
+		Options:                  d.Options,
+	}
+}
+
+func (d SubcommandDefinition) validate(parent string) []error {
+	path := parent + "/" + d.Name
+	var errs []error
+	if !slashCommandNameRegex.MatchString(d.Name) {
+		errs = append(errs, fmt.Errorf("command %q: invalid name", path))
+	}
+	if err := validateDescription(path, d.Description); err != nil {
+		errs = append(errs, err)
+	}
+	if d.Handler == nil {
+		errs = append(errs, fmt.Errorf("command %q: has no Handler", path))
+	}
+	return append(errs, validateOptions(path, d.Options, d.Autocomplete)...)
+}
+
+func (d SubcommandDefinition) register(r Router, parent string) {
+	path := parent + "/" + d.Name
+	register(r, d.Middlewares, func(r Router) {
+		r.SlashCommand(path, d.Handler)
+		if d.Autocomplete != nil {
+			r.Autocomplete(path, d.Autocomplete)
+		}
+	})
+}
+
+// UserCommandDefinition declares a user command.
+type UserCommandDefinition struct {
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	DefaultMemberPermissions *json.Nullable[discord.Permissions]
+	IntegrationTypes         []discord.ApplicationIntegrationType
+	Contexts                 []discord.InteractionContextType
+	NSFW                     *bool
+	Handler                  UserCommandHandler
+	Middlewares              []Middleware
+}
+
+func (d UserCommandDefinition) Create() discord.ApplicationCommandCreate {
+	return discord.UserCommandCreate{
+		Name:                     d.Name,
+		NameLocalizations:        d.NameLocalizations,
+		DefaultMemberPermissions: d.DefaultMemberPermissions,
+		IntegrationTypes:         d.IntegrationTypes,
+		Contexts:                 d.Contexts,
+		NSFW:                     d.NSFW,
+	}
+}
+
+func (d UserCommandDefinition) Validate() error {
+	return validateContextMenuCommand(d.Name, d.Handler != nil)
+}
+
+func (d UserCommandDefinition) register(r Router) {
+	register(r, d.Middlewares, func(r Router) {
+		r.UserCommand("/"+d.Name, d.Handler)
+	})
+}
+
+// MessageCommandDefinition declares a message command.
+type MessageCommandDefinition struct {
+	Name                     string
+	NameLocalizations        map[discord.Locale]string
+	DefaultMemberPermissions *json.Nullable[discord.Permissions]
+	IntegrationTypes         []discord.ApplicationIntegrationType
+	Contexts                 []discord.InteractionContextType
+	NSFW                     *bool
+	Handler                  MessageCommandHandler
+	Middlewares              []Middleware
+}
+
+func (d MessageCommandDefinition) Create() discord.ApplicationCommandCreate {
+	return discord.MessageCommandCreate{
+		Name:                     d.Name,
+		NameLocalizations:        d.NameLocalizations,
+		DefaultMemberPermissions: d.DefaultMemberPermissions,
+		IntegrationTypes:         d.IntegrationTypes,
+		Contexts:                 d.Contexts,
+		NSFW:                     d.NSFW,
+	}
+}
+
+func (d MessageCommandDefinition) Validate() error {
+	return validateContextMenuCommand(d.Name, d.Handler != nil)
+}
+
+func (d MessageCommandDefinition) register(r Router) {
+	register(r, d.Middlewares, func(r Router) {
+		r.MessageCommand("/"+d.Name, d.Handler)
+	})
+}
+
+func validateContextMenuCommand(name string, hasHandler bool) error {
+	path := "/" + name
+	var errs []error
+	if len(name) == 0 || len([]rune(name)) > 32 {
+		errs = append(errs, fmt.Errorf("command %q: name must be between 1 and 32 characters", path))
+	}
+	if !hasHandler {
+		errs = append(errs, fmt.Errorf("command %q: has no Handler", path))
+	}
+	return errors.Join(errs...)
+}
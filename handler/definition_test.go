@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+func TestDefine(t *testing.T) {
+	respond := func(content string) SlashCommandHandler {
+		return func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+			return e.CreateMessage(discord.MessageCreate{Content: content})
+		}
+	}
+
+	mux := New()
+	commands := Define(mux,
+		SlashCommandDefinition{
+			Name:        "foo",
+			Description: "foo",
+			Subcommands: []SubcommandDefinition{
+				{
+					Name:        "bar",
+					Description: "bar",
+					Handler:     respond("bar"),
+				},
+			},
+			Groups: []SubcommandGroupDefinition{
+				{
+					Name:        "group",
+					Description: "group",
+					Subcommands: []SubcommandDefinition{
+						{
+							Name:        "baz",
+							Description: "baz",
+							Handler:     respond("baz"),
+						},
+					},
+				},
+			},
+		},
+		UserCommandDefinition{
+			Name: "foo",
+			Handler: func(data discord.UserCommandInteractionData, e *CommandEvent) error {
+				return nil
+			},
+		},
+	)
+	require.Len(t, commands, 2)
+	slashCommand := commands[0].(discord.SlashCommandCreate)
+	require.Len(t, slashCommand.Options, 2)
+	assert.Equal(t, "group", slashCommand.Options[0].OptionName())
+	assert.Equal(t, "bar", slashCommand.Options[1].OptionName())
+	assert.NoError(t, ValidateCommands(mux, commands))
+
+	interaction, err := discord.UnmarshalInteraction([]byte(`{
+		"type": 2,
+		"id": "786008729715212338",
+		"token": "A_UNIQUE_TOKEN",
+		"data": {
+			"type": 1,
+			"id": "771825006014889984",
+			"name": "foo",
+			"options": [{"type": 2, "name": "group", "options": [{"type": 1, "name": "baz"}]}]
+		}
+	}`))
+	require.NoError(t, err)
+
+	recorder := NewRecorder()
+	mux.OnEvent(&events.InteractionCreate{
+		GenericEvent: events.NewGenericEvent(nil, 0, 0),
+		Interaction:  interaction,
+		Respond:      recorder.Respond,
+	})
+	require.NotNil(t, recorder.Response)
+	assert.Equal(t, discord.MessageCreate{Content: "baz"}, recorder.Response.Data)
+}
+
+func TestDefineMismatch(t *testing.T) {
+	assert.Panics(t, func() {
+		Define(New(), SlashCommandDefinition{
+			Name:        "foo",
+			Description: "foo",
+			Subcommands: []SubcommandDefinition{
+				{
+					Name:        "bar",
+					Description: "bar",
+				},
+			},
+		})
+	})
+
+	assert.Panics(t, func() {
+		Define(New(), SlashCommandDefinition{
+			Name:        "foo",
+			Description: "foo",
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionString{Name: "query", Description: "query", Autocomplete: true},
+			},
+			Handler: func(data discord.SlashCommandInteractionData, e *CommandEvent) error { return nil },
+		})
+	})
+
+	mux := New()
+	mux.SlashCommand("/foo/bar", func(data discord.SlashCommandInteractionData, e *CommandEvent) error { return nil })
+	err := ValidateCommands(mux, []discord.ApplicationCommandCreate{
+		discord.SlashCommandCreate{
+			Name:        "foo",
+			Description: "foo",
+			Options: []discord.ApplicationCommandOption{
+				discord.ApplicationCommandOptionSubCommand{Name: "renamed", Description: "renamed"},
+			},
+		},
+	})
+	assert.EqualError(t, err, `command "/foo/renamed": no handler registered`)
+}
@@ -14,6 +14,11 @@
 //
 // The handler iterates over all routes until it finds the fist matching route. If no route matches, the handler will call the NotFoundHandler.
 // The NotFoundHandler can be set via the `NotFound` method on the *Mux. If no NotFoundHandler is set nothing will happen.
+//
+// Instead of writing the discord.ApplicationCommandCreate(s) and routes separately, commands can be declared with a CommandDefinition.
+// Define registers the handlers of the definitions and returns the discord.ApplicationCommandCreate(s) to pass to SyncCommands.
+//
+// State which does not fit into a custom id can be stored server side with ComponentStates, which creates opaque custom ids for components & modals.
 
 package handler
 
@@ -73,6 +78,7 @@ func (h *handlerHolder[T]) Match(path string, t discord.InteractionType, t2 int)
 
 func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 	parseVariables(path, h.pattern, event.Vars)
+	event.ackWatchdog.start()
 
 	switch handler := any(h.handler).(type) {
 	case InteractionHandler:
@@ -137,8 +143,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ComponentInteraction: event.Interaction.(discord.ComponentInteraction),
 				Respond:              event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:  event.Vars,
+			Ctx:   event.Ctx,
+			State: event.State,
 		})
 	case ButtonComponentHandler:
 		componentInteraction := event.Interaction.(discord.ComponentInteraction)
@@ -148,8 +155,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ComponentInteraction: componentInteraction,
 				Respond:              event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:  event.Vars,
+			Ctx:   event.Ctx,
+			State: event.State,
 		})
 	case SelectMenuComponentHandler:
 		componentInteraction := event.Interaction.(discord.ComponentInteraction)
@@ -159,8 +167,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ComponentInteraction: componentInteraction,
 				Respond:              event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:  event.Vars,
+			Ctx:   event.Ctx,
+			State: event.State,
 		})
 	case ModalHandler:
 		return handler(&ModalEvent{
@@ -169,8 +178,9 @@ func (h *handlerHolder[T]) Handle(path string, event *InteractionEvent) error {
 				ModalSubmitInteraction: event.Interaction.(discord.ModalSubmitInteraction),
 				Respond:                event.Respond,
 			},
-			Vars: event.Vars,
-			Ctx:  event.Ctx,
+			Vars:  event.Vars,
+			Ctx:   event.Ctx,
+			State: event.State,
 		})
 	}
 	return errors.New("unknown handler type")
@@ -14,6 +14,10 @@ type InteractionEvent struct {
 	*events.InteractionCreate
 	Vars map[string]string
 	Ctx  context.Context
+	// State is the ComponentState of component & modal interactions with a custom id created by ComponentStates
+	State *ComponentState
+
+	ackWatchdog *ackWatchdog
 }
 
 // CreateMessage responds to the interaction with a new message.
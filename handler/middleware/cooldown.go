@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/handler"
+)
+
+// CooldownScope decides which interactions share a cooldown.
+type CooldownScope int
+
+const (
+	// CooldownScopeUser applies the cooldown per user.
+	CooldownScopeUser CooldownScope = iota
+	// CooldownScopeGuild applies the cooldown per guild. Interactions outside of guilds are limited per user.
+	CooldownScopeGuild
+	// CooldownScopeChannel applies the cooldown per channel.
+	CooldownScopeChannel
+	// CooldownScopeGlobal applies the cooldown to all interactions.
+	CooldownScopeGlobal
+)
+
+// CooldownAlgorithm is the algorithm used to limit the uses.
+type CooldownAlgorithm int
+
+const (
+	// CooldownAlgorithmFixedWindow allows Uses per Window, the window starts with the first use.
+	CooldownAlgorithmFixedWindow CooldownAlgorithm = iota
+	// CooldownAlgorithmTokenBucket allows bursts of Uses, which refill continuously over the Window.
+	CooldownAlgorithmTokenBucket
+)
+
+// CooldownLimit is the limit of a cooldown.
+type CooldownLimit struct {
+	Algorithm CooldownAlgorithm
+	Uses      int
+	Window    time.Duration
+}
+
+// CooldownMessageFunc returns the message sent when an interaction is on cooldown. The message is always sent ephemeral.
+type CooldownMessageFunc func(event *handler.InteractionEvent, retryAfter time.Duration) discord.MessageCreate
+
+// DefaultCooldownMessage tells the user when they can try again.
+func DefaultCooldownMessage(_ *handler.InteractionEvent, retryAfter time.Duration) discord.MessageCreate {
+	return discord.MessageCreate{
+		Content: fmt.Sprintf("You are on cooldown. Try again %s.", discord.FormattedTimestampMention(time.Now().Add(retryAfter).Unix(), discord.TimestampStyleRelative)),
+	}
+}
+
+// DefaultCooldownConfig returns the default configuration of Cooldown.
+func DefaultCooldownConfig() *CooldownConfig {
+	return &CooldownConfig{
+		Scope:   CooldownScopeUser,
+		Message: DefaultCooldownMessage,
+	}
+}
+
+// CooldownConfig is the configuration of Cooldown.
+type CooldownConfig struct {
+	Scope     CooldownScope
+	Algorithm CooldownAlgorithm
+	// Store stores the uses. Defaults to a new NewMemoryCooldownStore per Cooldown
+	Store CooldownStore
+	// Name separates the cooldowns in a shared Store. Defaults to a name unique to the Cooldown
+	Name    string
+	Message CooldownMessageFunc
+}
+
+// CooldownConfigOpt is a functional option for configuring Cooldown.
+type CooldownConfigOpt func(config *CooldownConfig)
+
+// Apply applies the given CooldownConfigOpt(s) to the CooldownConfig.
+func (c *CooldownConfig) Apply(opts []CooldownConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Store == nil {
+		c.Store = NewMemoryCooldownStore()
+	}
+	if c.Name == "" {
+		c.Name = "cooldown-" + strconv.FormatInt(cooldownCounter.Add(1), 10)
+	}
+}
+
+// WithCooldownScope sets the CooldownScope.
+func WithCooldownScope(scope CooldownScope) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.Scope = scope
+	}
+}
+
+// WithCooldownAlgorithm sets the CooldownAlgorithm.
+func WithCooldownAlgorithm(algorithm CooldownAlgorithm) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.Algorithm = algorithm
+	}
+}
+
+// WithCooldownStore sets the CooldownStore.
+func WithCooldownStore(store CooldownStore) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.Store = store
+	}
+}
+
+// WithCooldownName sets the name of the cooldown in the CooldownStore.
+func WithCooldownName(name string) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.Name = name
+	}
+}
+
+// WithCooldownMessage sets the CooldownMessageFunc.
+func WithCooldownMessage(message CooldownMessageFunc) CooldownConfigOpt {
+	return func(config *CooldownConfig) {
+		config.Message = message
+	}
+}
+
+var cooldownCounter atomic.Int64
+
+// Cooldown is a middleware that allows the given number of uses per window and replies with an ephemeral message when the limit is exceeded.
+// Attach it to single routes with handler.Router.WithGroup, handler.Router.Route or handler.Router.Group:
+//
+//	r.WithGroup(middleware.Cooldown(1, 10*time.Second)).SlashCommand("/daily", dailyHandler)
+//
+// Autocomplete interactions are not limited. Cooldown panics if uses or window are not positive.
+func Cooldown(uses int, window time.Duration, opts ...CooldownConfigOpt) handler.Middleware {
+	if uses <= 0 {
+		panic("cooldown uses must be positive")
+	}
+	if window <= 0 {
+		panic("cooldown window must be positive")
+	}
+	config := DefaultCooldownConfig()
+	config.Apply(opts)
+	limit := CooldownLimit{
+		Algorithm: config.Algorithm,
+		Uses:      uses,
+		Window:    window,
+	}
+
+	return func(next handler.Handler) handler.Handler {
+		return func(event *handler.InteractionEvent) error {
+			if event.Type() == discord.InteractionTypeAutocomplete {
+				return next(event)
+			}
+
+			retryAfter, err := config.Store.Take(event.Ctx, cooldownKey(config, event), limit)
+			if err != nil {
+				return fmt.Errorf("failed to check cooldown: %w", err)
+			}
+			if retryAfter <= 0 {
+				return next(event)
+			}
+
+			message := config.Message(event, retryAfter)
+			message.Flags = message.Flags.Add(discord.MessageFlagEphemeral)
+			return event.CreateMessage(message)
+		}
+	}
+}
+
+func cooldownKey(config *CooldownConfig, event *handler.InteractionEvent) string {
+	switch config.Scope {
+	case CooldownScopeGuild:
+		if guildID := event.GuildID(); guildID != nil {
+			return config.Name + ":guild:" + guildID.String()
+		}
+	case CooldownScopeChannel:
+		return config.Name + ":channel:" + event.ChannelID().String()
+	case CooldownScopeGlobal:
+		return config.Name + ":global"
+	}
+	return config.Name + ":user:" + event.User().ID.String()
+}
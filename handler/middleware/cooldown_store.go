@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/disgoorg/disgo/internal/ttlmap"
+)
+
+var _ CooldownStore = (*memoryCooldownStore)(nil)
+
+// CooldownStore stores the usages of cooldown keys.
+// Implementations have to apply the CooldownAlgorithm atomically, so multiple processes can share a store.
+type CooldownStore interface {
+	// Take uses the given key once. If the key has no uses left, it returns how long to wait until the next use.
+	Take(ctx context.Context, key string, limit CooldownLimit) (time.Duration, error)
+}
+
+// NewMemoryCooldownStore returns a new in-memory CooldownStore.
+func NewMemoryCooldownStore() CooldownStore {
+	return &memoryCooldownStore{
+		entries: ttlmap.New[cooldownEntry](),
+	}
+}
+
+type cooldownEntry struct {
+	// uses is the number of uses in the current window for CooldownAlgorithmFixedWindow or the number of tokens left for CooldownAlgorithmTokenBucket
+	uses float64
+	// last is the start of the current window for CooldownAlgorithmFixedWindow or the time of the last refill for CooldownAlgorithmTokenBucket
+	last time.Time
+}
+
+type memoryCooldownStore struct {
+	entries *ttlmap.Map[cooldownEntry]
+}
+
+func (s *memoryCooldownStore) Take(_ context.Context, key string, limit CooldownLimit) (time.Duration, error) {
+	var retryAfter time.Duration
+	// both algorithms are back to the initial state after one window without uses
+	s.entries.Update(key, limit.Window, func(entry cooldownEntry, ok bool) cooldownEntry {
+		now := time.Now()
+		if !ok {
+			entry = cooldownEntry{last: now}
+			if limit.Algorithm == CooldownAlgorithmTokenBucket {
+				entry.uses = float64(limit.Uses)
+			}
+		}
+
+		switch limit.Algorithm {
+		case CooldownAlgorithmTokenBucket:
+			rate := float64(limit.Uses) / limit.Window.Seconds()
+			entry.uses = min(float64(limit.Uses), entry.uses+now.Sub(entry.last).Seconds()*rate)
+			entry.last = now
+			if entry.uses >= 1 {
+				entry.uses--
+			} else {
+				retryAfter = time.Duration(math.Ceil((1 - entry.uses) / rate * float64(time.Second)))
+			}
+
+		default:
+			if !now.Before(entry.last.Add(limit.Window)) {
+				entry.last = now
+				entry.uses = 0
+			}
+			if entry.uses < float64(limit.Uses) {
+				entry.uses++
+			} else {
+				retryAfter = entry.last.Add(limit.Window).Sub(now)
+			}
+		}
+		return entry
+	})
+	return retryAfter, nil
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/handler"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestCooldown(t *testing.T) {
+	slashData, err := os.ReadFile("../testdata/command/slash_command.json")
+	require.NoError(t, err)
+
+	var handled int
+	mux := handler.New()
+	mux.WithGroup(Cooldown(2, time.Minute)).SlashCommand("/foo", func(data discord.SlashCommandInteractionData, e *handler.CommandEvent) error {
+		handled++
+		return nil
+	})
+
+	var responses []discord.InteractionResponse
+	for range 3 {
+		interaction, err := discord.UnmarshalInteraction(slashData)
+		require.NoError(t, err)
+
+		mux.OnEvent(&events.InteractionCreate{
+			GenericEvent: events.NewGenericEvent(nil, 0, 0),
+			Interaction:  interaction,
+			Respond: func(responseType discord.InteractionResponseType, data discord.InteractionResponseData, opts ...rest.RequestOpt) error {
+				responses = append(responses, discord.InteractionResponse{Type: responseType, Data: data})
+				return nil
+			},
+		})
+	}
+
+	assert.Equal(t, 2, handled)
+	require.Len(t, responses, 1)
+	assert.Equal(t, discord.InteractionResponseTypeCreateMessage, responses[0].Type)
+	assert.True(t, responses[0].Data.(discord.MessageCreate).Flags.Has(discord.MessageFlagEphemeral))
+}
+
+func TestCooldown_Invalid(t *testing.T) {
+	assert.Panics(t, func() { Cooldown(0, time.Minute) })
+	assert.Panics(t, func() { Cooldown(1, 0) })
+}
+
+func TestMemoryCooldownStore(t *testing.T) {
+	store := NewMemoryCooldownStore()
+	ctx := context.Background()
+
+	fixedWindow := CooldownLimit{Algorithm: CooldownAlgorithmFixedWindow, Uses: 2, Window: 100 * time.Millisecond}
+	for range 2 {
+		retryAfter, err := store.Take(ctx, "fixed", fixedWindow)
+		require.NoError(t, err)
+		assert.Zero(t, retryAfter)
+	}
+	retryAfter, err := store.Take(ctx, "fixed", fixedWindow)
+	require.NoError(t, err)
+	assert.Positive(t, retryAfter)
+	assert.LessOrEqual(t, retryAfter, fixedWindow.Window)
+
+	tokenBucket := CooldownLimit{Algorithm: CooldownAlgorithmTokenBucket, Uses: 2, Window: 100 * time.Millisecond}
+	for range 2 {
+		retryAfter, err = store.Take(ctx, "bucket", tokenBucket)
+		require.NoError(t, err)
+		assert.Zero(t, retryAfter)
+	}
+	retryAfter, err = store.Take(ctx, "bucket", tokenBucket)
+	require.NoError(t, err)
+	assert.Positive(t, retryAfter)
+	// one token refills after half the window
+	assert.LessOrEqual(t, retryAfter, 50*time.Millisecond)
+
+	time.Sleep(retryAfter)
+	retryAfter, err = store.Take(ctx, "bucket", tokenBucket)
+	require.NoError(t, err)
+	assert.Zero(t, retryAfter)
+}
@@ -14,6 +14,8 @@ type ModalEvent struct {
 	*events.ModalSubmitInteractionCreate
 	Vars map[string]string
 	Ctx  context.Context
+	// State is the ComponentState if the custom id was created by ComponentStates
+	State *ComponentState
 }
 
 func (e *ModalEvent) GetInteractionResponse(opts ...rest.RequestOpt) (*discord.Message, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/disgoorg/disgo/bot"
 	"github.com/disgoorg/disgo/discord"
@@ -35,6 +36,9 @@ type Mux struct {
 	notFoundHandler NotFoundHandler
 	errorHandler    ErrorHandler
 	defaultContext  func() context.Context
+	ackDeadline     time.Duration
+	ackEphemeral    bool
+	componentStates *ComponentStates
 }
 
 // OnEvent is called when a new event is received.
@@ -67,12 +71,24 @@ func (r *Mux) OnEvent(event bot.Event) {
 		ctx = context.Background()
 	}
 
+	var watchdog *ackWatchdog
+	if r.ackDeadline > 0 {
+		e, watchdog = r.watchAck(e)
+	}
+
 	ie := &InteractionEvent{
 		InteractionCreate: e,
 		Ctx:               ctx,
 		Vars:              make(map[string]string),
+		ackWatchdog:       watchdog,
+	}
+	err := r.resolveComponentState(&path, ie)
+	if err == nil {
+		err = r.Handle(path, ie)
 	}
-	if err := r.Handle(path, ie); err != nil {
+	if err != nil {
+		// the error handler decides whether & how to respond
+		watchdog.stop()
 		if r.errorHandler != nil {
 			r.errorHandler(ie, err)
 			return
@@ -81,6 +97,27 @@ func (r *Mux) OnEvent(event bot.Event) {
 	}
 }
 
+// resolveComponentState sets the ComponentState of component & modal interactions with a custom id created by the ComponentStates of the router and replaces the path with the path of the state.
+func (r *Mux) resolveComponentState(path *string, e *InteractionEvent) error {
+	if r.componentStates == nil || !IsComponentStateID(*path) {
+		return nil
+	}
+	state, err := r.componentStates.Get(e.Ctx, *path)
+	if err != nil {
+		return err
+	}
+	if i, ok := e.Interaction.(discord.ComponentInteraction); ok && state.entry.MessageID != i.Message.ID {
+		if err = r.componentStates.Track(e.Ctx, i.Message); err != nil {
+			e.Client().Logger().Error("failed to track component state message", slog.Any("err", err), slog.String("custom_id", *path))
+		} else {
+			state.tracked(i.Message)
+		}
+	}
+	e.State = state
+	*path = state.Path()
+	return nil
+}
+
 // Match returns true if the given path matches the Route.
 func (r *Mux) Match(path string, t discord.InteractionType, t2 int) bool {
 	if r.pattern != "" {
@@ -148,6 +185,14 @@ func (r *Mux) With(middlewares ...Middleware) Router {
 	return newRouter("", middlewares, nil)
 }
 
+// WithGroup returns a new Router with the given middlewares and adds it to the current Router.
+// Handlers registered on the returned Router only run the given middlewares in addition to the ones of the current Router.
+func (r *Mux) WithGroup(middlewares ...Middleware) Router {
+	router := newRouter("", middlewares, nil)
+	r.handle(router)
+	return router
+}
+
 // Group creates a new Router and adds it to the current Router.
 func (r *Mux) Group(fn func(router Router)) {
 	router := New()
@@ -301,6 +346,12 @@ func (r *Mux) Error(h ErrorHandler) {
 	r.errorHandler = h
 }
 
+// ComponentStates sets the ComponentStates which resolve the custom ids of component & modal interactions.
+// This only works for the root router and will be ignored for sub routers.
+func (r *Mux) ComponentStates(states *ComponentStates) {
+	r.componentStates = states
+}
+
 // DefaultContext sets the default context for this router.
 // This context will be used for all interaction events.
 func (r *Mux) DefaultContext(ctx func() context.Context) {
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"os"
 	"testing"
 
@@ -156,3 +157,46 @@ func TestComponentMux(t *testing.T) {
 		assert.Equal(t, d.expected, recorder.Response)
 	}
 }
+
+func TestMuxWithGroup(t *testing.T) {
+	slashData, err := os.ReadFile("testdata/command/slash_command.json")
+	assert.NoError(t, err)
+
+	var middlewareCalled bool
+	mux := New()
+	// routes of a Router returned by With are only reached once it is mounted
+	mux.With(func(next Handler) Handler {
+		return func(event *InteractionEvent) error {
+			return errors.New("not mounted")
+		}
+	}).SlashCommand("/foo", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		return errors.New("not mounted")
+	})
+	mux.WithGroup(func(next Handler) Handler {
+		return func(event *InteractionEvent) error {
+			middlewareCalled = true
+			return next(event)
+		}
+	}).SlashCommand("/foo", func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		return e.CreateMessage(discord.MessageCreate{
+			Content: "bar",
+		})
+	})
+
+	interaction, err := discord.UnmarshalInteraction(slashData)
+	assert.NoError(t, err)
+
+	recorder := NewRecorder()
+	mux.OnEvent(&events.InteractionCreate{
+		GenericEvent: events.NewGenericEvent(nil, 0, 0),
+		Interaction:  interaction,
+		Respond:      recorder.Respond,
+	})
+	assert.True(t, middlewareCalled)
+	assert.Equal(t, &discord.InteractionResponse{
+		Type: discord.InteractionResponseTypeCreateMessage,
+		Data: discord.MessageCreate{
+			Content: "bar",
+		},
+	}, recorder.Response)
+}
@@ -0,0 +1,340 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+var (
+	// ErrOptionRequired is returned when a required option is missing.
+	ErrOptionRequired = errors.New("option is required")
+	// ErrOptionType is returned when an option can't be decoded into the type of its field.
+	ErrOptionType = errors.New("option has an unexpected type")
+	// ErrOptionNotResolved is returned when the resolved data of a user, member, role, channel, attachment or mentionable option is missing.
+	ErrOptionNotResolved = errors.New("option is not resolved")
+)
+
+// OptionError is returned by DecodeOptions when an option can't be decoded.
+// It is passed to the ErrorHandler of the Mux when returned from a SlashCommandHandlerT.
+type OptionError struct {
+	Option string
+	Field  string
+	Err    error
+}
+
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("failed to decode option %q into field %s: %s", e.Option, e.Field, e.Err)
+}
+
+func (e *OptionError) Unwrap() error {
+	return e.Err
+}
+
+// Mentionable is the decoded value of a mentionable option. Either User & Member or Role is set.
+type Mentionable struct {
+	ID     snowflake.ID
+	User   *discord.User
+	Member *discord.ResolvedMember
+	Role   *discord.Role
+}
+
+// SlashCommandHandlerT is a SlashCommandHandler which receives its options decoded into T. See DecodeOptions for the supported fields.
+type SlashCommandHandlerT[T any] func(data T, e *CommandEvent) error
+
+// SlashCommandT returns a SlashCommandHandler which decodes the options into T before calling the given SlashCommandHandlerT.
+// Errors from decoding the options are returned as *OptionError and passed to the ErrorHandler of the Mux.
+// SlashCommandT panics if T is not a struct or has fields of unsupported types.
+//
+//	type BanOptions struct {
+//		User   discord.User `option:"user,required"`
+//		Reason *string      `option:"reason"`
+//	}
+//
+//	r.SlashCommand("/ban", handler.SlashCommandT(func(data BanOptions, e *handler.CommandEvent) error {
+//		...
+//	}))
+func SlashCommandT[T any](h SlashCommandHandlerT[T]) SlashCommandHandler {
+	if _, err := optionFieldsOf(reflect.TypeFor[T]()); err != nil {
+		panic(err)
+	}
+	return func(data discord.SlashCommandInteractionData, e *CommandEvent) error {
+		var v T
+		if err := DecodeOptions(data, &v); err != nil {
+			return err
+		}
+		return h(v, e)
+	}
+}
+
+// DecodeOptions decodes the options of the given discord.SlashCommandInteractionData into the struct v points to.
+//
+// Fields are mapped to options with the `option:"name"` tag, fields without the tag are ignored.
+// Adding `required` like `option:"name,required"` returns an ErrOptionRequired if the option is missing.
+// Pointer fields are left nil when the option is missing, other fields keep their value.
+//
+// The supported field types are:
+//   - string for string options
+//   - all int & uint types for integer options
+//   - float32 & float64 for number & integer options
+//   - bool for boolean options
+//   - discord.User for user & mentionable options
+//   - discord.ResolvedMember & discord.Member for user & mentionable options
+//   - discord.Role for role & mentionable options
+//   - discord.ResolvedChannel for channel options
+//   - discord.Attachment for attachment options
+//   - Mentionable for user, role & mentionable options
+//   - snowflake.ID for user, role, channel, mentionable & attachment options
+func DecodeOptions(data discord.SlashCommandInteractionData, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("v must be a non nil pointer to a struct, got %T", v)
+	}
+	rv = rv.Elem()
+	fields, err := optionFieldsOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		option, ok := data.Option(field.option)
+		if !ok {
+			if field.required {
+				return &OptionError{Option: field.option, Field: field.name, Err: ErrOptionRequired}
+			}
+			continue
+		}
+
+		fv := rv.Field(field.index)
+		if field.pointer {
+			ptr := reflect.New(fv.Type().Elem())
+			fv.Set(ptr)
+			fv = ptr.Elem()
+		}
+		if err = decodeOption(data, option, fv); err != nil {
+			return &OptionError{Option: field.option, Field: field.name, Err: err}
+		}
+	}
+	return nil
+}
+
+var (
+	snowflakeType      = reflect.TypeFor[snowflake.ID]()
+	userType           = reflect.TypeFor[discord.User]()
+	memberType         = reflect.TypeFor[discord.Member]()
+	resolvedMemberType = reflect.TypeFor[discord.ResolvedMember]()
+	roleType           = reflect.TypeFor[discord.Role]()
+	channelType        = reflect.TypeFor[discord.ResolvedChannel]()
+	attachmentType     = reflect.TypeFor[discord.Attachment]()
+	mentionableType    = reflect.TypeFor[Mentionable]()
+)
+
+type optionField struct {
+	index    int
+	name     string
+	option   string
+	required bool
+	pointer  bool
+}
+
+var optionFieldsCache sync.Map
+
+// optionFieldsOf returns the tagged fields of the given struct type
+func optionFieldsOf(t reflect.Type) ([]optionField, error) {
+	if fields, ok := optionFieldsCache.Load(t); ok {
+		return fields.([]optionField), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("options must be decoded into a struct, got %s", t)
+	}
+
+	var fields []optionField
+	for i := range t.NumField() {
+		structField := t.Field(i)
+		tag, ok := structField.Tag.Lookup("option")
+		if !ok || tag == "-" || !structField.IsExported() {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		field := optionField{
+			index:    i,
+			name:     structField.Name,
+			option:   name,
+			required: flags == "required",
+		}
+		if field.option == "" {
+			return nil, fmt.Errorf("field %s.%s: option tag must have a name", t, structField.Name)
+		}
+
+		fieldType := structField.Type
+		if fieldType.Kind() == reflect.Pointer {
+			field.pointer = true
+			fieldType = fieldType.Elem()
+		}
+		if !isSupportedOptionType(fieldType) {
+			return nil, fmt.Errorf("field %s.%s: unsupported option type %s", t, structField.Name, structField.Type)
+		}
+		fields = append(fields, field)
+	}
+
+	optionFieldsCache.Store(t, fields)
+	return fields, nil
+}
+
+func isSupportedOptionType(t reflect.Type) bool {
+	switch t {
+	case snowflakeType, userType, memberType, resolvedMemberType, roleType, channelType, attachmentType, mentionableType:
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func decodeOption(data discord.SlashCommandInteractionData, option discord.SlashCommandOption, fv reflect.Value) error {
+	switch fv.Type() {
+	case snowflakeType:
+		if !hasOptionType(option,
+			discord.ApplicationCommandOptionTypeUser,
+			discord.ApplicationCommandOptionTypeRole,
+			discord.ApplicationCommandOptionTypeChannel,
+			discord.ApplicationCommandOptionTypeMentionable,
+			discord.ApplicationCommandOptionTypeAttachment,
+		) {
+			return ErrOptionType
+		}
+		id, err := optionID(option)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(id))
+		return nil
+
+	case userType:
+		return decodeResolved(option, fv, data.Resolved.Users, discord.ApplicationCommandOptionTypeUser, discord.ApplicationCommandOptionTypeMentionable)
+
+	case resolvedMemberType:
+		return decodeResolved(option, fv, data.Resolved.Members, discord.ApplicationCommandOptionTypeUser, discord.ApplicationCommandOptionTypeMentionable)
+
+	case memberType:
+		var member discord.ResolvedMember
+		if err := decodeResolved(option, reflect.ValueOf(&member).Elem(), data.Resolved.Members, discord.ApplicationCommandOptionTypeUser, discord.ApplicationCommandOptionTypeMentionable); err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(member.Member))
+		return nil
+
+	case roleType:
+		return decodeResolved(option, fv, data.Resolved.Roles, discord.ApplicationCommandOptionTypeRole, discord.ApplicationCommandOptionTypeMentionable)
+
+	case channelType:
+		return decodeResolved(option, fv, data.Resolved.Channels, discord.ApplicationCommandOptionTypeChannel)
+
+	case attachmentType:
+		return decodeResolved(option, fv, data.Resolved.Attachments, discord.ApplicationCommandOptionTypeAttachment)
+
+	case mentionableType:
+		if !hasOptionType(option, discord.ApplicationCommandOptionTypeUser, discord.ApplicationCommandOptionTypeRole, discord.ApplicationCommandOptionTypeMentionable) {
+			return ErrOptionType
+		}
+		id, err := optionID(option)
+		if err != nil {
+			return err
+		}
+		mentionable := Mentionable{ID: id}
+		if user, ok := data.Resolved.Users[id]; ok {
+			mentionable.User = &user
+			if member, ok := data.Resolved.Members[id]; ok {
+				mentionable.Member = &member
+			}
+		} else if role, ok := data.Resolved.Roles[id]; ok {
+			mentionable.Role = &role
+		} else {
+			return ErrOptionNotResolved
+		}
+		fv.Set(reflect.ValueOf(mentionable))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		var v string
+		if !hasOptionType(option, discord.ApplicationCommandOptionTypeString) || json.Unmarshal(option.Value, &v) != nil {
+			return ErrOptionType
+		}
+		fv.SetString(v)
+
+	case reflect.Bool:
+		var v bool
+		if !hasOptionType(option, discord.ApplicationCommandOptionTypeBool) || json.Unmarshal(option.Value, &v) != nil {
+			return ErrOptionType
+		}
+		fv.SetBool(v)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		if !hasOptionType(option, discord.ApplicationCommandOptionTypeInt) || json.Unmarshal(option.Value, &v) != nil || fv.OverflowInt(v) {
+			return ErrOptionType
+		}
+		fv.SetInt(v)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var v uint64
+		if !hasOptionType(option, discord.ApplicationCommandOptionTypeInt) || json.Unmarshal(option.Value, &v) != nil || fv.OverflowUint(v) {
+			return ErrOptionType
+		}
+		fv.SetUint(v)
+
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		if !hasOptionType(option, discord.ApplicationCommandOptionTypeFloat, discord.ApplicationCommandOptionTypeInt) || json.Unmarshal(option.Value, &v) != nil || fv.OverflowFloat(v) {
+			return ErrOptionType
+		}
+		fv.SetFloat(v)
+
+	default:
+		return ErrOptionType
+	}
+	return nil
+}
+
+func hasOptionType(option discord.SlashCommandOption, types ...discord.ApplicationCommandOptionType) bool {
+	return slices.Contains(types, option.Type)
+}
+
+func optionID(option discord.SlashCommandOption) (snowflake.ID, error) {
+	var id snowflake.ID
+	if err := json.Unmarshal(option.Value, &id); err != nil {
+		return 0, ErrOptionType
+	}
+	return id, nil
+}
+
+// decodeResolved sets the resolved entity of the given option
+func decodeResolved[T any](option discord.SlashCommandOption, fv reflect.Value, resolved map[snowflake.ID]T, types ...discord.ApplicationCommandOptionType) error {
+	if !hasOptionType(option, types...) {
+		return ErrOptionType
+	}
+	id, err := optionID(option)
+	if err != nil {
+		return err
+	}
+	v, ok := resolved[id]
+	if !ok {
+		return ErrOptionNotResolved
+	}
+	fv.Set(reflect.ValueOf(v))
+	return nil
+}
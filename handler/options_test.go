@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+)
+
+type testOptions struct {
+	Query       string          `option:"query,required"`
+	Limit       *int            `option:"limit"`
+	Ratio       float64         `option:"ratio"`
+	Ephemeral   bool            `option:"ephemeral"`
+	User        discord.User    `option:"user"`
+	Member      *discord.Member `option:"user"`
+	Role        discord.Role    `option:"role"`
+	RoleID      snowflake.ID    `option:"role"`
+	Mentionable Mentionable     `option:"mentionable"`
+	Ignored     string
+}
+
+func newSlashCommandInteraction(t *testing.T, options string) discord.Interaction {
+	interaction, err := discord.UnmarshalInteraction([]byte(`{
+		"type": 2,
+		"id": "786008729715212338",
+		"token": "A_UNIQUE_TOKEN",
+		"guild_id": "290926798626357999",
+		"data": {
+			"type": 1,
+			"id": "771825006014889984",
+			"name": "foo",
+			"options": ` + options + `,
+			"resolved": {
+				"users": {"53908232506183680": {"id": "53908232506183680", "username": "Mason"}},
+				"members": {"53908232506183680": {"roles": [], "joined_at": "2017-03-13T19:19:14.040000+00:00"}},
+				"roles": {"539082325061836999": {"id": "539082325061836999", "name": "mod"}}
+			}
+		}
+	}`))
+	require.NoError(t, err)
+	return interaction
+}
+
+func TestSlashCommandT(t *testing.T) {
+	var (
+		decoded    testOptions
+		handlerErr error
+	)
+	mux := New()
+	mux.Error(func(e *InteractionEvent, err error) {
+		handlerErr = err
+	})
+	mux.SlashCommand("/foo", SlashCommandT(func(data testOptions, e *CommandEvent) error {
+		decoded = data
+		return nil
+	}))
+
+	dispatch := func(options string) {
+		handlerErr = nil
+		mux.OnEvent(&events.InteractionCreate{
+			GenericEvent: events.NewGenericEvent(nil, 0, 0),
+			Interaction:  newSlashCommandInteraction(t, options),
+			Respond:      NewRecorder().Respond,
+		})
+	}
+
+	dispatch(`[
+		{"name": "query", "type": 3, "value": "test"},
+		{"name": "limit", "type": 4, "value": 10},
+		{"name": "ratio", "type": 10, "value": 0.5},
+		{"name": "ephemeral", "type": 5, "value": true},
+		{"name": "user", "type": 6, "value": "53908232506183680"},
+		{"name": "role", "type": 8, "value": "539082325061836999"},
+		{"name": "mentionable", "type": 9, "value": "539082325061836999"}
+	]`)
+	require.NoError(t, handlerErr)
+	assert.Equal(t, "test", decoded.Query)
+	require.NotNil(t, decoded.Limit)
+	assert.Equal(t, 10, *decoded.Limit)
+	assert.Equal(t, 0.5, decoded.Ratio)
+	assert.True(t, decoded.Ephemeral)
+	assert.Equal(t, "Mason", decoded.User.Username)
+	require.NotNil(t, decoded.Member)
+	assert.Equal(t, "Mason", decoded.Member.User.Username)
+	assert.Equal(t, "mod", decoded.Role.Name)
+	assert.Equal(t, snowflake.ID(539082325061836999), decoded.RoleID)
+	require.NotNil(t, decoded.Mentionable.Role)
+	assert.Nil(t, decoded.Mentionable.User)
+
+	decoded = testOptions{}
+	dispatch(`[{"name": "query", "type": 3, "value": "test"}]`)
+	require.NoError(t, handlerErr)
+	assert.Nil(t, decoded.Limit)
+	assert.Nil(t, decoded.Member)
+
+	dispatch(`[{"name": "limit", "type": 4, "value": 10}]`)
+	var optionErr *OptionError
+	require.True(t, errors.As(handlerErr, &optionErr))
+	assert.Equal(t, "query", optionErr.Option)
+	assert.ErrorIs(t, handlerErr, ErrOptionRequired)
+
+	dispatch(`[{"name": "query", "type": 3, "value": "test"}, {"name": "limit", "type": 3, "value": "10"}]`)
+	assert.ErrorIs(t, handlerErr, ErrOptionType)
+}
+
+func TestSlashCommandTUnsupportedField(t *testing.T) {
+	assert.Panics(t, func() {
+		SlashCommandT(func(data struct {
+			Values []string `option:"values"`
+		}, e *CommandEvent) error {
+			return nil
+		})
+	})
+}
@@ -49,6 +49,9 @@ type Router interface {
 	// With returns a new Router with the given middlewares.
 	With(middlewares ...Middleware) Router
 
+	// WithGroup returns a new Router with the given middlewares and adds it to the current Router.
+	WithGroup(middlewares ...Middleware) Router
+
 	// Group creates a new Router and adds it to the current Router.
 	Group(fn func(r Router))
 
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func TestGuildHandlers_Availability(t *testing.T) {
+	var dispatched []string
+	config := bot.DefaultConfig(GetGatewayHandlers(), GetHTTPServerHandler())
+	config.Apply([]bot.ConfigOpt{
+		bot.WithEventListenerFunc(func(*events.GuildJoin) { dispatched = append(dispatched, "join") }),
+		bot.WithEventListenerFunc(func(*events.GuildAvailable) { dispatched = append(dispatched, "available") }),
+		bot.WithEventListenerFunc(func(*events.GuildUnavailable) { dispatched = append(dispatched, "unavailable") }),
+		bot.WithEventListenerFunc(func(*events.GuildLeave) { dispatched = append(dispatched, "leave") }),
+	})
+	client, err := bot.BuildClient("MTIzNDU2Nzg5MA.x.y", config, DefaultGatewayEventHandlerFunc, DefaultHTTPServerEventHandlerFunc, "linux", "disgo", "", "")
+	require.NoError(t, err)
+
+	guildCreate := func(guildID snowflake.ID) {
+		var event gateway.EventGuildCreate
+		event.ID = guildID
+		client.EventManager().HandleGatewayEvent(gateway.EventTypeGuildCreate, 0, 0, event)
+	}
+	guildDelete := func(guildID snowflake.ID, unavailable bool) {
+		var event gateway.EventGuildDelete
+		event.ID = guildID
+		event.Unavailable = unavailable
+		client.EventManager().HandleGatewayEvent(gateway.EventTypeGuildDelete, 0, 0, event)
+	}
+
+	// a guild which was never unavailable is joined
+	guildCreate(1)
+	assert.Equal(t, []string{"join"}, dispatched)
+	assert.False(t, client.Caches().IsGuildUnavailable(1))
+
+	// an outage marks the guild unavailable until it is created again
+	guildDelete(1, true)
+	assert.True(t, client.Caches().IsGuildUnavailable(1))
+	guildCreate(1)
+	assert.Equal(t, []string{"join", "unavailable", "available"}, dispatched)
+	assert.False(t, client.Caches().IsGuildUnavailable(1))
+
+	// leaving a guild doesn't mark it unavailable, so joining it again is a join
+	guildDelete(1, false)
+	assert.False(t, client.Caches().IsGuildUnavailable(1))
+	guildCreate(1)
+	assert.Equal(t, []string{"join", "unavailable", "available", "leave", "join"}, dispatched)
+}
@@ -187,10 +187,18 @@ func HandleInteraction(publicKey PublicKey, logger *slog.Logger, handleFunc Even
 		rsBody := &bytes.Buffer{}
 		multiWriter := io.MultiWriter(w, rsBody)
 
-		if multiPart, ok := body.(*discord.MultipartBuffer); ok {
+		switch multiPart := body.(type) {
+		case *discord.MultipartBuffer:
 			w.Header().Set("Content-Type", multiPart.ContentType)
 			_, err = io.Copy(multiWriter, multiPart.Buffer)
-		} else {
+		case *discord.MultipartStream:
+			w.Header().Set("Content-Type", multiPart.ContentType)
+			var reader io.ReadCloser
+			if reader, err = multiPart.Open(); err == nil {
+				_, err = io.Copy(multiWriter, reader)
+				_ = reader.Close()
+			}
+		default:
 			w.Header().Set("Content-Type", "application/json")
 			err = json.NewEncoder(multiWriter).Encode(body)
 		}
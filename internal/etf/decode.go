@@ -0,0 +1,889 @@
+package etf
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// RawTerm is a raw ETF encoded term including the version byte.
+// It can be used to delay decoding of a term, like json.RawMessage.
+type RawTerm []byte
+
+// UnmarshalTypeError is returned when a term can't be decoded into a Go value of the given type.
+type UnmarshalTypeError struct {
+	Term string
+	Type reflect.Type
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	return "etf: cannot unmarshal " + e.Term + " into Go value of type " + e.Type.String()
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	rawTermType         = reflect.TypeFor[RawTerm]()
+	snowflakeType       = reflect.TypeFor[snowflake.ID]()
+)
+
+// Unmarshal decodes an ETF encoded term directly into the value pointed to by v.
+// Terms are decoded like encoding/json decodes their ToJSON representation: struct fields are matched by their json tags, nil & null atoms are treated as null
+// and integers can be decoded into integer, float & string values.
+// Types implementing json.Unmarshaler receive the JSON representation of their term, except snowflake.ID which is decoded directly.
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("etf: Unmarshal requires a non nil pointer")
+	}
+	d, err := newDecoder(data)
+	if err != nil {
+		return err
+	}
+	if err = d.value(rv.Elem(), 0); err != nil {
+		return err
+	}
+	return d.end()
+}
+
+func (d *decoder) value(v reflect.Value, depth int) error {
+	if depth > maxNestingDepth {
+		return ErrTooDeep
+	}
+	if d.pos >= len(d.data) {
+		return ErrUnexpectedEnd
+	}
+	tag := int(d.data[d.pos])
+
+	if v.Kind() == reflect.Pointer {
+		if d.null() {
+			v.SetZero()
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.value(v.Elem(), depth)
+	}
+
+	info := cachedTypeInfo(v.Type())
+	switch {
+	case info.raw:
+		start := d.pos
+		if err := d.skip(depth); err != nil {
+			return err
+		}
+		raw := make(RawTerm, 0, d.pos-start+1)
+		raw = append(raw, tagVersion)
+		v.SetBytes(append(raw, d.data[start:d.pos]...))
+		return nil
+
+	case info.snowflake && tag == tagBinary:
+		return d.snowflake(v)
+
+	case info.jsonUnmarshaler && !(info.snowflake && isInteger(tag)):
+		data, err := d.term(nil, depth)
+		if err != nil {
+			return err
+		}
+		return v.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(data)
+	}
+
+	if d.null() {
+		switch v.Kind() {
+		case reflect.Interface, reflect.Map, reflect.Slice:
+			v.SetZero()
+		}
+		return nil
+	}
+
+	if v.Kind() == reflect.Interface {
+		if v.NumMethod() > 0 {
+			return &UnmarshalTypeError{Term: termName(tag), Type: v.Type()}
+		}
+		x, err := d.any(depth)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(&x).Elem())
+		return nil
+	}
+
+	d.pos++
+	switch tag {
+	case tagSmallInteger, tagInteger:
+		i, err := d.integer(tag)
+		if err != nil {
+			return err
+		}
+		if i < 0 {
+			return setInteger(v, true, uint64(-i))
+		}
+		return setInteger(v, false, uint64(i))
+
+	case tagSmallBig, tagLargeBig:
+		negative, digits, err := d.big(tag)
+		if err != nil {
+			return err
+		}
+		if len(digits) > 8 {
+			return setBig(v, bigInt(negative, digits))
+		}
+		return setInteger(v, negative, littleEndian(digits))
+
+	case tagNewFloat, tagFloat:
+		f, err := d.float(tag)
+		if err != nil {
+			return err
+		}
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64:
+			if v.OverflowFloat(f) {
+				return &UnmarshalTypeError{Term: "float " + strconv.FormatFloat(f, 'g', -1, 64), Type: v.Type()}
+			}
+			v.SetFloat(f)
+			return nil
+		}
+		return &UnmarshalTypeError{Term: "float", Type: v.Type()}
+
+	case tagAtom, tagAtomUTF8, tagSmallAtom, tagSmallAtomUTF8:
+		atom, err := d.bytes(tag)
+		if err != nil {
+			return err
+		}
+		switch string(atom) {
+		case atomTrue, atomFalse:
+			if v.Kind() != reflect.Bool {
+				return &UnmarshalTypeError{Term: "bool", Type: v.Type()}
+			}
+			v.SetBool(string(atom) == atomTrue)
+			return nil
+		}
+		return setString(v, info, atom)
+
+	case tagBinary:
+		b, err := d.bytes(tag)
+		if err != nil {
+			return err
+		}
+		return setString(v, info, b)
+
+	case tagString:
+		b, err := d.bytes(tag)
+		if err != nil {
+			return err
+		}
+		return d.list(v, len(b), func(e reflect.Value, i int) error {
+			return setInteger(e, false, uint64(b[i]))
+		})
+
+	case tagNil:
+		return d.list(v, 0, nil)
+
+	case tagList, tagSmallTuple, tagLargeTuple:
+		n, err := d.length(tag)
+		if err != nil {
+			return err
+		}
+		if err = d.list(v, n, func(e reflect.Value, _ int) error {
+			return d.value(e, depth+1)
+		}); err != nil {
+			return err
+		}
+		if tag == tagList {
+			return d.tail()
+		}
+		return nil
+
+	case tagMap:
+		n, err := d.length(tag)
+		if err != nil {
+			return err
+		}
+		switch v.Kind() {
+		case reflect.Struct:
+			return d.structValue(v, info, n, depth)
+		case reflect.Map:
+			return d.mapValue(v, n, depth)
+		}
+		return &UnmarshalTypeError{Term: "map", Type: v.Type()}
+
+	default:
+		d.pos--
+		return &UnmarshalTypeError{Term: termName(tag), Type: v.Type()}
+	}
+}
+
+// list decodes n elements into a slice or array using decode, which is called for every element.
+func (d *decoder) list(v reflect.Value, n int, decode func(e reflect.Value, i int) error) error {
+	switch v.Kind() {
+	case reflect.Slice:
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			if err := decode(s.Index(i), i); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+
+	case reflect.Array:
+		if n > v.Len() {
+			return &UnmarshalTypeError{Term: "list of length " + strconv.Itoa(n), Type: v.Type()}
+		}
+		for i := 0; i < v.Len(); i++ {
+			if i >= n {
+				v.Index(i).SetZero()
+				continue
+			}
+			if err := decode(v.Index(i), i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return &UnmarshalTypeError{Term: "list", Type: v.Type()}
+}
+
+func (d *decoder) structValue(v reflect.Value, info *typeInfo, n int, depth int) error {
+	fields := info.structFields()
+	for i := 0; i < n; i++ {
+		key, err := d.mapKey()
+		if err != nil {
+			return err
+		}
+		f := fields.lookup(key)
+		if f == nil {
+			if err = d.skip(depth + 1); err != nil {
+				return err
+			}
+			continue
+		}
+		fv := fieldByIndex(v, f.index)
+		if f.quoted {
+			err = d.quoted(fv, depth+1)
+		} else {
+			err = d.value(fv, depth+1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *decoder) mapValue(v reflect.Value, n int, depth int) error {
+	t := v.Type()
+	if v.IsNil() {
+		v.Set(reflect.MakeMapWithSize(t, n))
+	}
+	for i := 0; i < n; i++ {
+		key, err := d.mapKey()
+		if err != nil {
+			return err
+		}
+		kv := reflect.New(t.Key()).Elem()
+		if err = setKey(kv, key); err != nil {
+			return err
+		}
+		ev := reflect.New(t.Elem()).Elem()
+		if err = d.value(ev, depth+1); err != nil {
+			return err
+		}
+		v.SetMapIndex(kv, ev)
+	}
+	return nil
+}
+
+// quoted decodes a field tagged with the json string option, which holds its value as JSON in a string.
+func (d *decoder) quoted(v reflect.Value, depth int) error {
+	if d.pos >= len(d.data) || d.data[d.pos] != tagBinary {
+		return d.value(v, depth)
+	}
+	d.pos++
+	b, err := d.bytes(tagBinary)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v.Addr().Interface())
+}
+
+// snowflake decodes a snowflake.ID sent as a binary. Snowflakes sent as integers are decoded like any other integer.
+func (d *decoder) snowflake(v reflect.Value) error {
+	d.pos++
+	b, err := d.bytes(tagBinary)
+	if err != nil {
+		return err
+	}
+	id, err := strconv.ParseUint(string(b), 10, 64)
+	if err != nil {
+		return fmt.Errorf("etf: failed to parse snowflake: %w", err)
+	}
+	v.SetUint(id)
+	return nil
+}
+
+// mapKey reads a map key. Atoms, binaries and strings are returned as is, all other terms are returned as their JSON representation.
+func (d *decoder) mapKey() ([]byte, error) {
+	if d.pos >= len(d.data) {
+		return nil, ErrUnexpectedEnd
+	}
+	switch tag := int(d.data[d.pos]); tag {
+	case tagAtom, tagAtomUTF8, tagSmallAtom, tagSmallAtomUTF8, tagBinary, tagString:
+		d.pos++
+		return d.bytes(tag)
+	}
+	return d.term(nil, 0)
+}
+
+// null reports whether the next term is the nil or null atom and skips it if so.
+func (d *decoder) null() bool {
+	start := d.pos
+	tag, err := d.readUint8()
+	if err == nil {
+		switch tag {
+		case tagAtom, tagAtomUTF8, tagSmallAtom, tagSmallAtomUTF8:
+			atom, err := d.bytes(tag)
+			if err == nil && (string(atom) == atomNil || string(atom) == atomNull) {
+				return true
+			}
+		}
+	}
+	d.pos = start
+	return false
+}
+
+// any decodes the next term into the value encoding/json would decode its JSON representation into.
+func (d *decoder) any(depth int) (any, error) {
+	if depth > maxNestingDepth {
+		return nil, ErrTooDeep
+	}
+	tag, err := d.readUint8()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case tagSmallInteger, tagInteger:
+		i, err := d.integer(tag)
+		return float64(i), err
+
+	case tagSmallBig, tagLargeBig:
+		negative, digits, err := d.big(tag)
+		if err != nil {
+			return nil, err
+		}
+		if len(digits) <= 8 {
+			if u := littleEndian(digits); u <= maxSafeInteger {
+				if negative {
+					return -float64(u), nil
+				}
+				return float64(u), nil
+			}
+		}
+		return bigInt(negative, digits).String(), nil
+
+	case tagNewFloat, tagFloat:
+		return d.float(tag)
+
+	case tagAtom, tagAtomUTF8, tagSmallAtom, tagSmallAtomUTF8:
+		atom, err := d.bytes(tag)
+		if err != nil {
+			return nil, err
+		}
+		switch string(atom) {
+		case atomNil, atomNull:
+			return nil, nil
+		case atomTrue:
+			return true, nil
+		case atomFalse:
+			return false, nil
+		}
+		return validString(atom), nil
+
+	case tagBinary:
+		b, err := d.bytes(tag)
+		if err != nil {
+			return nil, err
+		}
+		return validString(b), nil
+
+	case tagString:
+		b, err := d.bytes(tag)
+		if err != nil {
+			return nil, err
+		}
+		s := make([]any, len(b))
+		for i, c := range b {
+			s[i] = float64(c)
+		}
+		return s, nil
+
+	case tagNil:
+		return []any{}, nil
+
+	case tagList, tagSmallTuple, tagLargeTuple:
+		n, err := d.length(tag)
+		if err != nil {
+			return nil, err
+		}
+		s := make([]any, n)
+		for i := range s {
+			if s[i], err = d.any(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		if tag == tagList {
+			if err = d.tail(); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+
+	case tagMap:
+		n, err := d.length(tag)
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[string]any, n)
+		for i := 0; i < n; i++ {
+			key, err := d.mapKey()
+			if err != nil {
+				return nil, err
+			}
+			if m[validString(key)], err = d.any(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+
+	default:
+		return nil, fmt.Errorf("etf: unsupported tag %d", tag)
+	}
+}
+
+// skip skips the next term without decoding it.
+func (d *decoder) skip(depth int) error {
+	if depth > maxNestingDepth {
+		return ErrTooDeep
+	}
+	tag, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+
+	var n int
+	switch tag {
+	case tagSmallInteger:
+		_, err = d.read(1)
+	case tagInteger:
+		_, err = d.read(4)
+	case tagNewFloat:
+		_, err = d.read(8)
+	case tagFloat:
+		_, err = d.read(31)
+	case tagSmallBig, tagLargeBig:
+		_, _, err = d.big(tag)
+	case tagAtom, tagAtomUTF8, tagSmallAtom, tagSmallAtomUTF8, tagBinary, tagString:
+		_, err = d.bytes(tag)
+	case tagNil:
+	case tagList, tagSmallTuple, tagLargeTuple:
+		if n, err = d.length(tag); err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if err = d.skip(depth + 1); err != nil {
+				return err
+			}
+		}
+		if tag == tagList {
+			err = d.tail()
+		}
+	case tagMap:
+		if n, err = d.length(tag); err != nil {
+			return err
+		}
+		for i := 0; i < n*2; i++ {
+			if err = d.skip(depth + 1); err != nil {
+				return err
+			}
+		}
+	default:
+		err = fmt.Errorf("etf: unsupported tag %d", tag)
+	}
+	return err
+}
+
+func setInteger(v reflect.Value, negative bool, u uint64) error {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := int64(u)
+		if negative {
+			i = -i
+		}
+		if u > math.MaxInt64 && !(negative && u == 1<<63) || v.OverflowInt(i) {
+			break
+		}
+		v.SetInt(i)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if negative || v.OverflowUint(u) {
+			break
+		}
+		v.SetUint(u)
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		f := float64(u)
+		if negative {
+			f = -f
+		}
+		v.SetFloat(f)
+		return nil
+
+	case reflect.String:
+		s := strconv.FormatUint(u, 10)
+		if negative {
+			s = "-" + s
+		}
+		v.SetString(s)
+		return nil
+
+	case reflect.Interface:
+		if v.NumMethod() == 0 {
+			f := float64(u)
+			if negative {
+				f = -f
+			}
+			v.Set(reflect.ValueOf(f))
+			return nil
+		}
+
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setInteger(v.Elem(), negative, u)
+	}
+
+	s := strconv.FormatUint(u, 10)
+	if negative {
+		s = "-" + s
+	}
+	return &UnmarshalTypeError{Term: "integer " + s, Type: v.Type()}
+}
+
+func setBig(v reflect.Value, b *big.Int) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(b.String())
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, _ := new(big.Float).SetInt(b).Float64()
+		v.SetFloat(f)
+		return nil
+	}
+	return &UnmarshalTypeError{Term: "integer " + b.String(), Type: v.Type()}
+}
+
+func setString(v reflect.Value, info *typeInfo, b []byte) error {
+	if info.textUnmarshaler {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(b)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(validString(b))
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			dst := make([]byte, base64.StdEncoding.DecodedLen(len(b)))
+			n, err := base64.StdEncoding.Decode(dst, b)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(dst[:n])
+			return nil
+		}
+	}
+	return &UnmarshalTypeError{Term: "string", Type: v.Type()}
+}
+
+func setKey(v reflect.Value, key []byte) error {
+	if reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(key)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(validString(key))
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(string(key), 10, 64)
+		if err != nil || v.OverflowInt(i) {
+			break
+		}
+		v.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(string(key), 10, 64)
+		if err != nil || v.OverflowUint(u) {
+			break
+		}
+		v.SetUint(u)
+		return nil
+	}
+	return &UnmarshalTypeError{Term: "map key " + strconv.Quote(string(key)), Type: v.Type()}
+}
+
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func littleEndian(digits []byte) uint64 {
+	var u uint64
+	for i := len(digits) - 1; i >= 0; i-- {
+		u = u<<8 | uint64(digits[i])
+	}
+	return u
+}
+
+func bigInt(negative bool, digits []byte) *big.Int {
+	// digits are little endian, big.Int expects big endian
+	be := make([]byte, len(digits))
+	for i, b := range digits {
+		be[len(digits)-1-i] = b
+	}
+	v := new(big.Int).SetBytes(be)
+	if negative {
+		v.Neg(v)
+	}
+	return v
+}
+
+// validString replaces invalid UTF-8 like encoding/json does.
+func validString(b []byte) string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	return strings.ToValidUTF8(string(b), "�")
+}
+
+func isInteger(tag int) bool {
+	return tag == tagSmallInteger || tag == tagInteger || tag == tagSmallBig || tag == tagLargeBig
+}
+
+func termName(tag int) string {
+	switch tag {
+	case tagSmallInteger, tagInteger, tagSmallBig, tagLargeBig:
+		return "integer"
+	case tagNewFloat, tagFloat:
+		return "float"
+	case tagAtom, tagAtomUTF8, tagSmallAtom, tagSmallAtomUTF8:
+		return "atom"
+	case tagBinary:
+		return "string"
+	case tagString, tagNil, tagList, tagSmallTuple, tagLargeTuple:
+		return "list"
+	case tagMap:
+		return "map"
+	}
+	return "tag " + strconv.Itoa(tag)
+}
+
+type typeInfo struct {
+	typ             reflect.Type
+	raw             bool
+	snowflake       bool
+	jsonUnmarshaler bool
+	textUnmarshaler bool
+
+	fieldsOnce sync.Once
+	fields     structFields
+}
+
+func (i *typeInfo) structFields() *structFields {
+	i.fieldsOnce.Do(func() {
+		i.fields = typeFields(i.typ)
+	})
+	return &i.fields
+}
+
+var typeInfos sync.Map // map[reflect.Type]*typeInfo
+
+func cachedTypeInfo(t reflect.Type) *typeInfo {
+	if info, ok := typeInfos.Load(t); ok {
+		return info.(*typeInfo)
+	}
+	pt := reflect.PointerTo(t)
+	info, _ := typeInfos.LoadOrStore(t, &typeInfo{
+		typ:             t,
+		raw:             t == rawTermType,
+		snowflake:       t == snowflakeType,
+		jsonUnmarshaler: pt.Implements(jsonUnmarshalerType),
+		textUnmarshaler: pt.Implements(textUnmarshalerType),
+	})
+	return info.(*typeInfo)
+}
+
+type field struct {
+	name   string
+	index  []int
+	tagged bool
+	quoted bool
+}
+
+type structFields struct {
+	byName map[string]*field
+	byFold map[string]*field
+}
+
+func (f *structFields) lookup(key []byte) *field {
+	if field, ok := f.byName[string(key)]; ok {
+		return field
+	}
+	return f.byFold[strings.ToLower(string(key))]
+}
+
+// typeFields returns the fields encoding/json would decode into for the given struct type.
+// Fields of embedded structs are promoted, shallower fields hide deeper ones and conflicting fields at the same depth are ignored unless exactly one of them is tagged.
+func typeFields(t reflect.Type) structFields {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+
+	fields := structFields{
+		byName: map[string]*field{},
+		byFold: map[string]*field{},
+	}
+	hidden := map[string]bool{}
+	visited := map[reflect.Type]bool{}
+	current := []embedded{{typ: t}}
+	for len(current) > 0 {
+		var next []embedded
+		candidates := map[string][]*field{}
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				if sf.Anonymous {
+					ft := sf.Type
+					if ft.Kind() == reflect.Pointer {
+						if !sf.IsExported() {
+							// unexported embedded pointers can't be allocated
+							continue
+						}
+						ft = ft.Elem()
+					}
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := make([]int, len(e.index)+1)
+				copy(index, e.index)
+				index[len(e.index)] = i
+
+				ft := sf.Type
+				if ft.Name() == "" && ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+					next = append(next, embedded{typ: ft, index: index})
+					continue
+				}
+
+				f := &field{
+					name:   name,
+					index:  index,
+					tagged: name != "",
+					quoted: strings.Contains(","+opts+",", ",string,") && isQuotable(ft.Kind()),
+				}
+				if f.name == "" {
+					f.name = sf.Name
+				}
+				candidates[f.name] = append(candidates[f.name], f)
+			}
+		}
+
+		for name, fs := range candidates {
+			if _, ok := fields.byName[name]; ok || hidden[name] {
+				continue
+			}
+			if f := dominantField(fs); f != nil {
+				fields.byName[name] = f
+			} else {
+				hidden[name] = true
+			}
+		}
+		current = next
+	}
+
+	for name, f := range fields.byName {
+		fold := strings.ToLower(name)
+		if existing, ok := fields.byFold[fold]; !ok || name < existing.name {
+			fields.byFold[fold] = f
+		}
+	}
+	return fields
+}
+
+func dominantField(fs []*field) *field {
+	if len(fs) == 1 {
+		return fs[0]
+	}
+	var dominant *field
+	for _, f := range fs {
+		if !f.tagged {
+			continue
+		}
+		if dominant != nil {
+			return nil
+		}
+		dominant = f
+	}
+	return dominant
+}
+
+func isQuotable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return true
+	}
+	return false
+}
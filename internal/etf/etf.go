@@ -0,0 +1,573 @@
+// Package etf decodes the Erlang External Term Format used by the Discord gateway into Go values and transcodes between it and JSON.
+// See here for more information: https://www.erlang.org/doc/apps/erts/erl_ext_dist.html
+package etf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	tagVersion       = 131
+	tagCompressed    = 80
+	tagNewFloat      = 70
+	tagSmallInteger  = 97
+	tagInteger       = 98
+	tagFloat         = 99
+	tagAtom          = 100
+	tagSmallTuple    = 104
+	tagLargeTuple    = 105
+	tagNil           = 106
+	tagString        = 107
+	tagList          = 108
+	tagBinary        = 109
+	tagSmallBig      = 110
+	tagLargeBig      = 111
+	tagSmallAtom     = 115
+	tagMap           = 116
+	tagAtomUTF8      = 118
+	tagSmallAtomUTF8 = 119
+)
+
+const (
+	atomNil   = "nil"
+	atomNull  = "null"
+	atomTrue  = "true"
+	atomFalse = "false"
+)
+
+const (
+	// maxSafeInteger is the largest integer a float64 can represent exactly.
+	maxSafeInteger  = 1<<53 - 1
+	maxNestingDepth = 10000
+	hexDigits       = "0123456789abcdef"
+)
+
+var (
+	// ErrInvalidVersion is returned when the term does not start with the ETF version byte.
+	ErrInvalidVersion = errors.New("etf: invalid version byte")
+	// ErrUnexpectedEnd is returned when the term ends prematurely.
+	ErrUnexpectedEnd = errors.New("etf: unexpected end of data")
+	// ErrTooDeep is returned when the term is nested too deeply.
+	ErrTooDeep = errors.New("etf: term nested too deeply")
+)
+
+// ToJSON converts an ETF encoded term to JSON.
+// Atoms nil & null become null, true & false become booleans and all other atoms become strings.
+// Integers which can't be represented exactly by a float64 (like snowflakes) are encoded as JSON strings, like Discord does in its JSON encoding.
+func ToJSON(data []byte) ([]byte, error) {
+	d, err := newDecoder(data)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(data)*2)
+	if out, err = d.term(out, 0); err != nil {
+		return nil, err
+	}
+	if err = d.end(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+func newDecoder(data []byte) (*decoder, error) {
+	if len(data) == 0 || data[0] != tagVersion {
+		return nil, ErrInvalidVersion
+	}
+	d := &decoder{data: data, pos: 1}
+	if len(data) > 1 && data[1] == tagCompressed {
+		if err := d.decompress(); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (d *decoder) end() error {
+	if d.pos != len(d.data) {
+		return fmt.Errorf("etf: %d trailing bytes", len(d.data)-d.pos)
+	}
+	return nil
+}
+
+func (d *decoder) decompress() error {
+	d.pos++
+	if _, err := d.read(4); err != nil {
+		return err
+	}
+	r, err := zlib.NewReader(bytes.NewReader(d.data[d.pos:]))
+	if err != nil {
+		return fmt.Errorf("etf: failed to decompress term: %w", err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("etf: failed to decompress term: %w", err)
+	}
+	d.data = data
+	d.pos = 0
+	return nil
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, ErrUnexpectedEnd
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *decoder) readUint8() (int, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return int(b[0]), nil
+}
+
+func (d *decoder) readUint16() (int, error) {
+	b, err := d.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(b)), nil
+}
+
+func (d *decoder) readUint32() (int, error) {
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint32(b)), nil
+}
+
+// length reads the length prefix of a term with the given tag.
+func (d *decoder) length(tag int) (int, error) {
+	switch tag {
+	case tagSmallBig, tagSmallTuple, tagSmallAtom, tagSmallAtomUTF8:
+		return d.readUint8()
+	case tagAtom, tagAtomUTF8, tagString:
+		return d.readUint16()
+	default:
+		return d.readUint32()
+	}
+}
+
+func (d *decoder) integer(tag int) (int64, error) {
+	if tag == tagSmallInteger {
+		v, err := d.readUint8()
+		return int64(v), err
+	}
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return int64(int32(binary.BigEndian.Uint32(b))), nil
+}
+
+func (d *decoder) float(tag int) (float64, error) {
+	if tag == tagNewFloat {
+		b, err := d.read(8)
+		if err != nil {
+			return 0, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	}
+	b, err := d.read(31)
+	if err != nil {
+		return 0, err
+	}
+	f, err := strconv.ParseFloat(string(bytes.TrimRight(b, "\x00")), 64)
+	if err != nil {
+		return 0, fmt.Errorf("etf: invalid float: %w", err)
+	}
+	return f, nil
+}
+
+// big reads the sign and the little endian digits of a bignum.
+func (d *decoder) big(tag int) (bool, []byte, error) {
+	n, err := d.length(tag)
+	if err != nil {
+		return false, nil, err
+	}
+	sign, err := d.readUint8()
+	if err != nil {
+		return false, nil, err
+	}
+	digits, err := d.read(n)
+	if err != nil {
+		return false, nil, err
+	}
+	return sign != 0, digits, nil
+}
+
+// bytes reads the content of an atom, binary or string term.
+func (d *decoder) bytes(tag int) ([]byte, error) {
+	n, err := d.length(tag)
+	if err != nil {
+		return nil, err
+	}
+	return d.read(n)
+}
+
+func (d *decoder) term(out []byte, depth int) ([]byte, error) {
+	if depth > maxNestingDepth {
+		return nil, ErrTooDeep
+	}
+	tag, err := d.readUint8()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case tagSmallInteger, tagInteger:
+		v, err := d.integer(tag)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.AppendInt(out, v, 10), nil
+
+	case tagNewFloat, tagFloat:
+		f, err := d.float(tag)
+		if err != nil {
+			return nil, err
+		}
+		return appendFloat(out, f), nil
+
+	case tagSmallBig, tagLargeBig:
+		negative, digits, err := d.big(tag)
+		if err != nil {
+			return nil, err
+		}
+		return appendBig(out, negative, digits), nil
+
+	case tagAtom, tagAtomUTF8, tagSmallAtom, tagSmallAtomUTF8:
+		atom, err := d.bytes(tag)
+		if err != nil {
+			return nil, err
+		}
+		switch string(atom) {
+		case atomNil, atomNull:
+			return append(out, atomNull...), nil
+		case atomTrue:
+			return append(out, atomTrue...), nil
+		case atomFalse:
+			return append(out, atomFalse...), nil
+		}
+		return appendString(out, atom), nil
+
+	case tagBinary:
+		b, err := d.bytes(tag)
+		if err != nil {
+			return nil, err
+		}
+		return appendString(out, b), nil
+
+	case tagString:
+		// STRING_EXT is how Erlang encodes lists of small integers, like the shard array of the ready event
+		b, err := d.bytes(tag)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, '[')
+		for i, c := range b {
+			if i > 0 {
+				out = append(out, ',')
+			}
+			out = strconv.AppendUint(out, uint64(c), 10)
+		}
+		return append(out, ']'), nil
+
+	case tagNil:
+		return append(out, "[]"...), nil
+
+	case tagList:
+		n, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		if out, err = d.array(out, n, depth); err != nil {
+			return nil, err
+		}
+		if err = d.tail(); err != nil {
+			return nil, err
+		}
+		return out, nil
+
+	case tagSmallTuple, tagLargeTuple:
+		n, err := d.length(tag)
+		if err != nil {
+			return nil, err
+		}
+		return d.array(out, n, depth)
+
+	case tagMap:
+		n, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, '{')
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				out = append(out, ',')
+			}
+			if out, err = d.key(out); err != nil {
+				return nil, err
+			}
+			out = append(out, ':')
+			if out, err = d.term(out, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return append(out, '}'), nil
+
+	default:
+		return nil, fmt.Errorf("etf: unsupported tag %d", tag)
+	}
+}
+
+// tail reads the end of a list. Proper lists end with NIL_EXT, improper tails are not supported.
+func (d *decoder) tail() error {
+	tail, err := d.readUint8()
+	if err != nil {
+		return err
+	}
+	if tail != tagNil {
+		return fmt.Errorf("etf: improper lists are not supported")
+	}
+	return nil
+}
+
+func (d *decoder) array(out []byte, n int, depth int) ([]byte, error) {
+	var err error
+	out = append(out, '[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		if out, err = d.term(out, depth+1); err != nil {
+			return nil, err
+		}
+	}
+	return append(out, ']'), nil
+}
+
+// key decodes a map key which always has to be a JSON string.
+func (d *decoder) key(out []byte) ([]byte, error) {
+	start := len(out)
+	out, err := d.term(out, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > start && out[start] == '"' {
+		return out, nil
+	}
+	// non string keys like integers are quoted
+	return appendString(out[:start], append([]byte(nil), out[start:]...)), nil
+}
+
+func appendFloat(out []byte, f float64) []byte {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return append(out, atomNull...)
+	}
+	return strconv.AppendFloat(out, f, 'g', -1, 64)
+}
+
+func appendBig(out []byte, negative bool, digits []byte) []byte {
+	if len(digits) <= 8 {
+		var v uint64
+		for i := len(digits) - 1; i >= 0; i-- {
+			v = v<<8 | uint64(digits[i])
+		}
+		if v <= maxSafeInteger {
+			if negative {
+				out = append(out, '-')
+			}
+			return strconv.AppendUint(out, v, 10)
+		}
+		out = append(out, '"')
+		if negative {
+			out = append(out, '-')
+		}
+		return append(strconv.AppendUint(out, v, 10), '"')
+	}
+
+	// digits are little endian, big.Int expects big endian
+	be := make([]byte, len(digits))
+	for i, b := range digits {
+		be[len(digits)-1-i] = b
+	}
+	v := new(big.Int).SetBytes(be)
+	if negative {
+		v.Neg(v)
+	}
+	out = append(out, '"')
+	return append(v.Append(out, 10), '"')
+}
+
+func appendString(out []byte, s []byte) []byte {
+	out = append(out, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				out = append(out, '\\', c)
+			case c == '\n':
+				out = append(out, '\\', 'n')
+			case c == '\r':
+				out = append(out, '\\', 'r')
+			case c == '\t':
+				out = append(out, '\\', 't')
+			case c < 0x20:
+				out = append(out, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			default:
+				out = append(out, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			out = append(out, `�`...)
+		} else {
+			out = append(out, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(out, '"')
+}
+
+// FromJSON converts JSON to an ETF encoded term.
+// Objects become maps with binary keys, strings become binaries and null becomes the nil atom.
+func FromJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, tagVersion)
+	return appendTerm(out, v)
+}
+
+func appendTerm(out []byte, v any) ([]byte, error) {
+	var err error
+	switch v := v.(type) {
+	case nil:
+		return appendAtom(out, atomNil), nil
+
+	case bool:
+		if v {
+			return appendAtom(out, atomTrue), nil
+		}
+		return appendAtom(out, atomFalse), nil
+
+	case string:
+		out = append(out, tagBinary)
+		out = binary.BigEndian.AppendUint32(out, uint32(len(v)))
+		return append(out, v...), nil
+
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return appendInt(out, i), nil
+		}
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return appendUint(out, false, u), nil
+		}
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("etf: invalid number %q: %w", v, err)
+		}
+		out = append(out, tagNewFloat)
+		return binary.BigEndian.AppendUint64(out, math.Float64bits(f)), nil
+
+	case []any:
+		if len(v) == 0 {
+			return append(out, tagNil), nil
+		}
+		out = append(out, tagList)
+		out = binary.BigEndian.AppendUint32(out, uint32(len(v)))
+		for _, e := range v {
+			if out, err = appendTerm(out, e); err != nil {
+				return nil, err
+			}
+		}
+		return append(out, tagNil), nil
+
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		// sort keys to produce deterministic output
+		sort.Strings(keys)
+
+		out = append(out, tagMap)
+		out = binary.BigEndian.AppendUint32(out, uint32(len(v)))
+		for _, k := range keys {
+			if out, err = appendTerm(out, k); err != nil {
+				return nil, err
+			}
+			if out, err = appendTerm(out, v[k]); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+
+	default:
+		return nil, fmt.Errorf("etf: unsupported type %T", v)
+	}
+}
+
+func appendAtom(out []byte, atom string) []byte {
+	out = append(out, tagSmallAtomUTF8, byte(len(atom)))
+	return append(out, atom...)
+}
+
+func appendInt(out []byte, i int64) []byte {
+	switch {
+	case i >= 0 && i <= math.MaxUint8:
+		return append(out, tagSmallInteger, byte(i))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		out = append(out, tagInteger)
+		return binary.BigEndian.AppendUint32(out, uint32(int32(i)))
+	case i < 0:
+		return appendUint(out, true, uint64(-(i+1))+1)
+	default:
+		return appendUint(out, false, uint64(i))
+	}
+}
+
+func appendUint(out []byte, negative bool, u uint64) []byte {
+	var digits []byte
+	for u > 0 {
+		digits = append(digits, byte(u))
+		u >>= 8
+	}
+	out = append(out, tagSmallBig, byte(len(digits)))
+	if negative {
+		out = append(out, 1)
+	} else {
+		out = append(out, 0)
+	}
+	return append(out, digits...)
+}
@@ -0,0 +1,152 @@
+package etf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// readyPayload is term_to_binary(#{op => 0, s => 1, t => 'READY', d => #{v => 10, session_id => <<"abc">>, shard => [0, 1],
+// user => #{id => 81384788765712384, username => <<"disgo">>, avatar => nil, bot => true}}}).
+// Erlang encodes the shard list of small integers as STRING_EXT.
+var readyPayload = []byte{
+	131, 116, 0, 0, 0, 4,
+	119, 1, 'd', 116, 0, 0, 0, 4,
+	119, 10, 's', 'e', 's', 's', 'i', 'o', 'n', '_', 'i', 'd', 109, 0, 0, 0, 3, 'a', 'b', 'c',
+	119, 5, 's', 'h', 'a', 'r', 'd', 107, 0, 2, 0, 1,
+	119, 4, 'u', 's', 'e', 'r', 116, 0, 0, 0, 4,
+	119, 6, 'a', 'v', 'a', 't', 'a', 'r', 119, 3, 'n', 'i', 'l',
+	119, 3, 'b', 'o', 't', 119, 4, 't', 'r', 'u', 'e',
+	119, 2, 'i', 'd', 110, 8, 0, 0, 32, 128, 192, 8, 35, 33, 1,
+	119, 8, 'u', 's', 'e', 'r', 'n', 'a', 'm', 'e', 109, 0, 0, 0, 5, 'd', 'i', 's', 'g', 'o',
+	119, 1, 'v', 97, 10,
+	119, 2, 'o', 'p', 97, 0,
+	119, 1, 's', 97, 1,
+	119, 1, 't', 119, 5, 'R', 'E', 'A', 'D', 'Y',
+}
+
+// compressedPayload is term_to_binary(lists:duplicate(20, <<"disgo">>), [compressed]).
+var compressedPayload = []byte{
+	131, 80, 0, 0, 0, 206,
+	120, 156, 203, 97, 96, 96, 16, 201, 5, 18, 172, 41, 153, 197, 233, 249, 67, 155, 149, 5, 0, 56, 44, 51, 139,
+}
+
+type testMessage struct {
+	Op int     `json:"op"`
+	S  int     `json:"s"`
+	T  string  `json:"t"`
+	D  RawTerm `json:"d"`
+}
+
+type testUser struct {
+	ID       snowflake.ID `json:"id"`
+	Username string       `json:"username"`
+	Avatar   *string      `json:"avatar"`
+	Bot      bool         `json:"bot"`
+}
+
+type testReady struct {
+	V         int      `json:"v"`
+	SessionID string   `json:"session_id"`
+	Shard     [2]int   `json:"shard"`
+	User      testUser `json:"user"`
+}
+
+func TestToJSON(t *testing.T) {
+	data, err := ToJSON(readyPayload)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"op":0,"s":1,"t":"READY","d":{"v":10,"session_id":"abc","shard":[0,1],"user":{"id":"81384788765712384","username":"disgo","avatar":null,"bot":true}}}`, string(data))
+}
+
+func TestUnmarshal(t *testing.T) {
+	var message testMessage
+	err := Unmarshal(readyPayload, &message)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, message.Op)
+	assert.Equal(t, 1, message.S)
+	assert.Equal(t, "READY", message.T)
+
+	var ready testReady
+	err = Unmarshal(message.D, &ready)
+	assert.NoError(t, err)
+	assert.Equal(t, testReady{
+		V:         10,
+		SessionID: "abc",
+		Shard:     [2]int{0, 1},
+		User: testUser{
+			ID:       81384788765712384,
+			Username: "disgo",
+			Bot:      true,
+		},
+	}, ready)
+
+	var generic map[string]any
+	err = Unmarshal(message.D, &generic)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{float64(0), float64(1)}, generic["shard"])
+	assert.Equal(t, "81384788765712384", generic["user"].(map[string]any)["id"])
+}
+
+func TestUnmarshal_Compressed(t *testing.T) {
+	var names []string
+	err := Unmarshal(compressedPayload, &names)
+	assert.NoError(t, err)
+	assert.Len(t, names, 20)
+	assert.Equal(t, "disgo", names[19])
+
+	data, err := ToJSON(compressedPayload)
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte(`["disgo","disgo",`)))
+}
+
+func TestUnmarshal_Big(t *testing.T) {
+	// term_to_binary(-9223372036854775808)
+	var i int64
+	err := Unmarshal([]byte{131, 110, 8, 1, 0, 0, 0, 0, 0, 0, 0, 128}, &i)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MinInt64), i)
+
+	// term_to_binary(1 bsl 2048) is a LARGE_BIG_EXT with 257 digits
+	large := []byte{131, 111, 0, 0, 1, 1, 0}
+	large = append(large, make([]byte, 256)...)
+	large = append(large, 1)
+
+	var s string
+	err = Unmarshal(large, &s)
+	assert.NoError(t, err)
+	assert.Len(t, s, 617)
+	assert.Equal(t, "32317006071311007300714876688669951960444102669715484032130345427524655138867890", s[:80])
+
+	data, err := ToJSON(large)
+	assert.NoError(t, err)
+	assert.Equal(t, `"`+s+`"`, string(data))
+
+	var u uint64
+	err = Unmarshal(large, &u)
+	var typeErr *UnmarshalTypeError
+	assert.True(t, errors.As(err, &typeErr))
+}
+
+func TestUnmarshal_JSONUnmarshaler(t *testing.T) {
+	// term_to_binary(#{<<"time">> => <<"2021-01-01T00:00:00Z">>, <<"raw">> => [1, 2]})
+	data := []byte{
+		131, 116, 0, 0, 0, 2,
+		109, 0, 0, 0, 3, 'r', 'a', 'w', 107, 0, 2, 1, 2,
+		109, 0, 0, 0, 4, 't', 'i', 'm', 'e', 109, 0, 0, 0, 20, '2', '0', '2', '1', '-', '0', '1', '-', '0', '1', 'T', '0', '0', ':', '0', '0', ':', '0', '0', 'Z',
+	}
+
+	var v struct {
+		Time time.Time       `json:"time"`
+		Raw  json.RawMessage `json:"raw"`
+	}
+	err := Unmarshal(data, &v)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), v.Time)
+	assert.Equal(t, json.RawMessage(`[1,2]`), v.Raw)
+}
@@ -0,0 +1,100 @@
+// Package ttlmap implements a thread safe in-memory map whose values expire.
+package ttlmap
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often expired values are removed on writes.
+const sweepInterval = time.Minute
+
+// New returns a new empty Map.
+func New[V any]() *Map[V] {
+	return &Map[V]{
+		entries: map[string]entry[V]{},
+	}
+}
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Map is a thread safe map whose values expire after their ttl.
+// Expired values are never returned. They are removed when read and by a sweep on writes, which runs at most once per minute.
+type Map[V any] struct {
+	mu        sync.Mutex
+	entries   map[string]entry[V]
+	lastSweep time.Time
+}
+
+// sweep removes all expired values. It must be called with the lock held.
+func (m *Map[V]) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) <= sweepInterval {
+		return
+	}
+	for key, e := range m.entries {
+		if now.After(e.expiresAt) {
+			delete(m.entries, key)
+		}
+	}
+	m.lastSweep = now
+}
+
+// get returns the value of the key if it is not expired. It must be called with the lock held.
+func (m *Map[V]) get(key string, now time.Time) (V, bool) {
+	e, ok := m.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if now.After(e.expiresAt) {
+		delete(m.entries, key)
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Get returns the value of the key or false if it is missing or expired.
+func (m *Map[V]) Get(key string) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.get(key, time.Now())
+}
+
+// Put stores the value for the key, replacing any existing value.
+func (m *Map[V]) Put(key string, value V, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+	m.entries[key] = entry[V]{
+		value:     value,
+		expiresAt: now.Add(ttl),
+	}
+}
+
+// Update atomically replaces the value of the key with the one returned by updateFunc and resets its ttl.
+// updateFunc receives the current value and false if the key is missing or expired.
+func (m *Map[V]) Update(key string, ttl time.Duration, updateFunc func(value V, ok bool) V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.sweep(now)
+	value, ok := m.get(key, now)
+	m.entries[key] = entry[V]{
+		value:     updateFunc(value, ok),
+		expiresAt: now.Add(ttl),
+	}
+}
+
+// Delete removes the value of the key.
+func (m *Map[V]) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+}
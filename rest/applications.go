@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"iter"
+
 	"github.com/disgoorg/disgo/internal/slicehelper"
 	"github.com/disgoorg/snowflake/v2"
 
@@ -38,6 +40,7 @@ type Applications interface {
 	UpdateApplicationRoleConnectionMetadata(applicationID snowflake.ID, newRecords []discord.ApplicationRoleConnectionMetadata, opts ...RequestOpt) ([]discord.ApplicationRoleConnectionMetadata, error)
 
 	GetEntitlements(applicationID snowflake.ID, userID snowflake.ID, guildID snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, excludeEnded bool, skuIDs []snowflake.ID, opts ...RequestOpt) ([]discord.Entitlement, error)
+	GetEntitlementsIter(applicationID snowflake.ID, userID snowflake.ID, guildID snowflake.ID, excludeEnded bool, skuIDs []snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.Entitlement, error]
 	CreateTestEntitlement(applicationID snowflake.ID, entitlementCreate discord.TestEntitlementCreate, opts ...RequestOpt) (*discord.Entitlement, error)
 	DeleteTestEntitlement(applicationID snowflake.ID, entitlementID snowflake.ID, opts ...RequestOpt) error
 	ConsumeEntitlement(applicationID snowflake.ID, entitlementID snowflake.ID, opts ...RequestOpt) error
@@ -207,6 +210,14 @@ func (s *applicationsImpl) GetEntitlements(applicationID snowflake.ID, userID sn
 	return
 }
 
+func (s *applicationsImpl) GetEntitlementsIter(applicationID snowflake.ID, userID snowflake.ID, guildID snowflake.ID, excludeEnded bool, skuIDs []snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.Entitlement, error] {
+	return iterate(params, 100, []Direction{DirectionBefore, DirectionAfter}, func(entitlement discord.Entitlement) snowflake.ID {
+		return entitlement.ID
+	}, func(before snowflake.ID, after snowflake.ID, limit int) ([]discord.Entitlement, error) {
+		return s.GetEntitlements(applicationID, userID, guildID, before, after, limit, excludeEnded, skuIDs, opts...)
+	})
+}
+
 func (s *applicationsImpl) CreateTestEntitlement(applicationID snowflake.ID, entitlementCreate discord.TestEntitlementCreate, opts ...RequestOpt) (entitlement *discord.Entitlement, err error) {
 	err = s.client.Do(CreateTestEntitlement.Compile(nil, applicationID), entitlementCreate, &entitlement, opts...)
 	return
@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"iter"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -30,6 +32,7 @@ type Channels interface {
 	GetMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 	GetMessages(channelID snowflake.ID, around snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Message, error)
 	GetMessagesPage(channelID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.Message]
+	GetMessagesIter(channelID snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.Message, error]
 	CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (*discord.Message, error)
 	UpdateMessage(channelID snowflake.ID, messageID snowflake.ID, messageUpdate discord.MessageUpdate, opts ...RequestOpt) (*discord.Message, error)
 	DeleteMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) error
@@ -37,6 +40,7 @@ type Channels interface {
 	CrosspostMessage(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 
 	GetReactions(channelID snowflake.ID, messageID snowflake.ID, emoji string, reactionType discord.MessageReactionType, after int, limit int, opts ...RequestOpt) ([]discord.User, error)
+	GetReactionsIter(channelID snowflake.ID, messageID snowflake.ID, emoji string, reactionType discord.MessageReactionType, params IterParams, opts ...RequestOpt) iter.Seq2[discord.User, error]
 	AddReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error
 	RemoveOwnReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error
 	RemoveUserReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, userID snowflake.ID, opts ...RequestOpt) error
@@ -50,6 +54,7 @@ type Channels interface {
 
 	GetPollAnswerVotes(channelID snowflake.ID, messageID snowflake.ID, answerID int, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.User, error)
 	GetPollAnswerVotesPage(channelID snowflake.ID, messageID snowflake.ID, answerID int, startID snowflake.ID, limit int, opts ...RequestOpt) PollAnswerVotesPage
+	GetPollAnswerVotesIter(channelID snowflake.ID, messageID snowflake.ID, answerID int, params IterParams, opts ...RequestOpt) iter.Seq2[discord.User, error]
 	ExpirePoll(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (*discord.Message, error)
 }
 
@@ -153,6 +158,14 @@ func (s *channelImpl) GetMessagesPage(channelID snowflake.ID, startID snowflake.
 	}
 }
 
+func (s *channelImpl) GetMessagesIter(channelID snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.Message, error] {
+	return iterate(params, 100, []Direction{DirectionBefore, DirectionAfter}, func(message discord.Message) snowflake.ID {
+		return message.ID
+	}, func(before snowflake.ID, after snowflake.ID, limit int) ([]discord.Message, error) {
+		return s.GetMessages(channelID, 0, before, after, limit, opts...)
+	})
+}
+
 func (s *channelImpl) CreateMessage(channelID snowflake.ID, messageCreate discord.MessageCreate, opts ...RequestOpt) (message *discord.Message, err error) {
 	body, err := messageCreate.ToBody()
 	if err != nil {
@@ -198,6 +211,14 @@ func (s *channelImpl) GetReactions(channelID snowflake.ID, messageID snowflake.I
 	return
 }
 
+func (s *channelImpl) GetReactionsIter(channelID snowflake.ID, messageID snowflake.ID, emoji string, reactionType discord.MessageReactionType, params IterParams, opts ...RequestOpt) iter.Seq2[discord.User, error] {
+	return iterate(params, 100, []Direction{DirectionAfter}, func(user discord.User) snowflake.ID {
+		return user.ID
+	}, func(_ snowflake.ID, after snowflake.ID, limit int) ([]discord.User, error) {
+		return s.GetReactions(channelID, messageID, emoji, reactionType, int(after), limit, opts...)
+	})
+}
+
 func (s *channelImpl) AddReaction(channelID snowflake.ID, messageID snowflake.ID, emoji string, opts ...RequestOpt) error {
 	return s.client.Do(AddReaction.Compile(nil, channelID, messageID, emoji), nil, nil, opts...)
 }
@@ -257,6 +278,14 @@ func (s *channelImpl) GetPollAnswerVotesPage(channelID snowflake.ID, messageID s
 	}
 }
 
+func (s *channelImpl) GetPollAnswerVotesIter(channelID snowflake.ID, messageID snowflake.ID, answerID int, params IterParams, opts ...RequestOpt) iter.Seq2[discord.User, error] {
+	return iterate(params, 100, []Direction{DirectionAfter}, func(user discord.User) snowflake.ID {
+		return user.ID
+	}, func(_ snowflake.ID, after snowflake.ID, limit int) ([]discord.User, error) {
+		return s.GetPollAnswerVotes(channelID, messageID, answerID, after, limit, opts...)
+	})
+}
+
 func (s *channelImpl) ExpirePoll(channelID snowflake.ID, messageID snowflake.ID, opts ...RequestOpt) (message *discord.Message, err error) {
 	err = s.client.Do(ExpirePoll.Compile(nil, channelID, messageID), nil, &message, opts...)
 	return
@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"iter"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -21,6 +23,7 @@ type GuildScheduledEvents interface {
 
 	GetGuildScheduledEventUsers(guildID snowflake.ID, guildScheduledEventID snowflake.ID, withMember bool, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.GuildScheduledEventUser, error)
 	GetGuildScheduledEventUsersPage(guildID snowflake.ID, guildScheduledEventID snowflake.ID, withMember bool, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.GuildScheduledEventUser]
+	GetGuildScheduledEventUsersIter(guildID snowflake.ID, guildScheduledEventID snowflake.ID, withMember bool, params IterParams, opts ...RequestOpt) iter.Seq2[discord.GuildScheduledEventUser, error]
 }
 
 type guildScheduledEventImpl struct {
@@ -88,3 +91,11 @@ func (s *guildScheduledEventImpl) GetGuildScheduledEventUsersPage(guildID snowfl
 		ID: startID,
 	}
 }
+
+func (s *guildScheduledEventImpl) GetGuildScheduledEventUsersIter(guildID snowflake.ID, guildScheduledEventID snowflake.ID, withMember bool, params IterParams, opts ...RequestOpt) iter.Seq2[discord.GuildScheduledEventUser, error] {
+	return iterate(params, 100, []Direction{DirectionBefore, DirectionAfter}, func(user discord.GuildScheduledEventUser) snowflake.ID {
+		return user.User.ID
+	}, func(before snowflake.ID, after snowflake.ID, limit int) ([]discord.GuildScheduledEventUser, error) {
+		return s.GetGuildScheduledEventUsers(guildID, guildScheduledEventID, withMember, before, after, limit, opts...)
+	})
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"iter"
 	"time"
 
 	"github.com/disgoorg/disgo/internal/slicehelper"
@@ -37,6 +38,7 @@ type Guilds interface {
 
 	GetBans(guildID snowflake.ID, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.Ban, error)
 	GetBansPage(guildID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) Page[discord.Ban]
+	GetBansIter(guildID snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.Ban, error]
 	GetBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (*discord.Ban, error)
 	AddBan(guildID snowflake.ID, userID snowflake.ID, deleteMessageDuration time.Duration, opts ...RequestOpt) error
 	DeleteBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
@@ -54,6 +56,7 @@ type Guilds interface {
 
 	GetAuditLog(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) (*discord.AuditLog, error)
 	GetAuditLogPage(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, startID snowflake.ID, limit int, opts ...RequestOpt) AuditLogPage
+	GetAuditLogIter(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, params IterParams, opts ...RequestOpt) iter.Seq2[discord.AuditLogEntry, error]
 
 	GetGuildWelcomeScreen(guildID snowflake.ID, opts ...RequestOpt) (*discord.GuildWelcomeScreen, error)
 	UpdateGuildWelcomeScreen(guildID snowflake.ID, screenUpdate discord.GuildWelcomeScreenUpdate, opts ...RequestOpt) (*discord.GuildWelcomeScreen, error)
@@ -198,6 +201,14 @@ func (s *guildImpl) GetBansPage(guildID snowflake.ID, startID snowflake.ID, limi
 	}
 }
 
+func (s *guildImpl) GetBansIter(guildID snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.Ban, error] {
+	return iterate(params, 1000, []Direction{DirectionBefore, DirectionAfter}, func(ban discord.Ban) snowflake.ID {
+		return ban.User.ID
+	}, func(before snowflake.ID, after snowflake.ID, limit int) ([]discord.Ban, error) {
+		return s.GetBans(guildID, before, after, limit, opts...)
+	})
+}
+
 func (s *guildImpl) GetBan(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (ban *discord.Ban, err error) {
 	err = s.client.Do(GetBan.Compile(nil, guildID, userID), nil, &ban, opts...)
 	return
@@ -291,6 +302,18 @@ func (s *guildImpl) GetAuditLogPage(guildID snowflake.ID, userID snowflake.ID, a
 	}
 }
 
+func (s *guildImpl) GetAuditLogIter(guildID snowflake.ID, userID snowflake.ID, actionType discord.AuditLogEvent, params IterParams, opts ...RequestOpt) iter.Seq2[discord.AuditLogEntry, error] {
+	return iterate(params, 100, []Direction{DirectionBefore, DirectionAfter}, func(entry discord.AuditLogEntry) snowflake.ID {
+		return entry.ID
+	}, func(before snowflake.ID, after snowflake.ID, limit int) ([]discord.AuditLogEntry, error) {
+		auditLog, err := s.GetAuditLog(guildID, userID, actionType, before, after, limit, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return auditLog.AuditLogEntries, nil
+	})
+}
+
 func (s *guildImpl) GetGuildWelcomeScreen(guildID snowflake.ID, opts ...RequestOpt) (welcomeScreen *discord.GuildWelcomeScreen, err error) {
 	err = s.client.Do(GetGuildWelcomeScreen.Compile(nil, guildID), nil, &welcomeScreen, opts...)
 	return
@@ -0,0 +1,129 @@
+package rest
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// maxSnowflake is the largest snowflake Discord accepts as cursor.
+const maxSnowflake = snowflake.ID(math.MaxInt64)
+
+// ErrDirectionNotSupported is yielded by an iterator when the endpoint does not support paging in the requested Direction.
+var ErrDirectionNotSupported = errors.New("direction not supported by endpoint")
+
+// Direction is the direction an iterator pages through a list endpoint in.
+type Direction int
+
+const (
+	// DirectionDefault pages in the default direction of the endpoint, which is DirectionBefore if the endpoint supports it.
+	DirectionDefault Direction = iota
+	// DirectionBefore pages from newer to older items, yielding items in descending ID order.
+	DirectionBefore
+	// DirectionAfter pages from older to newer items, yielding items in ascending ID order.
+	DirectionAfter
+)
+
+// IterParams configures an iterator over a list endpoint.
+type IterParams struct {
+	// Start is the exclusive ID to start paging from. 0 starts at the newest item for DirectionBefore and at the oldest item for DirectionAfter.
+	Start snowflake.ID
+	// Direction is the direction to page in.
+	Direction Direction
+	// Limit is the maximum number of items to yield. 0 yields all items.
+	Limit int
+	// PageSize is the number of items requested per page. 0 uses the maximum of the endpoint.
+	PageSize int
+}
+
+// ArchivedThreadsIterParams configures an iterator over archived threads, which are paged by their archive timestamp instead of their ID.
+type ArchivedThreadsIterParams struct {
+	// Before is the exclusive archive timestamp to start paging from. The zero time starts at the most recently archived thread.
+	// Discord only accepts whole seconds, so it is truncated to the second.
+	Before time.Time
+	// Limit is the maximum number of threads to yield. 0 yields all threads.
+	Limit int
+	// PageSize is the number of threads requested per page. 0 uses the maximum of the endpoint.
+	PageSize int
+}
+
+// pageFunc requests a page of at most limit items before or after the given ID. Only one of before & after is set.
+type pageFunc[T any] func(before snowflake.ID, after snowflake.ID, limit int) ([]T, error)
+
+// iterate returns an iterator which requests pages with getPage until all items, or params.Limit items, are yielded or the loop is stopped.
+// Items of each page are sorted by their ID according to the direction, as endpoints don't agree on the order of items.
+func iterate[T any](params IterParams, maxPageSize int, supported []Direction, getID func(T) snowflake.ID, getPage pageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		direction := params.Direction
+		if direction == DirectionDefault {
+			direction = supported[0]
+		}
+		if !slices.Contains(supported, direction) {
+			var zero T
+			yield(zero, ErrDirectionNotSupported)
+			return
+		}
+
+		pageSize := maxPageSize
+		if params.PageSize > 0 && params.PageSize < maxPageSize {
+			pageSize = params.PageSize
+		}
+
+		cursor := params.Start
+		if cursor == 0 {
+			if direction == DirectionAfter {
+				// endpoints don't send an after of 0, so start after the first possible snowflake instead
+				cursor = 1
+			} else {
+				// endpoints like bans return the oldest items without a cursor, so start before the last possible snowflake instead
+				cursor = maxSnowflake
+			}
+		}
+
+		yielded := 0
+		for {
+			limit := pageSize
+			if params.Limit > 0 {
+				limit = min(limit, params.Limit-yielded)
+			}
+
+			var (
+				items []T
+				err   error
+			)
+			if direction == DirectionBefore {
+				items, err = getPage(cursor, 0, limit)
+			} else {
+				items, err = getPage(0, cursor, limit)
+			}
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			slices.SortFunc(items, func(a T, b T) int {
+				if direction == DirectionBefore {
+					return cmp.Compare(getID(b), getID(a))
+				}
+				return cmp.Compare(getID(a), getID(b))
+			})
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				yielded++
+				cursor = getID(item)
+			}
+
+			if len(items) < limit || (params.Limit > 0 && yielded >= params.Limit) {
+				return
+			}
+		}
+	}
+}
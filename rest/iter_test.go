@@ -0,0 +1,154 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestIterate(t *testing.T) {
+	// items 101 to 125, pages are returned in reverse order of the direction to test the sorting
+	getPage := func(before snowflake.ID, after snowflake.ID, limit int) ([]snowflake.ID, error) {
+		var items []snowflake.ID
+		if after != 0 {
+			for id := after + 1; id <= 125 && len(items) < limit; id++ {
+				if id <= 100 {
+					continue
+				}
+				items = append(items, id)
+			}
+		} else {
+			before = min(before, 126)
+			for id := before - 1; id >= 101 && len(items) < limit; id-- {
+				items = append(items, id)
+			}
+		}
+		slices.Reverse(items)
+		return items, nil
+	}
+	getID := func(id snowflake.ID) snowflake.ID { return id }
+	collect := func(params IterParams) []snowflake.ID {
+		var ids []snowflake.ID
+		for id, err := range iterate(params, 10, []Direction{DirectionBefore, DirectionAfter}, getID, getPage) {
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	ids := collect(IterParams{})
+	require.Len(t, ids, 25)
+	assert.Equal(t, snowflake.ID(125), ids[0])
+	assert.Equal(t, snowflake.ID(101), ids[24])
+
+	assert.Equal(t, []snowflake.ID{121, 122, 123, 124, 125}, collect(IterParams{Start: 120, Direction: DirectionAfter}))
+	assert.Equal(t, []snowflake.ID{101, 102, 103}, collect(IterParams{Direction: DirectionAfter, Limit: 3, PageSize: 2}))
+
+	var stopped []snowflake.ID
+	for id := range iterate(IterParams{}, 10, []Direction{DirectionBefore}, getID, getPage) {
+		stopped = append(stopped, id)
+		if len(stopped) == 12 {
+			break
+		}
+	}
+	assert.Len(t, stopped, 12)
+
+	for _, err := range iterate(IterParams{Direction: DirectionBefore}, 10, []Direction{DirectionAfter}, getID, getPage) {
+		assert.ErrorIs(t, err, ErrDirectionNotSupported)
+	}
+
+	testErr := errors.New("test")
+	for _, err := range iterate(IterParams{}, 10, []Direction{DirectionBefore}, getID, func(snowflake.ID, snowflake.ID, int) ([]snowflake.ID, error) {
+		return nil, testErr
+	}) {
+		assert.ErrorIs(t, err, testErr)
+	}
+}
+
+func TestIterate_Ascending(t *testing.T) {
+	// items 101 to 125, pages are always returned in ascending order and start at the oldest item without a cursor like the bans endpoint
+	var requests int
+	getPage := func(before snowflake.ID, after snowflake.ID, limit int) ([]snowflake.ID, error) {
+		requests++
+		var items []snowflake.ID
+		for id := snowflake.ID(101); id <= 125; id++ {
+			if (before == 0 || id < before) && id > after {
+				items = append(items, id)
+			}
+		}
+		if before != 0 && len(items) > limit {
+			items = items[len(items)-limit:]
+		}
+		if len(items) > limit {
+			items = items[:limit]
+		}
+		return items, nil
+	}
+
+	var ids []snowflake.ID
+	for id, err := range iterate(IterParams{}, 10, []Direction{DirectionBefore, DirectionAfter}, func(id snowflake.ID) snowflake.ID { return id }, getPage) {
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	require.Len(t, ids, 25)
+	assert.Equal(t, snowflake.ID(125), ids[0])
+	assert.Equal(t, snowflake.ID(101), ids[24])
+	assert.Equal(t, 3, requests)
+}
+
+func TestIterateArchivedThreads(t *testing.T) {
+	// threads 1 to 7, newest first, where threads 2 to 5 were archived in the same second
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	timestamps := []time.Time{
+		base.Add(3 * time.Second),
+		base.Add(2*time.Second + 900*time.Millisecond),
+		base.Add(2*time.Second + 600*time.Millisecond),
+		base.Add(2*time.Second + 300*time.Millisecond),
+		base.Add(2 * time.Second),
+		base.Add(time.Second),
+		base,
+	}
+	threads := make([]discord.GuildThread, len(timestamps))
+	for i, timestamp := range timestamps {
+		data := fmt.Sprintf(`{"id":"%d","type":11,"thread_metadata":{"archived":true,"archive_timestamp":"%s"}}`, i+1, timestamp.Format(time.RFC3339Nano))
+		require.NoError(t, json.Unmarshal([]byte(data), &threads[i]))
+	}
+
+	getThreads := func(before time.Time, limit int) (*discord.GetThreads, error) {
+		// like discord, the cursor only has a precision of seconds
+		before = before.Truncate(time.Second)
+		var page []discord.GuildThread
+		for _, thread := range threads {
+			if !before.IsZero() && !thread.ThreadMetadata.ArchiveTimestamp.Before(before) {
+				continue
+			}
+			if len(page) == limit {
+				return &discord.GetThreads{Threads: page, HasMore: true}, nil
+			}
+			page = append(page, thread)
+		}
+		return &discord.GetThreads{Threads: page}, nil
+	}
+	collect := func(params ArchivedThreadsIterParams) []snowflake.ID {
+		var ids []snowflake.ID
+		for thread, err := range iterateArchivedThreads(params, getThreads) {
+			require.NoError(t, err)
+			ids = append(ids, thread.ID())
+		}
+		return ids
+	}
+
+	assert.Equal(t, []snowflake.ID{1, 2, 3, 4, 5, 6, 7}, collect(ArchivedThreadsIterParams{PageSize: 2}))
+	assert.Equal(t, []snowflake.ID{1, 2, 3}, collect(ArchivedThreadsIterParams{PageSize: 2, Limit: 3}))
+	assert.Equal(t, []snowflake.ID{6, 7}, collect(ArchivedThreadsIterParams{Before: base.Add(2 * time.Second)}))
+	assert.Equal(t, []snowflake.ID{1, 2, 3, 4, 5, 6, 7}, collect(ArchivedThreadsIterParams{PageSize: 1}))
+}
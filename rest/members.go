@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"iter"
+
 	"github.com/disgoorg/snowflake/v2"
 
 	"github.com/disgoorg/disgo/discord"
@@ -15,6 +17,7 @@ func NewMembers(client Client) Members {
 type Members interface {
 	GetMember(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) (*discord.Member, error)
 	GetMembers(guildID snowflake.ID, limit int, after snowflake.ID, opts ...RequestOpt) ([]discord.Member, error)
+	GetMembersIter(guildID snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.Member, error]
 	SearchMembers(guildID snowflake.ID, query string, limit int, opts ...RequestOpt) ([]discord.Member, error)
 	AddMember(guildID snowflake.ID, userID snowflake.ID, memberAdd discord.MemberAdd, opts ...RequestOpt) (*discord.Member, error)
 	RemoveMember(guildID snowflake.ID, userID snowflake.ID, opts ...RequestOpt) error
@@ -57,6 +60,14 @@ func (s *memberImpl) GetMembers(guildID snowflake.ID, limit int, after snowflake
 	return
 }
 
+func (s *memberImpl) GetMembersIter(guildID snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.Member, error] {
+	return iterate(params, 1000, []Direction{DirectionAfter}, func(member discord.Member) snowflake.ID {
+		return member.User.ID
+	}, func(_ snowflake.ID, after snowflake.ID, limit int) ([]discord.Member, error) {
+		return s.GetMembers(guildID, limit, after, opts...)
+	})
+}
+
 func (s *memberImpl) SearchMembers(guildID snowflake.ID, query string, limit int, opts ...RequestOpt) (members []discord.Member, err error) {
 	values := discord.QueryValues{}
 	if query != "" {
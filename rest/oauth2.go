@@ -2,6 +2,7 @@ package rest
 
 import (
 	"errors"
+	"iter"
 	"net/url"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -32,6 +33,9 @@ type OAuth2 interface {
 	// GetCurrentUserGuildsPage returns a Page of guilds the current user is a member of. Requires the discord.OAuth2ScopeGuilds scope.
 	// Leave bearerToken empty to use the bot token.
 	GetCurrentUserGuildsPage(bearerToken string, startID snowflake.ID, limit int, withCounts bool, opts ...RequestOpt) Page[discord.OAuth2Guild]
+	// GetCurrentUserGuildsIter returns an iterator over the guilds the current user is a member of. Requires the discord.OAuth2ScopeGuilds scope.
+	// Leave bearerToken empty to use the bot token.
+	GetCurrentUserGuildsIter(bearerToken string, withCounts bool, params IterParams, opts ...RequestOpt) iter.Seq2[discord.OAuth2Guild, error]
 	GetCurrentUserConnections(bearerToken string, opts ...RequestOpt) ([]discord.Connection, error)
 
 	SetGuildCommandPermissions(bearerToken string, applicationID snowflake.ID, guildID snowflake.ID, commandID snowflake.ID, commandPermissions []discord.ApplicationCommandPermission, opts ...RequestOpt) (*discord.ApplicationCommandPermissions, error)
@@ -109,6 +113,14 @@ func (s *oAuth2Impl) GetCurrentUserGuildsPage(bearerToken string, startID snowfl
 	}
 }
 
+func (s *oAuth2Impl) GetCurrentUserGuildsIter(bearerToken string, withCounts bool, params IterParams, opts ...RequestOpt) iter.Seq2[discord.OAuth2Guild, error] {
+	return iterate(params, 200, []Direction{DirectionBefore, DirectionAfter}, func(guild discord.OAuth2Guild) snowflake.ID {
+		return guild.ID
+	}, func(before snowflake.ID, after snowflake.ID, limit int) ([]discord.OAuth2Guild, error) {
+		return s.GetCurrentUserGuilds(bearerToken, before, after, limit, withCounts, opts...)
+	})
+}
+
 func (s *oAuth2Impl) GetCurrentUserConnections(bearerToken string, opts ...RequestOpt) (connections []discord.Connection, err error) {
 	if bearerToken == "" {
 		return nil, ErrMissingBearerToken
@@ -24,6 +24,11 @@ type RequestConfig struct {
 	Ctx     context.Context
 	Checks  []Check
 	Delay   time.Duration
+
+	RetryPolicy *RetryPolicy
+	Priority    Priority
+
+	UploadProgress UploadProgressFunc
 }
 
 // Check is a function which gets executed right before a request is made
@@ -63,6 +68,34 @@ func WithDelay(delay time.Duration) RequestOpt {
 	}
 }
 
+// WithRequestRetryPolicy overrides the RetryPolicy of the Config for the request
+func WithRequestRetryPolicy(policy RetryPolicy) RequestOpt {
+	return func(config *RequestConfig) {
+		config.RetryPolicy = &policy
+	}
+}
+
+// WithoutRetries disables retries on network errors & server errors for the request. Rate limited requests are still retried
+func WithoutRetries() RequestOpt {
+	return func(config *RequestConfig) {
+		config.RetryPolicy = &RetryPolicy{}
+	}
+}
+
+// WithPriority sets the Priority of the request in the RateLimiter
+func WithPriority(priority Priority) RequestOpt {
+	return func(config *RequestConfig) {
+		config.Priority = priority
+	}
+}
+
+// WithUploadProgress calls the given UploadProgressFunc while the request body is uploaded
+func WithUploadProgress(progress UploadProgressFunc) RequestOpt {
+	return func(config *RequestConfig) {
+		config.UploadProgress = progress
+	}
+}
+
 // WithHeader adds a custom header to the request
 func WithHeader(key string, value string) RequestOpt {
 	return func(config *RequestConfig) {
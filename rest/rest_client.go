@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/json"
@@ -23,10 +24,12 @@ func NewClient(botToken string, opts ...ConfigOpt) Client {
 
 	config.RateLimiter.Reset()
 
-	return &clientImpl{
+	client := &clientImpl{
 		botToken: botToken,
 		config:   *config,
 	}
+	client.roundTrip = chainInterceptors(config.Interceptors, client.send)
+	return client
 }
 
 // Client allows doing requests to different endpoints
@@ -45,8 +48,9 @@ type Client interface {
 }
 
 type clientImpl struct {
-	botToken string
-	config   Config
+	botToken  string
+	config    Config
+	roundTrip RoundTrip
 }
 
 func (c *clientImpl) Close(ctx context.Context) {
@@ -62,113 +66,240 @@ func (c *clientImpl) RateLimiter() RateLimiter {
 	return c.config.RateLimiter
 }
 
-func (c *clientImpl) retry(endpoint *CompiledEndpoint, rqBody any, rsBody any, tries int, opts []RequestOpt) error {
-	var (
-		rawRqBody   []byte
-		err         error
-		contentType string
-	)
+func marshalBody(rqBody any) ([]byte, string, error) {
+	switch v := rqBody.(type) {
+	case nil:
+		return nil, "", nil
 
-	if rqBody != nil {
-		switch v := rqBody.(type) {
-		case *discord.MultipartBuffer:
-			contentType = v.ContentType
-			rawRqBody = v.Buffer.Bytes()
+	case *discord.MultipartBuffer:
+		return v.Buffer.Bytes(), v.ContentType, nil
 
-		case url.Values:
-			contentType = "application/x-www-form-urlencoded"
-			rawRqBody = []byte(v.Encode())
+	case *discord.MultipartStream:
+		// streams are opened for each attempt in openBody
+		return nil, v.ContentType, nil
 
-		default:
-			contentType = "application/json"
-			if rawRqBody, err = json.Marshal(rqBody); err != nil {
-				return fmt.Errorf("failed to marshal request body: %w", err)
-			}
+	case url.Values:
+		return []byte(v.Encode()), "application/x-www-form-urlencoded", nil
+
+	default:
+		rawRqBody, err := json.Marshal(rqBody)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		c.config.Logger.Debug("new request", slog.String("endpoint", endpoint.URL), slog.String("body", string(rawRqBody)))
+		return rawRqBody, "application/json", nil
+	}
+}
+
+// openBody returns the body for a new attempt of the request
+func openBody(rqBody any, rawRqBody []byte) (io.Reader, error) {
+	if stream, ok := rqBody.(*discord.MultipartStream); ok {
+		body, err := stream.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open request body: %w", err)
+		}
+		return body, nil
 	}
+	return bytes.NewReader(rawRqBody), nil
+}
 
-	rq, err := http.NewRequest(endpoint.Endpoint.Method, c.config.URL+endpoint.URL, bytes.NewReader(rawRqBody))
+func (c *clientImpl) Do(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error {
+	rawRqBody, contentType, err := marshalBody(rqBody)
 	if err != nil {
 		return err
 	}
-
-	rq.Header.Set("User-Agent", c.config.UserAgent)
-	if contentType != "" {
-		rq.Header.Set("Content-Type", contentType)
+	if rawRqBody != nil {
+		c.config.Logger.Debug("new request", slog.String("endpoint", endpoint.URL), slog.String("body", string(rawRqBody)))
 	}
 
 	if endpoint.Endpoint.BotAuth {
 		// add token opt to the start, so you can override it
 		opts = append([]RequestOpt{WithToken(discord.TokenTypeBot, c.botToken)}, opts...)
 	}
+	if strings.Contains(endpoint.Endpoint.Route, "{interaction.token}") {
+		// interaction responses have to happen within the token window, so they are served first
+		opts = append([]RequestOpt{WithPriority(PriorityInteraction)}, opts...)
+	}
 
-	config := DefaultRequestConfig(rq)
-	config.Apply(opts)
+	var (
+		start     = time.Now()
+		attempt   = 1
+		rateLimit = 1
+	)
+	for {
+		body, err := openBody(rqBody, rawRqBody)
+		if err != nil {
+			return err
+		}
+		rq, err := http.NewRequest(endpoint.Endpoint.Method, c.config.URL+endpoint.URL, body)
+		if err != nil {
+			if closer, ok := body.(io.Closer); ok {
+				_ = closer.Close()
+			}
+			return err
+		}
+
+		rq.Header.Set("User-Agent", c.config.UserAgent)
+		if contentType != "" {
+			rq.Header.Set("Content-Type", contentType)
+		}
+
+		config := DefaultRequestConfig(rq)
+		config.Apply(opts)
+		if config.UploadProgress != nil && config.Request.Body != nil && config.Request.Body != http.NoBody {
+			config.Request.Body = newProgressReader(config.Request.Body, config.Request.ContentLength, config.UploadProgress)
+		}
+
+		policy := c.config.RetryPolicy
+		if config.RetryPolicy != nil {
+			policy = *config.RetryPolicy
+		}
+
+		rs, rawRsBody, doErr, err := c.do(&InterceptedRequest{
+			Endpoint: endpoint,
+			Request:  config.Request,
+			Body:     rawRqBody,
+			Attempt:  attempt + rateLimit - 1,
+		}, config)
+		if err != nil {
+			return err
+		}
+
+		if doErr == nil {
+			switch rs.StatusCode {
+			case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+				if rsBody != nil && rs.Body != nil {
+					if err = json.Unmarshal(rawRsBody, rsBody); err != nil {
+						c.config.Logger.Error("error unmarshalling response body", slog.Any("err", err), slog.String("endpoint", endpoint.URL), slog.String("code", rs.Status), slog.String("body", string(rawRsBody)))
+						return fmt.Errorf("error unmarshalling response body: %w", err)
+					}
+				}
+				return nil
+
+			case http.StatusTooManyRequests:
+				if rateLimit >= c.RateLimiter().MaxRetries() {
+					return NewError(config.Request, rawRqBody, rs, rawRsBody)
+				}
+				rateLimit++
+				continue
+			}
+		}
+
+		if delay, ok := c.shouldRetry(policy, config, rs, doErr, attempt, start); ok {
+			c.config.Logger.Debug("retrying request", slog.String("endpoint", endpoint.URL), slog.Int("attempt", attempt), slog.Duration("delay", delay), slog.Any("err", doErr))
+			if policy.OnRetry != nil {
+				policy.OnRetry(RetryAttempt{
+					Endpoint: endpoint,
+					Attempt:  attempt,
+					Err:      doErr,
+					Response: rs,
+					Delay:    delay,
+				})
+			}
+			if err = sleep(config.Ctx, delay); err != nil {
+				return err
+			}
+			attempt++
+			continue
+		}
+
+		if doErr != nil {
+			return doErr
+		}
+		return NewError(config.Request, rawRqBody, rs, rawRsBody)
+	}
+}
+
+// do sends the request of the given RequestConfig while respecting the rate limits.
+// Errors which happened while sending the request or reading the response are returned as doErr, as they can be retried.
+func (c *clientImpl) do(rq *InterceptedRequest, config *RequestConfig) (rs *http.Response, rawRsBody []byte, doErr error, err error) {
+	endpoint := rq.Endpoint
+
+	// the http.Client closes the body, but we have to close it ourselves if the request is never sent
+	closeBody := func() {
+		if config.Request.Body != nil {
+			_ = config.Request.Body.Close()
+		}
+	}
 
 	if config.Delay > 0 {
-		timer := time.NewTimer(config.Delay)
-		defer timer.Stop()
-		select {
-		case <-config.Ctx.Done():
-			return config.Ctx.Err()
-		case <-timer.C:
+		if err = sleep(config.Ctx, config.Delay); err != nil {
+			closeBody()
+			return nil, nil, nil, err
 		}
 	}
 
+	if err = c.config.InvalidRequestTracker.Allow(); err != nil {
+		closeBody()
+		return nil, nil, nil, err
+	}
+
 	// wait for rate limits
-	err = c.RateLimiter().WaitBucket(config.Ctx, endpoint)
-	if err != nil {
-		return fmt.Errorf("error locking bucket in rest client: %w", err)
+	if err = c.RateLimiter().WaitBucket(ContextWithPriority(config.Ctx, config.Priority), endpoint); err != nil {
+		closeBody()
+		return nil, nil, nil, fmt.Errorf("error locking bucket in rest client: %w", err)
 	}
-	rq = rq.WithContext(config.Ctx)
+	config.Request = config.Request.WithContext(config.Ctx)
 
 	for _, check := range config.Checks {
 		if !check() {
 			_ = c.RateLimiter().UnlockBucket(endpoint, nil)
-			return discord.ErrCheckFailed
+			closeBody()
+			return nil, nil, nil, discord.ErrCheckFailed
 		}
 	}
 
-	rs, err := c.HTTPClient().Do(config.Request)
+	rq.Request = config.Request
+	rs, err = c.roundTrip(rq)
+	// an Interceptor might have returned a canned response without sending the body
+	closeBody()
 	if err != nil {
 		_ = c.RateLimiter().UnlockBucket(endpoint, nil)
-		return fmt.Errorf("error doing request in rest client: %w", err)
+		return nil, nil, fmt.Errorf("error doing request in rest client: %w", err), nil
 	}
 
+	c.config.InvalidRequestTracker.Track(rs)
+
 	if err = c.RateLimiter().UnlockBucket(endpoint, rs); err != nil {
-		return fmt.Errorf("error unlocking bucket in rest client: %w", err)
+		return nil, nil, nil, fmt.Errorf("error unlocking bucket in rest client: %w", err)
 	}
 
-	var rawRsBody []byte
 	if rs.Body != nil {
+		defer rs.Body.Close()
 		if rawRsBody, err = io.ReadAll(rs.Body); err != nil {
-			return fmt.Errorf("error reading response body in rest client: %w", err)
+			return nil, nil, fmt.Errorf("error reading response body in rest client: %w", err), nil
 		}
 		c.config.Logger.Debug("new response", slog.String("endpoint", endpoint.URL), slog.String("code", rs.Status), slog.String("body", string(rawRsBody)))
 	}
+	return rs, rawRsBody, nil, nil
+}
 
-	switch rs.StatusCode {
-	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
-		if rsBody != nil && rs.Body != nil {
-			if err = json.Unmarshal(rawRsBody, rsBody); err != nil {
-				c.config.Logger.Error("error unmarshalling response body", slog.Any("err", err), slog.String("endpoint", endpoint.URL), slog.String("code", rs.Status), slog.String("body", string(rawRsBody)))
-				return fmt.Errorf("error unmarshalling response body: %w", err)
-			}
-		}
-		return nil
-
-	case http.StatusTooManyRequests:
-		if tries >= c.RateLimiter().MaxRetries() {
-			return NewError(rq, rawRqBody, rs, rawRsBody)
-		}
-		return c.retry(endpoint, rqBody, rsBody, tries+1, opts)
+// send is the innermost RoundTrip of the Interceptor chain
+func (c *clientImpl) send(rq *InterceptedRequest) (*http.Response, error) {
+	return c.HTTPClient().Do(rq.Request)
+}
 
-	default:
-		return NewError(rq, rawRqBody, rs, rawRsBody)
+// shouldRetry returns the delay before the next attempt if the failed attempt should be retried according to the RetryPolicy.
+func (c *clientImpl) shouldRetry(policy RetryPolicy, config *RequestConfig, rs *http.Response, doErr error, attempt int, start time.Time) (time.Duration, bool) {
+	if attempt > policy.MaxRetries || config.Ctx.Err() != nil {
+		return 0, false
+	}
+	if !policy.ShouldRetry(config.Request.Method, rs, doErr) {
+		return 0, false
+	}
+	delay := policy.Backoff(attempt)
+	if policy.MaxElapsedTime > 0 && time.Since(start)+delay > policy.MaxElapsedTime {
+		return 0, false
 	}
+	return delay, true
 }
 
-func (c *clientImpl) Do(endpoint *CompiledEndpoint, rqBody any, rsBody any, opts ...RequestOpt) error {
-	return c.retry(endpoint, rqBody, rsBody, 1, opts)
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
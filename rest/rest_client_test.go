@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestClientRetryPolicy(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"name":"test"}`, string(body))
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	var attempts []int
+	policy.OnRetry = func(attempt RetryAttempt) {
+		attempts = append(attempts, attempt.Attempt)
+		assert.Equal(t, http.StatusBadGateway, attempt.Response.StatusCode)
+	}
+
+	client := NewClient("token", WithURL(server.URL), WithRetryPolicy(policy))
+	rqBody := map[string]string{"name": "test"}
+
+	err := client.Do(NewEndpoint(http.MethodPut, "/test").Compile(nil), rqBody, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, attempts)
+	assert.EqualValues(t, 3, requests.Load())
+
+	// POST is not idempotent and therefore not retried by default
+	requests.Store(0)
+	err = client.Do(NewEndpoint(http.MethodPost, "/test").Compile(nil), rqBody, nil)
+	require.Error(t, err)
+	assert.EqualValues(t, 1, requests.Load())
+
+	requests.Store(0)
+	err = client.Do(NewEndpoint(http.MethodPut, "/test").Compile(nil), rqBody, nil, WithoutRetries())
+	require.Error(t, err)
+	assert.EqualValues(t, 1, requests.Load())
+}
+
+func TestClientStreamedUploadRetry(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("files[0]")
+		require.NoError(t, err)
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, "file content", string(data))
+
+		w.Header().Set("Via", "1.1 google")
+		if requests.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Bucket", "abc")
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// the file is opened again for the retry
+	body, err := discord.StreamPayloadWithFiles(discord.MessageCreate{}, discord.NewFileFromOpener("test.txt", "", func() (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("file content")), nil
+	}))
+	require.NoError(t, err)
+	var uploaded int64
+	client := NewClient("token", WithURL(server.URL))
+	err = client.Do(NewEndpoint(http.MethodPost, "/test").Compile(nil), body, nil, WithUploadProgress(func(n int64, total int64) {
+		uploaded = n
+		assert.EqualValues(t, -1, total)
+	}))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, requests.Load())
+	assert.Greater(t, uploaded, int64(len("file content")))
+}
+
+func TestClientBufferedUploadRetry(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("files[0]")
+		require.NoError(t, err)
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, "file content", string(data))
+
+		w.Header().Set("Via", "1.1 google")
+		if requests.Add(1) == 1 {
+			w.Header().Set("X-RateLimit-Bucket", "abc")
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// the file has no OpenFunc, so the payload is buffered to allow the retry
+	body, err := discord.MessageCreate{
+		Files: []*discord.File{discord.NewFile("test.txt", "", strings.NewReader("file content"))},
+	}.ToBody()
+	require.NoError(t, err)
+	assert.IsType(t, &discord.MultipartBuffer{}, body)
+
+	client := NewClient("token", WithURL(server.URL))
+	err = client.Do(NewEndpoint(http.MethodPost, "/test").Compile(nil), body, nil)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, requests.Load())
+}
+
+func TestClientInterceptors(t *testing.T) {
+	var (
+		calls   []string
+		latency time.Duration
+	)
+	client := NewClient("token",
+		WithURL("http://localhost:0"),
+		WithInterceptors(
+			func(rq *InterceptedRequest, next RoundTrip) (*http.Response, error) {
+				calls = append(calls, "outer")
+				start := time.Now()
+				rs, err := next(rq)
+				latency = time.Since(start)
+				return rs, err
+			},
+			func(rq *InterceptedRequest, next RoundTrip) (*http.Response, error) {
+				calls = append(calls, "inner")
+				assert.Equal(t, "/users/@me", rq.Endpoint.URL)
+				assert.Equal(t, `{"username":"test"}`, string(rq.Body))
+				assert.Equal(t, 1, rq.Attempt)
+				return NewCannedResponse(rq.Request, http.StatusOK, []byte(`{"username":"canned"}`)), nil
+			},
+		),
+	)
+
+	var rsBody map[string]string
+	err := client.Do(NewEndpoint(http.MethodPatch, "/users/@me").Compile(nil), map[string]string{"username": "test"}, &rsBody)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+	assert.Equal(t, "canned", rsBody["username"])
+	assert.Greater(t, latency, time.Duration(0))
+}
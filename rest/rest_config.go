@@ -10,9 +10,10 @@ import (
 // DefaultConfig is the configuration which is used by default
 func DefaultConfig() *Config {
 	return &Config{
-		Logger:     slog.Default(),
-		HTTPClient: &http.Client{Timeout: 20 * time.Second},
-		URL:        fmt.Sprintf("%sv%d", API, Version),
+		Logger:      slog.Default(),
+		HTTPClient:  &http.Client{Timeout: 20 * time.Second},
+		URL:         fmt.Sprintf("%sv%d", API, Version),
+		RetryPolicy: DefaultRetryPolicy(),
 	}
 }
 
@@ -22,8 +23,13 @@ type Config struct {
 	HTTPClient            *http.Client
 	RateLimiter           RateLimiter
 	RateLimiterConfigOpts []RateLimiterConfigOpt
-	URL                   string
-	UserAgent             string
+	// InvalidRequestTracker counts invalid requests to avoid Discord's temporary IP ban
+	InvalidRequestTracker           InvalidRequestTracker
+	InvalidRequestTrackerConfigOpts []InvalidRequestTrackerConfigOpt
+	URL                             string
+	UserAgent                       string
+	RetryPolicy                     RetryPolicy
+	Interceptors                    []Interceptor
 }
 
 // ConfigOpt can be used to supply optional parameters to NewClient
@@ -37,6 +43,9 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	if c.RateLimiter == nil {
 		c.RateLimiter = NewRateLimiter(c.RateLimiterConfigOpts...)
 	}
+	if c.InvalidRequestTracker == nil {
+		c.InvalidRequestTracker = NewInvalidRequestTracker(c.InvalidRequestTrackerConfigOpts...)
+	}
 }
 
 // WithLogger applies a custom logger to the rest rate limiter
@@ -67,6 +76,21 @@ func WithRateLimiterConfigOpts(opts ...RateLimiterConfigOpt) ConfigOpt {
 	}
 }
 
+// WithInvalidRequestTracker applies a custom InvalidRequestTracker to the rest client.
+// Keep a reference to the InvalidRequestTracker to read its count.
+func WithInvalidRequestTracker(tracker InvalidRequestTracker) ConfigOpt {
+	return func(config *Config) {
+		config.InvalidRequestTracker = tracker
+	}
+}
+
+// WithInvalidRequestTrackerConfigOpts applies InvalidRequestTrackerConfigOpt to the InvalidRequestTracker
+func WithInvalidRequestTrackerConfigOpts(opts ...InvalidRequestTrackerConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.InvalidRequestTrackerConfigOpts = append(config.InvalidRequestTrackerConfigOpts, opts...)
+	}
+}
+
 // WithURL sets the api url for all requests
 func WithURL(url string) ConfigOpt {
 	return func(config *Config) {
@@ -80,3 +104,17 @@ func WithUserAgent(userAgent string) ConfigOpt {
 		config.UserAgent = userAgent
 	}
 }
+
+// WithRetryPolicy sets the RetryPolicy for all requests. It can be overridden per request with WithRequestRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) ConfigOpt {
+	return func(config *Config) {
+		config.RetryPolicy = policy
+	}
+}
+
+// WithInterceptors adds the given Interceptor(s) to the rest client. The first Interceptor is the outermost one
+func WithInterceptors(interceptors ...Interceptor) ConfigOpt {
+	return func(config *Config) {
+		config.Interceptors = append(config.Interceptors, interceptors...)
+	}
+}
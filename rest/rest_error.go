@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/disgoorg/json"
 )
@@ -54,7 +57,15 @@ func (e Error) Is(target error) bool {
 // Error returns the error formatted as string
 func (e Error) Error() string {
 	if e.Code != 0 {
-		return fmt.Sprintf("%d: %s", e.Code, e.Message)
+		fieldErrors := e.FieldErrors()
+		if len(fieldErrors) == 0 {
+			return fmt.Sprintf("%d: %s", e.Code, e.Message)
+		}
+		lines := make([]string, len(fieldErrors))
+		for i, fieldError := range fieldErrors {
+			lines[i] = fieldError.Error()
+		}
+		return fmt.Sprintf("%d: %s: %s", e.Code, e.Message, strings.Join(lines, ", "))
 	}
 	return fmt.Sprintf("Status: %s, Body: %s", e.Response.Status, string(e.RsBody))
 }
@@ -63,3 +74,110 @@ func (e Error) Error() string {
 func (e Error) String() string {
 	return e.Error()
 }
+
+// FieldErrors returns all FieldError(s) of the Errors tree sorted by their path.
+// Discord returns these with JSONErrorCodeInvalidFormBody.
+func (e Error) FieldErrors() []FieldError {
+	fieldErrors, _ := ParseFieldErrors(e.Errors)
+	return fieldErrors
+}
+
+// FilterFieldErrors returns all FieldError(s) of the Errors tree which pass the given filter.
+func (e Error) FilterFieldErrors(filter func(fieldError FieldError) bool) []FieldError {
+	var fieldErrors []FieldError
+	for _, fieldError := range e.FieldErrors() {
+		if filter(fieldError) {
+			fieldErrors = append(fieldErrors, fieldError)
+		}
+	}
+	return fieldErrors
+}
+
+// FieldErrorsAt returns all FieldError(s) at the given path or below it.
+// For example "embeds.0" returns the errors of "embeds.0.fields.3.value" but not the ones of "embeds.1.title".
+func (e Error) FieldErrorsAt(path string) []FieldError {
+	return e.FilterFieldErrors(func(fieldError FieldError) bool {
+		return fieldError.Path == path || path == "" || strings.HasPrefix(fieldError.Path, path+".")
+	})
+}
+
+// FieldError is a validation error of a single field in the request body.
+type FieldError struct {
+	// Path is the JSON path of the field like "embeds.0.fields.3.value". It is empty for errors of the whole body.
+	Path string `json:"path"`
+	// Code is the validation error code like "BASE_TYPE_REQUIRED".
+	Code string `json:"code"`
+	// Message is the human-readable description of the error.
+	Message string `json:"message"`
+}
+
+// Error returns the error formatted as string
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+	}
+	return fmt.Sprintf("%s: %s (%s)", e.Path, e.Message, e.Code)
+}
+
+// ParseFieldErrors parses the nested "errors" object of a Discord error response into a flat list of FieldError(s) sorted by their path.
+func ParseFieldErrors(errs json.RawMessage) ([]FieldError, error) {
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	var fieldErrors []FieldError
+	if err := parseFieldErrors(errs, "", &fieldErrors); err != nil {
+		return nil, err
+	}
+	return fieldErrors, nil
+}
+
+func parseFieldErrors(data json.RawMessage, path string, fieldErrors *[]FieldError) error {
+	var tree map[string]json.RawMessage
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return fmt.Errorf("failed to parse field errors at %q: %w", path, err)
+	}
+
+	if rawErrors, ok := tree["_errors"]; ok {
+		var errs []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(rawErrors, &errs); err != nil {
+			return fmt.Errorf("failed to parse field errors at %q: %w", path, err)
+		}
+		for _, err := range errs {
+			*fieldErrors = append(*fieldErrors, FieldError{
+				Path:    path,
+				Code:    err.Code,
+				Message: err.Message,
+			})
+		}
+	}
+
+	keys := make([]string, 0, len(tree))
+	for key := range tree {
+		if key != "_errors" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		// sort array indices numerically, so "embeds.2" comes before "embeds.10"
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+
+	for _, key := range keys {
+		childPath := key
+		if path != "" {
+			childPath = path + "." + key
+		}
+		if err := parseFieldErrors(tree[key], childPath, fieldErrors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,472 @@
+package rest
+
+// JSONErrorCode constants
+// See https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes
+const (
+	// JSONErrorCodeGeneralError means: General error (such as a malformed request body, amongst other things)
+	JSONErrorCodeGeneralError JSONErrorCode = 0
+
+	// JSONErrorCodeUnknownAccount means: Unknown account
+	JSONErrorCodeUnknownAccount JSONErrorCode = 10001
+	// JSONErrorCodeUnknownApplication means: Unknown application
+	JSONErrorCodeUnknownApplication JSONErrorCode = 10002
+	// JSONErrorCodeUnknownChannel means: Unknown channel
+	JSONErrorCodeUnknownChannel JSONErrorCode = 10003
+	// JSONErrorCodeUnknownGuild means: Unknown guild
+	JSONErrorCodeUnknownGuild JSONErrorCode = 10004
+	// JSONErrorCodeUnknownIntegration means: Unknown integration
+	JSONErrorCodeUnknownIntegration JSONErrorCode = 10005
+	// JSONErrorCodeUnknownInvite means: Unknown invite
+	JSONErrorCodeUnknownInvite JSONErrorCode = 10006
+	// JSONErrorCodeUnknownMember means: Unknown member
+	JSONErrorCodeUnknownMember JSONErrorCode = 10007
+	// JSONErrorCodeUnknownMessage means: Unknown message
+	JSONErrorCodeUnknownMessage JSONErrorCode = 10008
+	// JSONErrorCodeUnknownPermissionOverwrite means: Unknown permission overwrite
+	JSONErrorCodeUnknownPermissionOverwrite JSONErrorCode = 10009
+	// JSONErrorCodeUnknownProvider means: Unknown provider
+	JSONErrorCodeUnknownProvider JSONErrorCode = 10010
+	// JSONErrorCodeUnknownRole means: Unknown role
+	JSONErrorCodeUnknownRole JSONErrorCode = 10011
+	// JSONErrorCodeUnknownToken means: Unknown token
+	JSONErrorCodeUnknownToken JSONErrorCode = 10012
+	// JSONErrorCodeUnknownUser means: Unknown user
+	JSONErrorCodeUnknownUser JSONErrorCode = 10013
+	// JSONErrorCodeUnknownEmoji means: Unknown emoji
+	JSONErrorCodeUnknownEmoji JSONErrorCode = 10014
+	// JSONErrorCodeUnknownWebhook means: Unknown webhook
+	JSONErrorCodeUnknownWebhook JSONErrorCode = 10015
+	// JSONErrorCodeUnknownWebhookService means: Unknown webhook service
+	JSONErrorCodeUnknownWebhookService JSONErrorCode = 10016
+	// JSONErrorCodeUnknownSession means: Unknown session
+	JSONErrorCodeUnknownSession JSONErrorCode = 10020
+	// JSONErrorCodeUnknownAsset means: Unknown asset
+	JSONErrorCodeUnknownAsset JSONErrorCode = 10021
+	// JSONErrorCodeUnknownBan means: Unknown ban
+	JSONErrorCodeUnknownBan JSONErrorCode = 10026
+	// JSONErrorCodeUnknownSKU means: Unknown SKU
+	JSONErrorCodeUnknownSKU JSONErrorCode = 10027
+	// JSONErrorCodeUnknownStoreListing means: Unknown Store Listing
+	JSONErrorCodeUnknownStoreListing JSONErrorCode = 10028
+	// JSONErrorCodeUnknownEntitlement means: Unknown entitlement
+	JSONErrorCodeUnknownEntitlement JSONErrorCode = 10029
+	// JSONErrorCodeUnknownBuild means: Unknown build
+	JSONErrorCodeUnknownBuild JSONErrorCode = 10030
+	// JSONErrorCodeUnknownLobby means: Unknown lobby
+	JSONErrorCodeUnknownLobby JSONErrorCode = 10031
+	// JSONErrorCodeUnknownBranch means: Unknown branch
+	JSONErrorCodeUnknownBranch JSONErrorCode = 10032
+	// JSONErrorCodeUnknownStoreDirectoryLayout means: Unknown store directory layout
+	JSONErrorCodeUnknownStoreDirectoryLayout JSONErrorCode = 10033
+	// JSONErrorCodeUnknownRedistributable means: Unknown redistributable
+	JSONErrorCodeUnknownRedistributable JSONErrorCode = 10036
+	// JSONErrorCodeUnknownGiftCode means: Unknown gift code
+	JSONErrorCodeUnknownGiftCode JSONErrorCode = 10038
+	// JSONErrorCodeUnknownStream means: Unknown stream
+	JSONErrorCodeUnknownStream JSONErrorCode = 10049
+	// JSONErrorCodeUnknownPremiumServerSubscribeCooldown means: Unknown premium server subscribe cooldown
+	JSONErrorCodeUnknownPremiumServerSubscribeCooldown JSONErrorCode = 10050
+	// JSONErrorCodeUnknownGuildTemplate means: Unknown guild template
+	JSONErrorCodeUnknownGuildTemplate JSONErrorCode = 10057
+	// JSONErrorCodeUnknownDiscoverableServerCategory means: Unknown discoverable server category
+	JSONErrorCodeUnknownDiscoverableServerCategory JSONErrorCode = 10059
+	// JSONErrorCodeUnknownSticker means: Unknown sticker
+	JSONErrorCodeUnknownSticker JSONErrorCode = 10060
+	// JSONErrorCodeUnknownStickerPack means: Unknown sticker pack
+	JSONErrorCodeUnknownStickerPack JSONErrorCode = 10061
+	// JSONErrorCodeUnknownInteraction means: Unknown interaction
+	JSONErrorCodeUnknownInteraction JSONErrorCode = 10062
+	// JSONErrorCodeUnknownApplicationCommand means: Unknown application command
+	JSONErrorCodeUnknownApplicationCommand JSONErrorCode = 10063
+	// JSONErrorCodeUnknownVoiceState means: Unknown voice state
+	JSONErrorCodeUnknownVoiceState JSONErrorCode = 10065
+	// JSONErrorCodeUnknownApplicationCommandPermissions means: Unknown application command permissions
+	JSONErrorCodeUnknownApplicationCommandPermissions JSONErrorCode = 10066
+	// JSONErrorCodeUnknownStageInstance means: Unknown Stage Instance
+	JSONErrorCodeUnknownStageInstance JSONErrorCode = 10067
+	// JSONErrorCodeUnknownGuildMemberVerificationForm means: Unknown Guild Member Verification Form
+	JSONErrorCodeUnknownGuildMemberVerificationForm JSONErrorCode = 10068
+	// JSONErrorCodeUnknownGuildWelcomeScreen means: Unknown Guild Welcome Screen
+	JSONErrorCodeUnknownGuildWelcomeScreen JSONErrorCode = 10069
+	// JSONErrorCodeUnknownGuildScheduledEvent means: Unknown Guild Scheduled Event
+	JSONErrorCodeUnknownGuildScheduledEvent JSONErrorCode = 10070
+	// JSONErrorCodeUnknownGuildScheduledEventUser means: Unknown Guild Scheduled Event User
+	JSONErrorCodeUnknownGuildScheduledEventUser JSONErrorCode = 10071
+	// JSONErrorCodeUnknownTag means: Unknown Tag
+	JSONErrorCodeUnknownTag JSONErrorCode = 10087
+	// JSONErrorCodeUnknownSound means: Unknown sound
+	JSONErrorCodeUnknownSound JSONErrorCode = 10097
+
+	// JSONErrorCodeBotsCannotUseEndpoint means: Bots cannot use this endpoint
+	JSONErrorCodeBotsCannotUseEndpoint JSONErrorCode = 20001
+	// JSONErrorCodeOnlyBotsCanUseEndpoint means: Only bots can use this endpoint
+	JSONErrorCodeOnlyBotsCanUseEndpoint JSONErrorCode = 20002
+	// JSONErrorCodeExplicitContentCannotBeSent means: Explicit content cannot be sent to the desired recipient(s)
+	JSONErrorCodeExplicitContentCannotBeSent JSONErrorCode = 20009
+	// JSONErrorCodeNotAuthorizedForApplication means: You are not authorized to perform this action on this application
+	JSONErrorCodeNotAuthorizedForApplication JSONErrorCode = 20012
+	// JSONErrorCodeSlowmodeRateLimit means: This action cannot be performed due to slowmode rate limit
+	JSONErrorCodeSlowmodeRateLimit JSONErrorCode = 20016
+	// JSONErrorCodeOnlyAccountOwner means: Only the owner of this account can perform this action
+	JSONErrorCodeOnlyAccountOwner JSONErrorCode = 20018
+	// JSONErrorCodeAnnouncementRateLimit means: This message cannot be edited due to announcement rate limits
+	JSONErrorCodeAnnouncementRateLimit JSONErrorCode = 20022
+	// JSONErrorCodeUnderMinimumAge means: Under minimum age
+	JSONErrorCodeUnderMinimumAge JSONErrorCode = 20024
+	// JSONErrorCodeChannelWriteRateLimit means: The channel you are writing has hit the write rate limit
+	JSONErrorCodeChannelWriteRateLimit JSONErrorCode = 20028
+	// JSONErrorCodeServerWriteRateLimit means: The write action you are performing on the server has hit the write rate limit
+	JSONErrorCodeServerWriteRateLimit JSONErrorCode = 20029
+	// JSONErrorCodeDisallowedWords means: Your Stage topic, server name, server description, or channel names contain words that are not allowed
+	JSONErrorCodeDisallowedWords JSONErrorCode = 20031
+	// JSONErrorCodeGuildPremiumTierTooLow means: Guild premium subscription level too low
+	JSONErrorCodeGuildPremiumTierTooLow JSONErrorCode = 20035
+
+	// JSONErrorCodeMaxGuilds means: Maximum number of guilds reached (100)
+	JSONErrorCodeMaxGuilds JSONErrorCode = 30001
+	// JSONErrorCodeMaxFriends means: Maximum number of friends reached (1000)
+	JSONErrorCodeMaxFriends JSONErrorCode = 30002
+	// JSONErrorCodeMaxPins means: Maximum number of pins reached for the channel (50)
+	JSONErrorCodeMaxPins JSONErrorCode = 30003
+	// JSONErrorCodeMaxRecipients means: Maximum number of recipients reached (10)
+	JSONErrorCodeMaxRecipients JSONErrorCode = 30004
+	// JSONErrorCodeMaxGuildRoles means: Maximum number of guild roles reached (250)
+	JSONErrorCodeMaxGuildRoles JSONErrorCode = 30005
+	// JSONErrorCodeMaxWebhooks means: Maximum number of webhooks reached (15)
+	JSONErrorCodeMaxWebhooks JSONErrorCode = 30007
+	// JSONErrorCodeMaxEmojis means: Maximum number of emojis reached
+	JSONErrorCodeMaxEmojis JSONErrorCode = 30008
+	// JSONErrorCodeMaxReactions means: Maximum number of reactions reached (20)
+	JSONErrorCodeMaxReactions JSONErrorCode = 30010
+	// JSONErrorCodeMaxGroupDMs means: Maximum number of group DMs reached (10)
+	JSONErrorCodeMaxGroupDMs JSONErrorCode = 30011
+	// JSONErrorCodeMaxGuildChannels means: Maximum number of guild channels reached (500)
+	JSONErrorCodeMaxGuildChannels JSONErrorCode = 30013
+	// JSONErrorCodeMaxAttachments means: Maximum number of attachments in a message reached (10)
+	JSONErrorCodeMaxAttachments JSONErrorCode = 30015
+	// JSONErrorCodeMaxInvites means: Maximum number of invites reached (1000)
+	JSONErrorCodeMaxInvites JSONErrorCode = 30016
+	// JSONErrorCodeMaxAnimatedEmojis means: Maximum number of animated emojis reached
+	JSONErrorCodeMaxAnimatedEmojis JSONErrorCode = 30018
+	// JSONErrorCodeMaxServerMembers means: Maximum number of server members reached
+	JSONErrorCodeMaxServerMembers JSONErrorCode = 30019
+	// JSONErrorCodeMaxServerCategories means: Maximum number of server categories has been reached
+	JSONErrorCodeMaxServerCategories JSONErrorCode = 30030
+	// JSONErrorCodeGuildAlreadyHasTemplate means: Guild already has a template
+	JSONErrorCodeGuildAlreadyHasTemplate JSONErrorCode = 30031
+	// JSONErrorCodeMaxApplicationCommands means: Maximum number of application commands reached
+	JSONErrorCodeMaxApplicationCommands JSONErrorCode = 30032
+	// JSONErrorCodeMaxThreadParticipants means: Maximum number of thread participants has been reached (1000)
+	JSONErrorCodeMaxThreadParticipants JSONErrorCode = 30033
+	// JSONErrorCodeMaxDailyApplicationCommandCreates means: Maximum number of daily application command creates has been reached (200)
+	JSONErrorCodeMaxDailyApplicationCommandCreates JSONErrorCode = 30034
+	// JSONErrorCodeMaxNonMemberBans means: Maximum number of bans for non-guild members have been exceeded
+	JSONErrorCodeMaxNonMemberBans JSONErrorCode = 30035
+	// JSONErrorCodeMaxBanFetches means: Maximum number of bans fetches has been reached
+	JSONErrorCodeMaxBanFetches JSONErrorCode = 30037
+	// JSONErrorCodeMaxUncompletedGuildScheduledEvents means: Maximum number of uncompleted guild scheduled events reached (100)
+	JSONErrorCodeMaxUncompletedGuildScheduledEvents JSONErrorCode = 30038
+	// JSONErrorCodeMaxStickers means: Maximum number of stickers reached
+	JSONErrorCodeMaxStickers JSONErrorCode = 30039
+	// JSONErrorCodeMaxPruneRequests means: Maximum number of prune requests has been reached. Try again later
+	JSONErrorCodeMaxPruneRequests JSONErrorCode = 30040
+	// JSONErrorCodeMaxGuildWidgetSettingsUpdates means: Maximum number of guild widget settings updates has been reached. Try again later
+	JSONErrorCodeMaxGuildWidgetSettingsUpdates JSONErrorCode = 30042
+	// JSONErrorCodeMaxSoundboardSounds means: Maximum number of soundboard sounds reached
+	JSONErrorCodeMaxSoundboardSounds JSONErrorCode = 30045
+	// JSONErrorCodeMaxOldMessageEdits means: Maximum number of edits to messages older than 1 hour reached. Try again later
+	JSONErrorCodeMaxOldMessageEdits JSONErrorCode = 30046
+	// JSONErrorCodeMaxPinnedForumThreads means: Maximum number of pinned threads in a forum channel has been reached
+	JSONErrorCodeMaxPinnedForumThreads JSONErrorCode = 30047
+	// JSONErrorCodeMaxForumTags means: Maximum number of tags in a forum channel has been reached
+	JSONErrorCodeMaxForumTags JSONErrorCode = 30048
+	// JSONErrorCodeBitrateTooHigh means: Bitrate is too high for channel of this type
+	JSONErrorCodeBitrateTooHigh JSONErrorCode = 30052
+	// JSONErrorCodeMaxPremiumEmojis means: Maximum number of premium emojis reached (25)
+	JSONErrorCodeMaxPremiumEmojis JSONErrorCode = 30056
+	// JSONErrorCodeMaxGuildWebhooks means: Maximum number of webhooks per guild reached (1000)
+	JSONErrorCodeMaxGuildWebhooks JSONErrorCode = 30058
+	// JSONErrorCodeMaxChannelPermissionOverwrites means: Maximum number of channel permission overwrites reached (1000)
+	JSONErrorCodeMaxChannelPermissionOverwrites JSONErrorCode = 30060
+	// JSONErrorCodeGuildChannelsTooLarge means: The channels for this guild are too large
+	JSONErrorCodeGuildChannelsTooLarge JSONErrorCode = 30061
+
+	// JSONErrorCodeUnauthorized means: Unauthorized. Provide a valid token and try again
+	JSONErrorCodeUnauthorized JSONErrorCode = 40001
+	// JSONErrorCodeAccountVerificationRequired means: You need to verify your account in order to perform this action
+	JSONErrorCodeAccountVerificationRequired JSONErrorCode = 40002
+	// JSONErrorCodeOpeningDMsTooFast means: You are opening direct messages too fast
+	JSONErrorCodeOpeningDMsTooFast JSONErrorCode = 40003
+	// JSONErrorCodeSendMessagesTemporarilyDisabled means: Send messages has been temporarily disabled
+	JSONErrorCodeSendMessagesTemporarilyDisabled JSONErrorCode = 40004
+	// JSONErrorCodeRequestEntityTooLarge means: Request entity too large. Try sending something smaller in size
+	JSONErrorCodeRequestEntityTooLarge JSONErrorCode = 40005
+	// JSONErrorCodeFeatureTemporarilyDisabled means: This feature has been temporarily disabled server-side
+	JSONErrorCodeFeatureTemporarilyDisabled JSONErrorCode = 40006
+	// JSONErrorCodeUserBannedFromGuild means: The user is banned from this guild
+	JSONErrorCodeUserBannedFromGuild JSONErrorCode = 40007
+	// JSONErrorCodeConnectionRevoked means: Connection has been revoked
+	JSONErrorCodeConnectionRevoked JSONErrorCode = 40012
+	// JSONErrorCodeOnlyConsumableSKUs means: Only consumable SKUs can be consumed
+	JSONErrorCodeOnlyConsumableSKUs JSONErrorCode = 40018
+	// JSONErrorCodeOnlySandboxEntitlementsDeletable means: You can only delete sandbox entitlements
+	JSONErrorCodeOnlySandboxEntitlementsDeletable JSONErrorCode = 40019
+	// JSONErrorCodeTargetUserNotConnectedToVoice means: Target user is not connected to voice
+	JSONErrorCodeTargetUserNotConnectedToVoice JSONErrorCode = 40032
+	// JSONErrorCodeMessageAlreadyCrossposted means: This message has already been crossposted
+	JSONErrorCodeMessageAlreadyCrossposted JSONErrorCode = 40033
+	// JSONErrorCodeApplicationCommandNameExists means: An application command with that name already exists
+	JSONErrorCodeApplicationCommandNameExists JSONErrorCode = 40041
+	// JSONErrorCodeApplicationInteractionFailedToSend means: Application interaction failed to send
+	JSONErrorCodeApplicationInteractionFailedToSend JSONErrorCode = 40043
+	// JSONErrorCodeCannotSendMessageInForumChannel means: Cannot send a message in a forum channel
+	JSONErrorCodeCannotSendMessageInForumChannel JSONErrorCode = 40058
+	// JSONErrorCodeInteractionAlreadyAcknowledged means: Interaction has already been acknowledged
+	JSONErrorCodeInteractionAlreadyAcknowledged JSONErrorCode = 40060
+	// JSONErrorCodeTagNamesMustBeUnique means: Tag names must be unique
+	JSONErrorCodeTagNamesMustBeUnique JSONErrorCode = 40061
+	// JSONErrorCodeServiceResourceRateLimited means: Service resource is being rate limited
+	JSONErrorCodeServiceResourceRateLimited JSONErrorCode = 40062
+	// JSONErrorCodeNoTagsAvailableForNonModerators means: There are no tags available that can be set by non-moderators
+	JSONErrorCodeNoTagsAvailableForNonModerators JSONErrorCode = 40066
+	// JSONErrorCodeTagRequiredForForumPost means: A tag is required to create a forum post in this channel
+	JSONErrorCodeTagRequiredForForumPost JSONErrorCode = 40067
+	// JSONErrorCodeEntitlementAlreadyGranted means: An entitlement has already been granted for this resource
+	JSONErrorCodeEntitlementAlreadyGranted JSONErrorCode = 40074
+	// JSONErrorCodeMaxFollowupMessages means: This interaction has hit the maximum number of follow up messages
+	JSONErrorCodeMaxFollowupMessages JSONErrorCode = 40094
+	// JSONErrorCodeCloudflareBlocked means: Cloudflare is blocking your request. This can often be resolved by setting a proper User Agent
+	JSONErrorCodeCloudflareBlocked JSONErrorCode = 40333
+
+	// JSONErrorCodeMissingAccess means: Missing access
+	JSONErrorCodeMissingAccess JSONErrorCode = 50001
+	// JSONErrorCodeInvalidAccountType means: Invalid account type
+	JSONErrorCodeInvalidAccountType JSONErrorCode = 50002
+	// JSONErrorCodeCannotExecuteOnDMChannel means: Cannot execute action on a DM channel
+	JSONErrorCodeCannotExecuteOnDMChannel JSONErrorCode = 50003
+	// JSONErrorCodeGuildWidgetDisabled means: Guild widget disabled
+	JSONErrorCodeGuildWidgetDisabled JSONErrorCode = 50004
+	// JSONErrorCodeCannotEditOtherUsersMessage means: Cannot edit a message authored by another user
+	JSONErrorCodeCannotEditOtherUsersMessage JSONErrorCode = 50005
+	// JSONErrorCodeCannotSendEmptyMessage means: Cannot send an empty message
+	JSONErrorCodeCannotSendEmptyMessage JSONErrorCode = 50006
+	// JSONErrorCodeCannotSendMessagesToUser means: Cannot send messages to this user
+	JSONErrorCodeCannotSendMessagesToUser JSONErrorCode = 50007
+	// JSONErrorCodeCannotSendMessagesInNonTextChannel means: Cannot send messages in a non-text channel
+	JSONErrorCodeCannotSendMessagesInNonTextChannel JSONErrorCode = 50008
+	// JSONErrorCodeChannelVerificationLevelTooHigh means: Channel verification level is too high for you to gain access
+	JSONErrorCodeChannelVerificationLevelTooHigh JSONErrorCode = 50009
+	// JSONErrorCodeOAuth2ApplicationHasNoBot means: OAuth2 application does not have a bot
+	JSONErrorCodeOAuth2ApplicationHasNoBot JSONErrorCode = 50010
+	// JSONErrorCodeOAuth2ApplicationLimitReached means: OAuth2 application limit reached
+	JSONErrorCodeOAuth2ApplicationLimitReached JSONErrorCode = 50011
+	// JSONErrorCodeInvalidOAuth2State means: Invalid OAuth2 state
+	JSONErrorCodeInvalidOAuth2State JSONErrorCode = 50012
+	// JSONErrorCodeMissingPermissions means: You lack permissions to perform that action
+	JSONErrorCodeMissingPermissions JSONErrorCode = 50013
+	// JSONErrorCodeInvalidAuthenticationToken means: Invalid authentication token provided
+	JSONErrorCodeInvalidAuthenticationToken JSONErrorCode = 50014
+	// JSONErrorCodeNoteTooLong means: Note was too long
+	JSONErrorCodeNoteTooLong JSONErrorCode = 50015
+	// JSONErrorCodeInvalidBulkDeleteMessageCount means: Provided too few or too many messages to delete. Must provide at least 2 and fewer than 100 messages to delete
+	JSONErrorCodeInvalidBulkDeleteMessageCount JSONErrorCode = 50016
+	// JSONErrorCodeInvalidMFALevel means: Invalid MFA Level
+	JSONErrorCodeInvalidMFALevel JSONErrorCode = 50017
+	// JSONErrorCodeMessagePinnedInWrongChannel means: A message can only be pinned to the channel it was sent in
+	JSONErrorCodeMessagePinnedInWrongChannel JSONErrorCode = 50019
+	// JSONErrorCodeInvalidInviteCode means: Invite code was either invalid or taken
+	JSONErrorCodeInvalidInviteCode JSONErrorCode = 50020
+	// JSONErrorCodeCannotExecuteOnSystemMessage means: Cannot execute action on a system message
+	JSONErrorCodeCannotExecuteOnSystemMessage JSONErrorCode = 50021
+	// JSONErrorCodeCannotExecuteOnChannelType means: Cannot execute action on this channel type
+	JSONErrorCodeCannotExecuteOnChannelType JSONErrorCode = 50024
+	// JSONErrorCodeInvalidOAuth2AccessToken means: Invalid OAuth2 access token provided
+	JSONErrorCodeInvalidOAuth2AccessToken JSONErrorCode = 50025
+	// JSONErrorCodeMissingOAuth2Scope means: Missing required OAuth2 scope
+	JSONErrorCodeMissingOAuth2Scope JSONErrorCode = 50026
+	// JSONErrorCodeInvalidWebhookToken means: Invalid webhook token provided
+	JSONErrorCodeInvalidWebhookToken JSONErrorCode = 50027
+	// JSONErrorCodeInvalidRole means: Invalid role
+	JSONErrorCodeInvalidRole JSONErrorCode = 50028
+	// JSONErrorCodeInvalidRecipients means: Invalid Recipient(s)
+	JSONErrorCodeInvalidRecipients JSONErrorCode = 50033
+	// JSONErrorCodeMessageTooOldToBulkDelete means: A message provided was too old to bulk delete
+	JSONErrorCodeMessageTooOldToBulkDelete JSONErrorCode = 50034
+	// JSONErrorCodeInvalidFormBody means: Invalid form body (returned for both application/json and multipart/form-data bodies), or invalid Content-Type provided
+	JSONErrorCodeInvalidFormBody JSONErrorCode = 50035
+	// JSONErrorCodeInviteAcceptedToGuildWithoutBot means: An invite was accepted to a guild the application's bot is not in
+	JSONErrorCodeInviteAcceptedToGuildWithoutBot JSONErrorCode = 50036
+	// JSONErrorCodeInvalidActivityAction means: Invalid Activity Action
+	JSONErrorCodeInvalidActivityAction JSONErrorCode = 50039
+	// JSONErrorCodeInvalidAPIVersion means: Invalid API version provided
+	JSONErrorCodeInvalidAPIVersion JSONErrorCode = 50041
+	// JSONErrorCodeFileExceedsMaximumSize means: File uploaded exceeds the maximum size
+	JSONErrorCodeFileExceedsMaximumSize JSONErrorCode = 50045
+	// JSONErrorCodeInvalidFileUploaded means: Invalid file uploaded
+	JSONErrorCodeInvalidFileUploaded JSONErrorCode = 50046
+	// JSONErrorCodeCannotSelfRedeemGift means: Cannot self-redeem this gift
+	JSONErrorCodeCannotSelfRedeemGift JSONErrorCode = 50054
+	// JSONErrorCodeInvalidGuild means: Invalid Guild
+	JSONErrorCodeInvalidGuild JSONErrorCode = 50055
+	// JSONErrorCodeInvalidSKU means: Invalid SKU
+	JSONErrorCodeInvalidSKU JSONErrorCode = 50057
+	// JSONErrorCodeInvalidRequestOrigin means: Invalid request origin
+	JSONErrorCodeInvalidRequestOrigin JSONErrorCode = 50067
+	// JSONErrorCodeInvalidMessageType means: Invalid message type
+	JSONErrorCodeInvalidMessageType JSONErrorCode = 50068
+	// JSONErrorCodePaymentSourceRequired means: Payment source required to redeem gift
+	JSONErrorCodePaymentSourceRequired JSONErrorCode = 50070
+	// JSONErrorCodeCannotModifySystemWebhook means: Cannot modify a system webhook
+	JSONErrorCodeCannotModifySystemWebhook JSONErrorCode = 50073
+	// JSONErrorCodeCannotDeleteCommunityChannel means: Cannot delete a channel required for Community guilds
+	JSONErrorCodeCannotDeleteCommunityChannel JSONErrorCode = 50074
+	// JSONErrorCodeCannotEditMessageStickers means: Cannot edit stickers within a message
+	JSONErrorCodeCannotEditMessageStickers JSONErrorCode = 50080
+	// JSONErrorCodeInvalidSticker means: Invalid sticker sent
+	JSONErrorCodeInvalidSticker JSONErrorCode = 50081
+	// JSONErrorCodeThreadArchived means: Tried to perform an operation on an archived thread
+	JSONErrorCodeThreadArchived JSONErrorCode = 50083
+	// JSONErrorCodeInvalidThreadNotificationSettings means: Invalid thread notification settings
+	JSONErrorCodeInvalidThreadNotificationSettings JSONErrorCode = 50084
+	// JSONErrorCodeBeforeEarlierThanThreadCreation means: before value is earlier than the thread creation date
+	JSONErrorCodeBeforeEarlierThanThreadCreation JSONErrorCode = 50085
+	// JSONErrorCodeCommunityChannelsMustBeText means: Community server channels must be text channels
+	JSONErrorCodeCommunityChannelsMustBeText JSONErrorCode = 50086
+	// JSONErrorCodeEventEntityTypeMismatch means: The entity type of the event is different from the entity you are trying to start the event for
+	JSONErrorCodeEventEntityTypeMismatch JSONErrorCode = 50091
+	// JSONErrorCodeServerNotAvailableInLocation means: This server is not available in your location
+	JSONErrorCodeServerNotAvailableInLocation JSONErrorCode = 50095
+	// JSONErrorCodeMonetizationRequired means: This server needs monetization enabled in order to perform this action
+	JSONErrorCodeMonetizationRequired JSONErrorCode = 50097
+	// JSONErrorCodeMoreBoostsRequired means: This server needs more boosts to perform this action
+	JSONErrorCodeMoreBoostsRequired JSONErrorCode = 50101
+	// JSONErrorCodeInvalidJSON means: The request body contains invalid JSON
+	JSONErrorCodeInvalidJSON JSONErrorCode = 50109
+	// JSONErrorCodeInvalidFile means: The provided file is invalid
+	JSONErrorCodeInvalidFile JSONErrorCode = 50110
+	// JSONErrorCodeInvalidFileType means: The provided file type is invalid
+	JSONErrorCodeInvalidFileType JSONErrorCode = 50123
+	// JSONErrorCodeFileDurationTooLong means: The provided file duration exceeds maximum of 5.2 seconds
+	JSONErrorCodeFileDurationTooLong JSONErrorCode = 50124
+	// JSONErrorCodeOwnerCannotBePending means: Owner cannot be pending member
+	JSONErrorCodeOwnerCannotBePending JSONErrorCode = 50131
+	// JSONErrorCodeCannotTransferOwnershipToBot means: Ownership cannot be transferred to a bot user
+	JSONErrorCodeCannotTransferOwnershipToBot JSONErrorCode = 50132
+	// JSONErrorCodeAssetResizeFailed means: Failed to resize asset below the maximum size: 262144
+	JSONErrorCodeAssetResizeFailed JSONErrorCode = 50138
+	// JSONErrorCodeCannotMixSubscriptionRoles means: Cannot mix subscription and non subscription roles for an emoji
+	JSONErrorCodeCannotMixSubscriptionRoles JSONErrorCode = 50144
+	// JSONErrorCodeCannotConvertPremiumEmoji means: Cannot convert between premium emoji and normal emoji
+	JSONErrorCodeCannotConvertPremiumEmoji JSONErrorCode = 50145
+	// JSONErrorCodeUploadedFileNotFound means: Uploaded file not found
+	JSONErrorCodeUploadedFileNotFound JSONErrorCode = 50146
+	// JSONErrorCodeInvalidEmoji means: The specified emoji is invalid
+	JSONErrorCodeInvalidEmoji JSONErrorCode = 50151
+	// JSONErrorCodeVoiceMessageAdditionalContent means: Voice messages do not support additional content
+	JSONErrorCodeVoiceMessageAdditionalContent JSONErrorCode = 50159
+	// JSONErrorCodeVoiceMessageSingleAttachment means: Voice messages must have a single audio attachment
+	JSONErrorCodeVoiceMessageSingleAttachment JSONErrorCode = 50160
+	// JSONErrorCodeVoiceMessageMetadataRequired means: Voice messages must have supporting metadata
+	JSONErrorCodeVoiceMessageMetadataRequired JSONErrorCode = 50161
+	// JSONErrorCodeVoiceMessageCannotBeEdited means: Voice messages cannot be edited
+	JSONErrorCodeVoiceMessageCannotBeEdited JSONErrorCode = 50162
+	// JSONErrorCodeCannotDeleteGuildSubscriptionIntegration means: Cannot delete guild subscription integration
+	JSONErrorCodeCannotDeleteGuildSubscriptionIntegration JSONErrorCode = 50163
+	// JSONErrorCodeCannotSendVoiceMessages means: You cannot send voice messages in this channel
+	JSONErrorCodeCannotSendVoiceMessages JSONErrorCode = 50173
+	// JSONErrorCodeAccountVerificationRequiredFirst means: The user account must first be verified
+	JSONErrorCodeAccountVerificationRequiredFirst JSONErrorCode = 50178
+	// JSONErrorCodeInvalidFileDuration means: The provided file does not have a valid duration
+	JSONErrorCodeInvalidFileDuration JSONErrorCode = 50192
+	// JSONErrorCodeNoPermissionToSendSticker means: You do not have permission to send this sticker
+	JSONErrorCodeNoPermissionToSendSticker JSONErrorCode = 50600
+
+	// JSONErrorCodeTwoFactorRequired means: Two factor is required for this operation
+	JSONErrorCodeTwoFactorRequired JSONErrorCode = 60003
+
+	// JSONErrorCodeNoUsersWithDiscordTag means: No users with DiscordTag exist
+	JSONErrorCodeNoUsersWithDiscordTag JSONErrorCode = 80004
+
+	// JSONErrorCodeReactionBlocked means: Reaction was blocked
+	JSONErrorCodeReactionBlocked JSONErrorCode = 90001
+	// JSONErrorCodeCannotUseBurstReactions means: User cannot use burst reactions
+	JSONErrorCodeCannotUseBurstReactions JSONErrorCode = 90002
+
+	// JSONErrorCodeApplicationNotAvailable means: Application not yet available. Try again later
+	JSONErrorCodeApplicationNotAvailable JSONErrorCode = 110001
+
+	// JSONErrorCodeAPIOverloaded means: API resource is currently overloaded. Try again a little later
+	JSONErrorCodeAPIOverloaded JSONErrorCode = 130000
+
+	// JSONErrorCodeStageAlreadyOpen means: The Stage is already open
+	JSONErrorCodeStageAlreadyOpen JSONErrorCode = 150006
+
+	// JSONErrorCodeCannotReplyWithoutReadMessageHistory means: Cannot reply without permission to read message history
+	JSONErrorCodeCannotReplyWithoutReadMessageHistory JSONErrorCode = 160002
+	// JSONErrorCodeThreadAlreadyCreated means: A thread has already been created for this message
+	JSONErrorCodeThreadAlreadyCreated JSONErrorCode = 160004
+	// JSONErrorCodeThreadLocked means: Thread is locked
+	JSONErrorCodeThreadLocked JSONErrorCode = 160005
+	// JSONErrorCodeMaxActiveThreads means: Maximum number of active threads reached
+	JSONErrorCodeMaxActiveThreads JSONErrorCode = 160006
+	// JSONErrorCodeMaxActiveAnnouncementThreads means: Maximum number of active announcement threads reached
+	JSONErrorCodeMaxActiveAnnouncementThreads JSONErrorCode = 160007
+
+	// JSONErrorCodeInvalidLottieJSON means: Invalid JSON for uploaded Lottie file
+	JSONErrorCodeInvalidLottieJSON JSONErrorCode = 170001
+	// JSONErrorCodeLottieContainsRasterImages means: Uploaded Lotties cannot contain rasterized images such as PNG or JPEG
+	JSONErrorCodeLottieContainsRasterImages JSONErrorCode = 170002
+	// JSONErrorCodeStickerMaxFramerateExceeded means: Sticker maximum framerate exceeded
+	JSONErrorCodeStickerMaxFramerateExceeded JSONErrorCode = 170003
+	// JSONErrorCodeStickerMaxFrameCountExceeded means: Sticker frame count exceeds maximum of 1000 frames
+	JSONErrorCodeStickerMaxFrameCountExceeded JSONErrorCode = 170004
+	// JSONErrorCodeLottieMaxDimensionsExceeded means: Lottie animation maximum dimensions exceeded
+	JSONErrorCodeLottieMaxDimensionsExceeded JSONErrorCode = 170005
+	// JSONErrorCodeStickerInvalidFrameRate means: Sticker frame rate is either too small or too large
+	JSONErrorCodeStickerInvalidFrameRate JSONErrorCode = 170006
+	// JSONErrorCodeStickerMaxDurationExceeded means: Sticker animation duration exceeds maximum of 5 seconds
+	JSONErrorCodeStickerMaxDurationExceeded JSONErrorCode = 170007
+
+	// JSONErrorCodeCannotUpdateFinishedEvent means: Cannot update a finished event
+	JSONErrorCodeCannotUpdateFinishedEvent JSONErrorCode = 180000
+	// JSONErrorCodeFailedToCreateStageForEvent means: Failed to create stage needed for stage event
+	JSONErrorCodeFailedToCreateStageForEvent JSONErrorCode = 180002
+
+	// JSONErrorCodeMessageBlockedByAutoModeration means: Message was blocked by automatic moderation
+	JSONErrorCodeMessageBlockedByAutoModeration JSONErrorCode = 200000
+	// JSONErrorCodeTitleBlockedByAutoModeration means: Title was blocked by automatic moderation
+	JSONErrorCodeTitleBlockedByAutoModeration JSONErrorCode = 200001
+
+	// JSONErrorCodeForumWebhookThreadNameOrIDRequired means: Webhooks posted to forum channels must have a thread_name or thread_id
+	JSONErrorCodeForumWebhookThreadNameOrIDRequired JSONErrorCode = 220001
+	// JSONErrorCodeForumWebhookThreadNameAndID means: Webhooks posted to forum channels cannot have both a thread_name and thread_id
+	JSONErrorCodeForumWebhookThreadNameAndID JSONErrorCode = 220002
+	// JSONErrorCodeWebhookThreadsOnlyInForum means: Webhooks can only create threads in forum channels
+	JSONErrorCodeWebhookThreadsOnlyInForum JSONErrorCode = 220003
+	// JSONErrorCodeWebhookServicesNotInForum means: Webhook services cannot be used in forum channels
+	JSONErrorCodeWebhookServicesNotInForum JSONErrorCode = 220004
+
+	// JSONErrorCodeMessageBlockedByHarmfulLinksFilter means: Message blocked by harmful links filter
+	JSONErrorCodeMessageBlockedByHarmfulLinksFilter JSONErrorCode = 240000
+
+	// JSONErrorCodeCannotEnableOnboarding means: Cannot enable onboarding, requirements are not met
+	JSONErrorCodeCannotEnableOnboarding JSONErrorCode = 350000
+	// JSONErrorCodeCannotUpdateOnboarding means: Cannot update onboarding while below requirements
+	JSONErrorCodeCannotUpdateOnboarding JSONErrorCode = 350001
+
+	// JSONErrorCodeFailedToBanUsers means: Failed to ban users
+	JSONErrorCodeFailedToBanUsers JSONErrorCode = 500000
+
+	// JSONErrorCodePollVotingBlocked means: Poll voting blocked
+	JSONErrorCodePollVotingBlocked JSONErrorCode = 520000
+	// JSONErrorCodePollExpired means: Poll expired
+	JSONErrorCodePollExpired JSONErrorCode = 520001
+	// JSONErrorCodeInvalidPollChannelType means: Invalid channel type for poll creation
+	JSONErrorCodeInvalidPollChannelType JSONErrorCode = 520002
+	// JSONErrorCodeCannotEditPollMessage means: Cannot edit a poll message
+	JSONErrorCodeCannotEditPollMessage JSONErrorCode = 520003
+	// JSONErrorCodeCannotUsePollEmoji means: Cannot use an emoji included with the poll
+	JSONErrorCodeCannotUsePollEmoji JSONErrorCode = 520004
+	// JSONErrorCodeCannotExpireNonPollMessage means: Cannot expire a non-poll message
+	JSONErrorCodeCannotExpireNonPollMessage JSONErrorCode = 520006
+)
@@ -0,0 +1,47 @@
+package rest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorFieldErrors(t *testing.T) {
+	rsBody := []byte(`{
+		"code": 50035,
+		"message": "Invalid Form Body",
+		"errors": {
+			"content": {"_errors": [{"code": "BASE_TYPE_MAX_LENGTH", "message": "Must be 2000 or fewer in length."}]},
+			"embeds": {
+				"10": {"title": {"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "This field is required"}]}},
+				"2": {"fields": {"3": {"value": {"_errors": [{"code": "BASE_TYPE_REQUIRED", "message": "This field is required"}]}}}}
+			}
+		}
+	}`)
+
+	err := NewError(nil, nil, nil, rsBody)
+	var restErr Error
+	require.True(t, errors.As(err, &restErr))
+	assert.Equal(t, JSONErrorCodeInvalidFormBody, restErr.Code)
+
+	assert.Equal(t, []FieldError{
+		{Path: "content", Code: "BASE_TYPE_MAX_LENGTH", Message: "Must be 2000 or fewer in length."},
+		{Path: "embeds.2.fields.3.value", Code: "BASE_TYPE_REQUIRED", Message: "This field is required"},
+		{Path: "embeds.10.title", Code: "BASE_TYPE_REQUIRED", Message: "This field is required"},
+	}, restErr.FieldErrors())
+
+	assert.Len(t, restErr.FieldErrorsAt("embeds.2"), 1)
+	assert.Len(t, restErr.FieldErrorsAt("embeds.1"), 0)
+	assert.Len(t, restErr.FilterFieldErrors(func(fieldError FieldError) bool {
+		return fieldError.Code == "BASE_TYPE_REQUIRED"
+	}), 2)
+	assert.Contains(t, restErr.Error(), "embeds.2.fields.3.value: This field is required (BASE_TYPE_REQUIRED)")
+}
+
+func TestParseFieldErrorsRoot(t *testing.T) {
+	fieldErrors, err := ParseFieldErrors([]byte(`{"_errors": [{"code": "DICT_TYPE_CONVERT", "message": "Only dictionaries may be used in a DictType"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []FieldError{{Code: "DICT_TYPE_CONVERT", Message: "Only dictionaries may be used in a DictType"}}, fieldErrors)
+}
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// InterceptedRequest is a request passed through the Interceptor chain of the Client.
+type InterceptedRequest struct {
+	// Endpoint is the endpoint of the request
+	Endpoint *CompiledEndpoint
+	// Request is the http request which is sent. Interceptors can modify it, like adding headers
+	Request *http.Request
+	// Body is the marshalled request body or nil for empty & streamed bodies
+	Body []byte
+	// Attempt is the number of the attempt starting at 1, including retries of rate limited requests
+	Attempt int
+}
+
+// RoundTrip sends an InterceptedRequest & returns the response.
+type RoundTrip func(rq *InterceptedRequest) (*http.Response, error)
+
+// Interceptor intercepts all requests of the Client after they passed the RateLimiter.
+// It can call next to send the request and inspect the response & latency, or return a canned response without calling next.
+// A returned error is handled like a network error & might be retried according to the RetryPolicy.
+type Interceptor func(rq *InterceptedRequest, next RoundTrip) (*http.Response, error)
+
+// chainInterceptors returns a RoundTrip which passes the request through all interceptors, with the first being the outermost, before calling roundTrip.
+func chainInterceptors(interceptors []Interceptor, roundTrip RoundTrip) RoundTrip {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor := interceptors[i]
+		next := roundTrip
+		roundTrip = func(rq *InterceptedRequest) (*http.Response, error) {
+			return interceptor(rq, next)
+		}
+	}
+	return roundTrip
+}
+
+// NewCannedResponse returns a http.Response with the given status code & json body for the given request, which can be returned by an Interceptor.
+func NewCannedResponse(rq *http.Request, statusCode int, body []byte) *http.Response {
+	header := http.Header{}
+	if body != nil {
+		header.Set("Content-Type", "application/json")
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(statusCode) + " " + http.StatusText(statusCode),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       rq,
+	}
+}
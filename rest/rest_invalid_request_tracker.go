@@ -0,0 +1,195 @@
+package rest
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// InvalidRequestLimit is the number of 401, 403 & 429 responses in InvalidRequestWindow after which Discord temporarily bans the IP
+	InvalidRequestLimit = 10000
+	// InvalidRequestWindow is the sliding window in which invalid requests are counted
+	InvalidRequestWindow = 10 * time.Minute
+
+	// invalidRequestSlots is the number of slots the window is divided in
+	invalidRequestSlots = 60
+	// minInvalidRequestWindow is the shortest window, so each slot is a meaningful & non-zero duration
+	minInvalidRequestWindow = time.Second
+)
+
+// InvalidRequestLimitError is returned by Client.Do when the circuit breaker of the InvalidRequestTracker refuses a request.
+type InvalidRequestLimitError struct {
+	// Count is the number of invalid requests in the current window
+	Count int
+	// Threshold is the count at which requests are refused
+	Threshold int
+	// Limit is the count at which Discord bans the IP
+	Limit int
+	// RetryAfter is the time until the count drops below the threshold
+	RetryAfter time.Duration
+}
+
+func (e *InvalidRequestLimitError) Error() string {
+	return fmt.Sprintf("invalid request circuit breaker open: %d/%d invalid requests (limit %d), retry after %s", e.Count, e.Threshold, e.Limit, e.RetryAfter)
+}
+
+// InvalidRequestTracker counts 401, 403 & 429 responses in a sliding window to avoid Discord's temporary IP ban.
+// Shared 429 responses are not counted, as they don't count towards the limit.
+type InvalidRequestTracker interface {
+	// Count returns the number of invalid requests in the current window
+	Count() int
+
+	// Allow returns an *InvalidRequestLimitError if the circuit breaker is enabled & open
+	Allow() error
+
+	// Track counts the response if it is an invalid request
+	Track(rs *http.Response)
+
+	// Reset resets the count to 0
+	Reset()
+}
+
+// NewInvalidRequestTracker returns a new InvalidRequestTracker with the given InvalidRequestTrackerConfigOpt(s).
+func NewInvalidRequestTracker(opts ...InvalidRequestTrackerConfigOpt) InvalidRequestTracker {
+	config := DefaultInvalidRequestTrackerConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "rest_invalid_request_tracker"))
+
+	return &invalidRequestTrackerImpl{
+		config:   *config,
+		slotSize: config.Window / invalidRequestSlots,
+		now:      time.Now,
+	}
+}
+
+var _ InvalidRequestTracker = (*invalidRequestTrackerImpl)(nil)
+
+type invalidRequestTrackerImpl struct {
+	config   InvalidRequestTrackerConfig
+	slotSize time.Duration
+	now      func() time.Time
+
+	mu sync.Mutex
+	// slots hold the count of each slot, indexed by the slot number modulo invalidRequestSlots
+	slots [invalidRequestSlots]struct {
+		slot  int64
+		count int
+	}
+	// reached is the highest warn threshold which was reached & is still reached
+	reached int
+}
+
+// slot returns the number of the slot the current time falls in
+func (t *invalidRequestTrackerImpl) slot() int64 {
+	return t.now().UnixNano() / int64(t.slotSize)
+}
+
+// count returns the number of invalid requests in the window ending with the given slot
+func (t *invalidRequestTrackerImpl) count(current int64) int {
+	var count int
+	for _, s := range t.slots {
+		if s.slot > current-invalidRequestSlots {
+			count += s.count
+		}
+	}
+	return count
+}
+
+func (t *invalidRequestTrackerImpl) Count() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.count(t.slot())
+}
+
+func (t *invalidRequestTrackerImpl) Allow() error {
+	if !t.config.CircuitBreaker {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := t.slot()
+	count := t.count(current)
+	if count < t.config.CircuitBreakerThreshold {
+		return nil
+	}
+
+	// find the slot which has to expire until the count drops below the threshold
+	remaining := count
+	for slot := max(current-invalidRequestSlots+1, 0); slot <= current; slot++ {
+		s := t.slots[slot%invalidRequestSlots]
+		if s.slot != slot {
+			continue
+		}
+		remaining -= s.count
+		if remaining < t.config.CircuitBreakerThreshold {
+			expires := time.Unix(0, (slot+invalidRequestSlots)*int64(t.slotSize))
+			return &InvalidRequestLimitError{
+				Count:      count,
+				Threshold:  t.config.CircuitBreakerThreshold,
+				Limit:      t.config.Limit,
+				RetryAfter: expires.Sub(t.now()),
+			}
+		}
+	}
+	return &InvalidRequestLimitError{
+		Count:      count,
+		Threshold:  t.config.CircuitBreakerThreshold,
+		Limit:      t.config.Limit,
+		RetryAfter: t.config.Window,
+	}
+}
+
+func (t *invalidRequestTrackerImpl) Track(rs *http.Response) {
+	if !isInvalidRequest(rs) {
+		return
+	}
+
+	t.mu.Lock()
+	current := t.slot()
+	s := &t.slots[current%invalidRequestSlots]
+	if s.slot != current {
+		s.slot = current
+		s.count = 0
+	}
+	s.count++
+	count := t.count(current)
+
+	var threshold int
+	for _, th := range t.config.WarnThresholds {
+		if count >= th && th > threshold {
+			threshold = th
+		}
+	}
+	crossed := threshold > t.reached
+	t.reached = threshold
+	t.mu.Unlock()
+
+	if !crossed {
+		return
+	}
+	t.config.Logger.Warn("invalid request threshold reached", slog.Int("count", count), slog.Int("threshold", threshold), slog.Int("limit", t.config.Limit))
+	if t.config.OnThreshold != nil {
+		t.config.OnThreshold(count, threshold)
+	}
+}
+
+func (t *invalidRequestTrackerImpl) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	clear(t.slots[:])
+	t.reached = 0
+}
+
+func isInvalidRequest(rs *http.Response) bool {
+	switch rs.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return true
+	case http.StatusTooManyRequests:
+		return rs.Header.Get("X-RateLimit-Scope") != "shared"
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"log/slog"
+	"time"
+)
+
+// DefaultInvalidRequestTrackerConfig is the configuration which is used by default.
+func DefaultInvalidRequestTrackerConfig() *InvalidRequestTrackerConfig {
+	return &InvalidRequestTrackerConfig{
+		Logger: slog.Default(),
+		Limit:  InvalidRequestLimit,
+		Window: InvalidRequestWindow,
+	}
+}
+
+// InvalidRequestTrackerConfig is the configuration for the invalid request tracker.
+type InvalidRequestTrackerConfig struct {
+	Logger *slog.Logger
+	// Limit is the number of invalid requests in Window after which Discord bans the IP.
+	// The WarnThresholds & CircuitBreakerThreshold default to fractions of it and are capped to it.
+	Limit int
+	// Window is the duration of the sliding window invalid requests are counted in. It is raised to at least one second.
+	Window time.Duration
+	// WarnThresholds are the counts at which a warning is logged & OnThreshold is called.
+	// They default to 50%, 75% & 90% of the Limit.
+	WarnThresholds []int
+	// OnThreshold is called when the count of invalid requests reaches one of the WarnThresholds.
+	OnThreshold func(count int, threshold int)
+	// CircuitBreaker enables refusing requests with an InvalidRequestLimitError once CircuitBreakerThreshold is reached.
+	CircuitBreaker bool
+	// CircuitBreakerThreshold is the count of invalid requests at which requests are refused. It defaults to 95% of the Limit.
+	CircuitBreakerThreshold int
+}
+
+// InvalidRequestTrackerConfigOpt can be used to supply optional parameters to NewInvalidRequestTracker.
+type InvalidRequestTrackerConfigOpt func(config *InvalidRequestTrackerConfig)
+
+// Apply applies the given InvalidRequestTrackerConfigOpt(s) to the InvalidRequestTrackerConfig.
+func (c *InvalidRequestTrackerConfig) Apply(opts []InvalidRequestTrackerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Limit <= 0 {
+		c.Limit = InvalidRequestLimit
+	}
+	if c.Window < minInvalidRequestWindow {
+		c.Window = minInvalidRequestWindow
+	}
+	if c.WarnThresholds == nil {
+		c.WarnThresholds = []int{c.Limit / 2, c.Limit * 3 / 4, c.Limit * 9 / 10}
+	}
+	if c.CircuitBreakerThreshold <= 0 || c.CircuitBreakerThreshold > c.Limit {
+		c.CircuitBreakerThreshold = c.Limit * 95 / 100
+	}
+}
+
+// WithInvalidRequestTrackerLogger applies a custom logger to the invalid request tracker.
+func WithInvalidRequestTrackerLogger(logger *slog.Logger) InvalidRequestTrackerConfigOpt {
+	return func(config *InvalidRequestTrackerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithInvalidRequestLimit sets the number of invalid requests allowed in the given window.
+func WithInvalidRequestLimit(limit int, window time.Duration) InvalidRequestTrackerConfigOpt {
+	return func(config *InvalidRequestTrackerConfig) {
+		config.Limit = limit
+		config.Window = window
+	}
+}
+
+// WithWarnThresholds sets the counts at which a warning is logged & the given func is called. The func may be nil.
+func WithWarnThresholds(onThreshold func(count int, threshold int), thresholds ...int) InvalidRequestTrackerConfigOpt {
+	return func(config *InvalidRequestTrackerConfig) {
+		config.OnThreshold = onThreshold
+		config.WarnThresholds = thresholds
+	}
+}
+
+// WithCircuitBreaker makes the invalid request tracker refuse all requests with an InvalidRequestLimitError once the given count of invalid requests is reached.
+// A threshold of 0 or above the limit uses the default of 95% of the limit.
+func WithCircuitBreaker(threshold int) InvalidRequestTrackerConfigOpt {
+	return func(config *InvalidRequestTrackerConfig) {
+		config.CircuitBreaker = true
+		config.CircuitBreakerThreshold = threshold
+	}
+}
@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidRequestTracker(t *testing.T) {
+	now := time.Unix(0, 0)
+	var thresholds []int
+	tracker := NewInvalidRequestTracker(
+		WithInvalidRequestLimit(10, time.Minute),
+		WithWarnThresholds(func(count int, threshold int) {
+			thresholds = append(thresholds, threshold)
+		}, 5, 8),
+		WithCircuitBreaker(9),
+	).(*invalidRequestTrackerImpl)
+	tracker.now = func() time.Time { return now }
+
+	tracker.Track(&http.Response{StatusCode: http.StatusOK})
+	tracker.Track(&http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"X-Ratelimit-Scope": {"shared"}}})
+	assert.Equal(t, 0, tracker.Count())
+
+	for i := 0; i < 5; i++ {
+		tracker.Track(&http.Response{StatusCode: http.StatusForbidden})
+	}
+	now = now.Add(30 * time.Second)
+	for i := 0; i < 4; i++ {
+		tracker.Track(&http.Response{StatusCode: http.StatusUnauthorized})
+	}
+	assert.Equal(t, 9, tracker.Count())
+	assert.Equal(t, []int{5, 8}, thresholds)
+
+	var limitErr *InvalidRequestLimitError
+	require.True(t, errors.As(tracker.Allow(), &limitErr))
+	assert.Equal(t, 30*time.Second, limitErr.RetryAfter)
+
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, 4, tracker.Count())
+	assert.NoError(t, tracker.Allow())
+}
+
+func TestInvalidRequestTrackerConfig(t *testing.T) {
+	tracker := NewInvalidRequestTracker(
+		WithInvalidRequestLimit(100, time.Nanosecond),
+		WithCircuitBreaker(1000),
+	).(*invalidRequestTrackerImpl)
+
+	assert.Equal(t, time.Second, tracker.config.Window)
+	assert.Equal(t, []int{50, 75, 90}, tracker.config.WarnThresholds)
+	assert.Equal(t, 95, tracker.config.CircuitBreakerThreshold)
+
+	// the slot size is not 0, so counting doesn't divide by zero
+	tracker.Track(&http.Response{StatusCode: http.StatusForbidden})
+	assert.Equal(t, 1, tracker.Count())
+}
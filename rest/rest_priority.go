@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"context"
+	"sync"
+)
+
+// Priority is the priority of a request in the RateLimiter. Waiters with a higher priority are served first, waiters with the same priority in FIFO order.
+type Priority int
+
+const (
+	// PriorityBackground is for bulk jobs which can wait, like syncing roles.
+	PriorityBackground Priority = iota - 1
+	// PriorityNormal is the default priority.
+	PriorityNormal
+	// PriorityInteraction is for requests which have to happen within the interaction token window. Requests to interaction endpoints use it by default.
+	PriorityInteraction
+)
+
+// priorities is the number of Priority levels
+const priorities = 3
+
+type priorityCtxKey struct{}
+
+// ContextWithPriority returns a copy of the context carrying the given Priority.
+// The Client uses it to pass the Priority of a request to the RateLimiter.
+func ContextWithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityCtxKey{}, priority)
+}
+
+// PriorityFromContext returns the Priority carried by the context or PriorityNormal.
+func PriorityFromContext(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityCtxKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityNormal
+}
+
+func (p Priority) index() int {
+	return min(max(int(p-PriorityBackground), 0), priorities-1)
+}
+
+// priorityMutex is a context-aware mutex which hands the lock to waiters in Priority order and FIFO within each Priority.
+type priorityMutex struct {
+	mu      sync.Mutex
+	locked  bool
+	waiters [priorities][]chan struct{}
+}
+
+// Lock locks the mutex or blocks until it is handed over or the context is done.
+func (m *priorityMutex) Lock(ctx context.Context, priority Priority) error {
+	m.mu.Lock()
+	if !m.locked {
+		m.locked = true
+		m.mu.Unlock()
+		return nil
+	}
+	ch := make(chan struct{})
+	i := priority.index()
+	m.waiters[i] = append(m.waiters[i], ch)
+	m.mu.Unlock()
+
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		m.mu.Lock()
+		for j, waiter := range m.waiters[i] {
+			if waiter == ch {
+				m.waiters[i] = append(m.waiters[i][:j], m.waiters[i][j+1:]...)
+				m.mu.Unlock()
+				return ctx.Err()
+			}
+		}
+		m.mu.Unlock()
+		// the lock was handed over to us in the meantime, pass it on
+		m.Unlock()
+		return ctx.Err()
+	}
+}
+
+// TryLock locks the mutex if it is not locked and reports whether it did.
+func (m *priorityMutex) TryLock() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.locked {
+		return false
+	}
+	m.locked = true
+	return true
+}
+
+// Unlock hands the lock to the next waiter or unlocks the mutex if there is none.
+func (m *priorityMutex) Unlock() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.locked {
+		panic("unlock of unlocked priority mutex")
+	}
+	for i := priorities - 1; i >= 0; i-- {
+		if len(m.waiters[i]) == 0 {
+			continue
+		}
+		ch := m.waiters[i][0]
+		m.waiters[i] = m.waiters[i][1:]
+		close(ch)
+		return
+	}
+	m.locked = false
+}
+
+// Waiters returns the number of goroutines waiting for the lock.
+func (m *priorityMutex) Waiters() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var n int
+	for _, waiters := range m.waiters {
+		n += len(waiters)
+	}
+	return n
+}
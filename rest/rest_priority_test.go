@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityMutex(t *testing.T) {
+	var m priorityMutex
+	require.NoError(t, m.Lock(context.Background(), PriorityNormal))
+
+	var (
+		mu    sync.Mutex
+		order []string
+		wg    sync.WaitGroup
+	)
+	waiters := 0
+	enqueue := func(name string, priority Priority) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, m.Lock(context.Background(), priority))
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			m.Unlock()
+		}()
+		// wait until the goroutine is queued to get a deterministic FIFO order
+		waiters++
+		require.Eventually(t, func() bool { return m.Waiters() == waiters }, time.Second, time.Millisecond)
+	}
+
+	enqueue("background", PriorityBackground)
+	enqueue("normal 1", PriorityNormal)
+	enqueue("interaction", PriorityInteraction)
+	enqueue("normal 2", PriorityNormal)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, m.Lock(ctx, PriorityInteraction), context.Canceled)
+	assert.Equal(t, 4, m.Waiters())
+
+	m.Unlock()
+	wg.Wait()
+	assert.Equal(t, []string{"interaction", "normal 1", "normal 2", "background"}, order)
+	assert.True(t, m.TryLock())
+}
@@ -8,8 +8,6 @@ import (
 	"strconv"
 	"sync"
 	"time"
-
-	"github.com/sasha-s/go-csync"
 )
 
 const (
@@ -38,6 +36,17 @@ type RateLimiter interface {
 	UnlockBucket(endpoint *CompiledEndpoint, rs *http.Response) error
 }
 
+// QueueDepthRateLimiter is a RateLimiter which can report how many requests are waiting for a bucket.
+// The default RateLimiter implements it, check for it with a type assertion.
+type QueueDepthRateLimiter interface {
+	RateLimiter
+
+	// QueueDepth returns the number of requests waiting for the bucket of the given endpoint
+	QueueDepth(endpoint *CompiledEndpoint) int
+}
+
+var _ QueueDepthRateLimiter = (*rateLimiterImpl)(nil)
+
 // NewRateLimiter return a new default RateLimiter with the given RateLimiterConfigOpt(s).
 func NewRateLimiter(opts ...RateLimiterConfigOpt) RateLimiter {
 	config := DefaultRateLimiterConfig()
@@ -48,6 +57,7 @@ func NewRateLimiter(opts ...RateLimiterConfigOpt) RateLimiter {
 		config:  *config,
 		hashes:  map[*Endpoint]string{},
 		buckets: map[string]*bucket{},
+		locked:  map[*CompiledEndpoint]*bucket{},
 	}
 
 	go rateLimiter.cleanup()
@@ -60,14 +70,18 @@ type (
 		config RateLimiterConfig
 
 		// global Rate Limit
-		global time.Time
+		global   time.Time
+		globalMu priorityMutex
 
-		// APIRoute -> Hash
+		// APIRoute -> Hash, the hash is the X-RateLimit-Bucket header once it is known
 		hashes   map[*Endpoint]string
 		hashesMu sync.Mutex
 		// Hash + Major Parameter -> bucket
 		buckets   map[string]*bucket
 		bucketsMu sync.Mutex
+		// CompiledEndpoint -> bucket locked by WaitBucket, as the hash of the endpoint can change until UnlockBucket
+		locked   map[*CompiledEndpoint]*bucket
+		lockedMu sync.Mutex
 	}
 )
 
@@ -104,11 +118,17 @@ func (l *rateLimiterImpl) doCleanup() {
 
 func (l *rateLimiterImpl) Close(ctx context.Context) {
 	var wg sync.WaitGroup
+	// routes with the same bucket hash share a bucket, so only lock each bucket once
+	seen := map[*bucket]struct{}{}
 	for i := range l.buckets {
-		wg.Add(1)
 		b := l.buckets[i]
+		if _, ok := seen[b]; ok {
+			continue
+		}
+		seen[b] = struct{}{}
+		wg.Add(1)
 		go func() {
-			_ = b.mu.CLock(ctx)
+			_ = b.mu.Lock(ctx, PriorityBackground)
 			wg.Done()
 		}()
 	}
@@ -119,8 +139,11 @@ func (l *rateLimiterImpl) Reset() {
 	l.buckets = map[string]*bucket{}
 	l.bucketsMu = sync.Mutex{}
 	l.global = time.Time{}
+	l.globalMu = priorityMutex{}
 	l.hashes = map[*Endpoint]string{}
 	l.hashesMu = sync.Mutex{}
+	l.locked = map[*CompiledEndpoint]*bucket{}
+	l.lockedMu = sync.Mutex{}
 }
 
 func (l *rateLimiterImpl) getRouteHash(endpoint *CompiledEndpoint) string {
@@ -163,41 +186,99 @@ func (l *rateLimiterImpl) getBucket(endpoint *CompiledEndpoint, create bool) *bu
 	return b
 }
 
+// setBucketHash remembers the bucket hash of the endpoint, so all routes with the same hash & major parameter share the given bucket.
+func (l *rateLimiterImpl) setBucketHash(endpoint *CompiledEndpoint, bucketHash string, b *bucket) {
+	l.hashesMu.Lock()
+	if l.hashes[endpoint.Endpoint] == bucketHash {
+		l.hashesMu.Unlock()
+		return
+	}
+	l.hashes[endpoint.Endpoint] = bucketHash
+	l.hashesMu.Unlock()
+
+	hash := bucketHash
+	if endpoint.MajorParams != "" {
+		hash += "+" + endpoint.MajorParams
+	}
+
+	l.bucketsMu.Lock()
+	defer l.bucketsMu.Unlock()
+	// another route with the same hash might already use a bucket, in which case this one is left to the cleanup
+	if _, ok := l.buckets[hash]; !ok {
+		l.buckets[hash] = b
+	}
+	l.config.Logger.Debug("learned bucket hash", slog.String("route", endpoint.Endpoint.Method+"+"+endpoint.Endpoint.Route), slog.String("hash", bucketHash))
+}
+
 func (l *rateLimiterImpl) WaitBucket(ctx context.Context, endpoint *CompiledEndpoint) error {
+	priority := PriorityFromContext(ctx)
 	b := l.getBucket(endpoint, true)
-	l.config.Logger.Debug("locking rest bucket", slog.String("id", b.ID), slog.Int("limit", b.Limit), slog.Int("remaining", b.Remaining), slog.Time("reset", b.Reset))
-	if err := b.mu.CLock(ctx); err != nil {
+	l.config.Logger.Debug("locking rest bucket", slog.String("id", b.ID), slog.Int("limit", b.Limit), slog.Int("remaining", b.Remaining), slog.Time("reset", b.Reset), slog.Int("priority", int(priority)))
+	if err := b.mu.Lock(ctx, priority); err != nil {
 		return err
 	}
 
-	var until time.Time
-	now := time.Now()
-
-	if b.Remaining == 0 && b.Reset.After(now) {
-		until = b.Reset
-	} else {
-		until = l.global
+	if b.Remaining == 0 {
+		if err := waitUntil(ctx, b.Reset); err != nil {
+			b.mu.Unlock()
+			return err
+		}
 	}
 
-	if until.After(now) {
-		// TODO: do we want to return early when we know the rate limit bigger than ctx deadline?
-		if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
-			return context.DeadlineExceeded
+	// waiters of all buckets pass the global rate limit in priority order
+	if l.global.After(time.Now()) {
+		if err := l.globalMu.Lock(ctx, priority); err != nil {
+			b.mu.Unlock()
+			return err
 		}
-
-		select {
-		case <-ctx.Done():
+		err := waitUntil(ctx, l.global)
+		l.globalMu.Unlock()
+		if err != nil {
 			b.mu.Unlock()
-			return ctx.Err()
-		case <-time.After(until.Sub(now)):
+			return err
 		}
 	}
+
+	l.lockedMu.Lock()
+	l.locked[endpoint] = b
+	l.lockedMu.Unlock()
 	return nil
 }
 
-func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Response) error {
+func (l *rateLimiterImpl) QueueDepth(endpoint *CompiledEndpoint) int {
 	b := l.getBucket(endpoint, false)
 	if b == nil {
+		return 0
+	}
+	return b.mu.Waiters()
+}
+
+// waitUntil blocks until the given time or returns early if the context is done or its deadline is before the given time.
+func waitUntil(ctx context.Context, until time.Time) error {
+	now := time.Now()
+	if !until.After(now) {
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
+		return context.DeadlineExceeded
+	}
+
+	timer := time.NewTimer(until.Sub(now))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Response) error {
+	l.lockedMu.Lock()
+	b, ok := l.locked[endpoint]
+	delete(l.locked, endpoint)
+	l.lockedMu.Unlock()
+	if !ok {
 		return nil
 	}
 	defer func() {
@@ -217,8 +298,10 @@ func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Resp
 	}
 
 	b.ID = bucketHeader
+	l.setBucketHash(endpoint, bucketHeader, b)
 
-	global := rs.Header.Get("X-RateLimit-Global") != ""
+	scope := rs.Header.Get("X-RateLimit-Scope")
+	global := rs.Header.Get("X-RateLimit-Global") != "" || scope == "global"
 	cloudflare := rs.Header.Get("via") == ""
 	remainingHeader := rs.Header.Get("X-RateLimit-Remaining")
 	limitHeader := rs.Header.Get("X-RateLimit-Limit")
@@ -226,25 +309,25 @@ func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Resp
 	resetAfterHeader := rs.Header.Get("X-RateLimit-Reset-After")
 	retryAfterHeader := rs.Header.Get("Retry-After")
 
-	l.config.Logger.Debug("ratelimit response headers", slog.Int("code", rs.StatusCode), slog.Bool("global", global), slog.Bool("cloudflare", cloudflare), slog.String("remaining", remainingHeader), slog.String("limit", limitHeader), slog.String("reset", resetHeader), slog.String("reset_after", resetAfterHeader), slog.String("retry_after", retryAfterHeader))
+	l.config.Logger.Debug("ratelimit response headers", slog.Int("code", rs.StatusCode), slog.Bool("global", global), slog.String("scope", scope), slog.Bool("cloudflare", cloudflare), slog.String("remaining", remainingHeader), slog.String("limit", limitHeader), slog.String("reset", resetHeader), slog.String("reset_after", resetAfterHeader), slog.String("retry_after", retryAfterHeader))
 
 	// we hit a rate limit. let's see if it was global cloudflare or a route specific one
 	if rs.StatusCode == http.StatusTooManyRequests {
-		retryAfter, err := strconv.Atoi(retryAfterHeader)
+		retryAfter, err := parseSeconds(retryAfterHeader)
 		if err != nil {
 			return fmt.Errorf("invalid retryAfter %s: %w", retryAfterHeader, err)
 		}
-		reset := time.Now().Add(time.Second * time.Duration(retryAfter))
+		reset := time.Now().Add(retryAfter)
 		if global {
 			l.global = reset
-			l.config.Logger.Warn("global rate limit exceeded", slog.Int("retry_after", retryAfter))
+			l.config.Logger.Warn("global rate limit exceeded", slog.Duration("retry_after", retryAfter))
 		} else if cloudflare {
 			l.global = reset
-			l.config.Logger.Warn("cloudflare rate limit exceeded", slog.Int("retry_after", retryAfter))
+			l.config.Logger.Warn("cloudflare rate limit exceeded", slog.Duration("retry_after", retryAfter))
 		} else {
 			b.Remaining = 0
 			b.Reset = reset
-			l.config.Logger.Warn("rate limit exceeded", slog.String("endpoint", endpoint.URL), slog.Int("retry_after", retryAfter))
+			l.config.Logger.Warn("rate limit exceeded", slog.String("endpoint", endpoint.URL), slog.String("scope", scope), slog.Duration("retry_after", retryAfter))
 		}
 		return nil
 	}
@@ -267,12 +350,12 @@ func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Resp
 
 	// we prioritize the reset after header over the reset header as it's more accurate due to clock differences
 	if resetAfterHeader != "" {
-		resetAfter, err := strconv.ParseFloat(resetAfterHeader, 64)
+		resetAfter, err := parseSeconds(resetAfterHeader)
 		if err != nil {
 			return fmt.Errorf("invalid reset after %s: %w", resetAfterHeader, err)
 		}
 
-		b.Reset = time.Now().Add(time.Duration(resetAfter) * time.Second)
+		b.Reset = time.Now().Add(resetAfter)
 	} else if resetHeader != "" {
 		reset, err := strconv.ParseFloat(resetHeader, 64)
 		if err != nil {
@@ -287,8 +370,17 @@ func (l *rateLimiterImpl) UnlockBucket(endpoint *CompiledEndpoint, rs *http.Resp
 	return nil
 }
 
+// parseSeconds parses a rate limit header containing (fractional) seconds
+func parseSeconds(header string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(header, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
 type bucket struct {
-	mu        csync.Mutex
+	mu        priorityMutex
 	ID        string
 	Reset     time.Time
 	Remaining int
@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterSharedBucketHash(t *testing.T) {
+	limiter := NewRateLimiter().(*rateLimiterImpl)
+	defer limiter.Close(context.Background())
+
+	getMessages := NewEndpoint(http.MethodGet, "/channels/{channel.id}/messages")
+	getMessage := NewEndpoint(http.MethodGet, "/channels/{channel.id}/messages/{message.id}")
+
+	rs := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Via":                     {"1.1 google"},
+			"X-Ratelimit-Bucket":      {"abc"},
+			"X-Ratelimit-Limit":       {"5"},
+			"X-Ratelimit-Remaining":   {"0"},
+			"X-Ratelimit-Reset-After": {"0.25"},
+		},
+	}
+
+	for _, endpoint := range []*CompiledEndpoint{getMessages.Compile(nil, 1), getMessage.Compile(nil, 1, 2)} {
+		require.NoError(t, limiter.WaitBucket(context.Background(), endpoint))
+		require.NoError(t, limiter.UnlockBucket(endpoint, rs))
+	}
+
+	shared := limiter.getBucket(getMessages.Compile(nil, 1), false)
+	assert.Same(t, shared, limiter.getBucket(getMessage.Compile(nil, 1, 2), false))
+	assert.Nil(t, limiter.getBucket(getMessage.Compile(nil, 2, 2), false))
+	assert.Equal(t, "abc", shared.ID)
+	assert.Equal(t, 0, shared.Remaining)
+	assert.WithinDuration(t, time.Now().Add(250*time.Millisecond), shared.Reset, 100*time.Millisecond)
+
+	start := time.Now()
+	endpoint := getMessage.Compile(nil, 1, 3)
+	require.NoError(t, limiter.WaitBucket(context.Background(), endpoint))
+	assert.Greater(t, time.Since(start), 100*time.Millisecond)
+	require.NoError(t, limiter.UnlockBucket(endpoint, nil))
+}
+
+func TestRateLimiterScope(t *testing.T) {
+	limiter := NewRateLimiter().(*rateLimiterImpl)
+	defer limiter.Close(context.Background())
+
+	endpoint := NewEndpoint(http.MethodGet, "/users/@me").Compile(nil)
+	require.NoError(t, limiter.WaitBucket(context.Background(), endpoint))
+	require.NoError(t, limiter.UnlockBucket(endpoint, &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header: http.Header{
+			"Via":                {"1.1 google"},
+			"X-Ratelimit-Bucket": {"def"},
+			"X-Ratelimit-Scope":  {"global"},
+			"Retry-After":        {"1.5"},
+		},
+	}))
+	assert.WithinDuration(t, time.Now().Add(1500*time.Millisecond), limiter.global, 100*time.Millisecond)
+}
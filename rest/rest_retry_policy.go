@@ -0,0 +1,88 @@
+package rest
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// DefaultRetryPolicy returns the RetryPolicy which is used by default.
+// It retries idempotent requests up to 3 times on network errors and 502, 503 & 504 responses.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     3,
+		Methods:        []string{http.MethodGet, http.MethodPut, http.MethodDelete},
+		StatusCodes:    []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+		MaxElapsedTime: 30 * time.Second,
+	}
+}
+
+// RetryPolicy decides whether a request which failed with a network error or one of the StatusCodes is retried.
+// Rate limited requests are always retried up to RateLimiter.MaxRetries times and don't count towards MaxRetries.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries. 0 disables retries.
+	MaxRetries int
+	// Methods are the http methods which are retried. Only idempotent methods should be retried, as a failed request might have been processed by Discord.
+	Methods []string
+	// StatusCodes are the http status codes which are retried.
+	StatusCodes []int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between two retries.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay grows by after each retry.
+	Multiplier float64
+	// Jitter randomizes each delay by up to the given fraction in both directions, so clients don't retry in lockstep.
+	Jitter float64
+	// MaxElapsedTime is the maximum time since the first attempt after which no retry is started. 0 means no limit.
+	MaxElapsedTime time.Duration
+	// OnRetry is called before waiting for a retry.
+	OnRetry func(attempt RetryAttempt)
+}
+
+// RetryAttempt describes a failed attempt which is about to be retried.
+type RetryAttempt struct {
+	// Endpoint is the endpoint of the request.
+	Endpoint *CompiledEndpoint
+	// Attempt is the number of the failed attempt, starting at 1.
+	Attempt int
+	// Err is the network error of the attempt or nil if a response was received.
+	Err error
+	// Response is the response of the attempt or nil on a network error. The body was already read.
+	Response *http.Response
+	// Delay is the time waited before the next attempt.
+	Delay time.Duration
+}
+
+// ShouldRetry reports whether a request with the given method which failed with the given response or network error should be retried.
+func (p RetryPolicy) ShouldRetry(method string, rs *http.Response, err error) bool {
+	if !slices.Contains(p.Methods, method) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return rs != nil && slices.Contains(p.StatusCodes, rs.StatusCode)
+}
+
+// Backoff returns the delay before the retry after the given failed attempt, starting at 1.
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		backoff *= 1 - p.Jitter + 2*p.Jitter*rand.Float64()
+	}
+	return time.Duration(backoff)
+}
@@ -0,0 +1,73 @@
+package resttest
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// DefaultConfig is the configuration which is used by default
+func DefaultConfig() *Config {
+	return &Config{
+		Logger: slog.Default(),
+		BotUser: discord.User{
+			ID:       1000000000000000000,
+			Username: "resttest",
+			Bot:      true,
+		},
+		RateLimit:       5,
+		RateLimitWindow: time.Second,
+	}
+}
+
+// Config is the configuration for the fake server
+type Config struct {
+	Logger *slog.Logger
+	// Token is the bot token requests have to be authorized with. If empty, all requests are accepted
+	Token string
+	// BotUser is returned for the current user & used as author of created messages
+	BotUser discord.User
+	// RateLimit is the number of requests allowed per bucket in RateLimitWindow. 0 disables rate limits
+	RateLimit       int
+	RateLimitWindow time.Duration
+}
+
+// ConfigOpt can be used to supply optional parameters to NewServer
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger applies a custom logger to the fake server
+func WithLogger(logger *slog.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithToken sets the bot token requests have to be authorized with
+func WithToken(token string) ConfigOpt {
+	return func(config *Config) {
+		config.Token = token
+	}
+}
+
+// WithBotUser sets the user returned for the current user & used as author of created messages
+func WithBotUser(user discord.User) ConfigOpt {
+	return func(config *Config) {
+		config.BotUser = user
+	}
+}
+
+// WithRateLimit sets the number of requests allowed per bucket in the given window. A limit of 0 disables rate limits
+func WithRateLimit(limit int, window time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.RateLimit = limit
+		config.RateLimitWindow = window
+	}
+}
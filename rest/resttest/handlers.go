@@ -0,0 +1,568 @@
+package resttest
+
+import (
+	"net/http"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+func unknown(code rest.JSONErrorCode, entity string) (int, any) {
+	return http.StatusNotFound, errorBody(code, "Unknown "+entity)
+}
+
+func invalidFormBody() (int, any) {
+	return http.StatusBadRequest, errorBody(rest.JSONErrorCodeInvalidFormBody, "Invalid Form Body")
+}
+
+func (s *serverImpl) parseObject(rq *request) (object, bool) {
+	o := object{}
+	if len(rq.body) == 0 {
+		return o, true
+	}
+	if err := json.Unmarshal(rq.body, &o); err != nil {
+		return nil, false
+	}
+	return o, true
+}
+
+func (s *serverImpl) getCurrentUser(_ *request) (int, any) {
+	return http.StatusOK, s.config.BotUser
+}
+
+func (s *serverImpl) getUser(rq *request) (int, any) {
+	userID := rq.id("user.id")
+	if userID == s.config.BotUser.ID {
+		return http.StatusOK, s.config.BotUser
+	}
+	user, ok := s.users[userID]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownUser, "User")
+	}
+	return http.StatusOK, user
+}
+
+func (s *serverImpl) getGuild(rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	guild, ok := s.guilds[guildID]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	rs := object{}
+	for key, value := range guild {
+		rs[key] = value
+	}
+	roles := s.roles[guildID]
+	rolesList := make([]object, 0, len(roles))
+	for _, roleID := range sortedIDs(roles) {
+		rolesList = append(rolesList, roles[roleID])
+	}
+	rs.set("roles", rolesList)
+	rs.set("emojis", []any{})
+	rs.set("stickers", []any{})
+	return http.StatusOK, rs
+}
+
+func (s *serverImpl) updateGuild(rq *request) (int, any) {
+	guild, ok := s.guilds[rq.id("guild.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	if err := guild.merge(rq.body); err != nil {
+		return invalidFormBody()
+	}
+	return http.StatusOK, guild
+}
+
+func (s *serverImpl) deleteGuild(rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	if _, ok := s.guilds[guildID]; !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	delete(s.guilds, guildID)
+	delete(s.roles, guildID)
+	delete(s.members, guildID)
+	for channelID, channel := range s.channels {
+		if channel.id("guild_id") == guildID {
+			delete(s.channels, channelID)
+			delete(s.messages, channelID)
+		}
+	}
+	return http.StatusNoContent, nil
+}
+
+func (s *serverImpl) getGuildChannels(rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	if _, ok := s.guilds[guildID]; !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	channels := make([]object, 0)
+	for _, channelID := range sortedIDs(s.channels) {
+		if channel := s.channels[channelID]; channel.id("guild_id") == guildID {
+			channels = append(channels, channel)
+		}
+	}
+	return http.StatusOK, channels
+}
+
+func (s *serverImpl) createGuildChannel(rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	if _, ok := s.guilds[guildID]; !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	channel, ok := s.parseObject(rq)
+	if !ok {
+		return invalidFormBody()
+	}
+	var name string
+	if !channel.get("name", &name) || name == "" {
+		return invalidFormBody()
+	}
+	channelID := s.newID()
+	channel.set("id", channelID)
+	channel.set("guild_id", guildID)
+	if _, ok = channel["type"]; !ok {
+		channel.set("type", 0)
+	}
+	s.channels[channelID] = channel
+	s.messages[channelID] = map[snowflake.ID]object{}
+	return http.StatusCreated, channel
+}
+
+func (s *serverImpl) getChannel(rq *request) (int, any) {
+	channel, ok := s.channels[rq.id("channel.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+	}
+	return http.StatusOK, channel
+}
+
+func (s *serverImpl) updateChannel(rq *request) (int, any) {
+	channel, ok := s.channels[rq.id("channel.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+	}
+	if err := channel.merge(rq.body); err != nil {
+		return invalidFormBody()
+	}
+	return http.StatusOK, channel
+}
+
+func (s *serverImpl) deleteChannel(rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	channel, ok := s.channels[channelID]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+	}
+	delete(s.channels, channelID)
+	delete(s.messages, channelID)
+	return http.StatusOK, channel
+}
+
+func (s *serverImpl) getMessages(rq *request) (int, any) {
+	messages, ok := s.messages[rq.id("channel.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+	}
+	limit := rq.queryInt("limit", 50)
+	if limit < 1 || limit > 100 {
+		return invalidFormBody()
+	}
+
+	// ids are sorted oldest first
+	ids := sortedIDs(messages)
+	switch {
+	case rq.queryID("before") != 0:
+		before := rq.queryID("before")
+		i, _ := slices.BinarySearch(ids, before)
+		ids = ids[max(i-limit, 0):i]
+
+	case rq.queryID("after") != 0:
+		after := rq.queryID("after")
+		i, found := slices.BinarySearch(ids, after)
+		if found {
+			i++
+		}
+		ids = ids[i:min(i+limit, len(ids))]
+
+	case rq.queryID("around") != 0:
+		around := rq.queryID("around")
+		i, _ := slices.BinarySearch(ids, around)
+		start := max(i-limit/2, 0)
+		ids = ids[start:min(start+limit, len(ids))]
+
+	default:
+		ids = ids[max(len(ids)-limit, 0):]
+	}
+
+	rs := make([]object, len(ids))
+	for i, id := range ids {
+		// messages are returned newest first
+		rs[len(ids)-1-i] = messages[id]
+	}
+	return http.StatusOK, rs
+}
+
+func (s *serverImpl) getMessage(rq *request) (int, any) {
+	messages, ok := s.messages[rq.id("channel.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+	}
+	message, ok := messages[rq.id("message.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownMessage, "Message")
+	}
+	return http.StatusOK, message
+}
+
+// validateMessage checks the content of a message create or update body
+func validateMessage(message object, files int, create bool) (int, any, bool) {
+	var content string
+	message.get("content", &content)
+	if utf8.RuneCountInString(content) > 2000 {
+		return http.StatusBadRequest, errorBody(rest.JSONErrorCodeInvalidFormBody, "Invalid Form Body"), false
+	}
+	if !create {
+		return 0, nil, true
+	}
+
+	var embeds, components, stickers []json.RawMessage
+	message.get("embeds", &embeds)
+	message.get("components", &components)
+	message.get("sticker_ids", &stickers)
+	_, poll := message["poll"]
+	if content == "" && len(embeds) == 0 && len(components) == 0 && len(stickers) == 0 && !poll && files == 0 {
+		return http.StatusBadRequest, errorBody(rest.JSONErrorCodeCannotSendEmptyMessage, "Cannot send an empty message"), false
+	}
+	return 0, nil, true
+}
+
+func (s *serverImpl) createMessage(rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	channel, ok := s.channels[channelID]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+	}
+	message, ok := s.parseObject(rq)
+	if !ok {
+		return invalidFormBody()
+	}
+	if status, body, ok := validateMessage(message, len(rq.files), true); !ok {
+		return status, body
+	}
+
+	attachments := make([]object, len(rq.files))
+	for i, file := range rq.files {
+		attachment := object{}
+		attachment.set("id", s.newID())
+		attachment.set("filename", file.Filename)
+		attachment.set("size", file.Size)
+		attachment.set("content_type", file.Header.Get("Content-Type"))
+		attachment.set("url", s.URL()+"/attachments/"+file.Filename)
+		attachments[i] = attachment
+	}
+
+	messageID := s.newID()
+	message.set("id", messageID)
+	message.set("channel_id", channelID)
+	if guildID := channel.id("guild_id"); guildID != 0 {
+		message.set("guild_id", guildID)
+	}
+	message.set("author", s.config.BotUser)
+	message.set("type", 0)
+	message.set("timestamp", messageID.Time())
+	message.set("attachments", attachments)
+	delete(message, "nonce")
+	delete(message, "enforce_nonce")
+	delete(message, "allowed_mentions")
+	delete(message, "message_reference")
+	delete(message, "sticker_ids")
+
+	s.messages[channelID][messageID] = message
+	return http.StatusOK, message
+}
+
+func (s *serverImpl) updateMessage(rq *request) (int, any) {
+	messages, ok := s.messages[rq.id("channel.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+	}
+	message, ok := messages[rq.id("message.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownMessage, "Message")
+	}
+	update, ok := s.parseObject(rq)
+	if !ok {
+		return invalidFormBody()
+	}
+	if status, body, ok := validateMessage(update, len(rq.files), false); !ok {
+		return status, body
+	}
+	delete(update, "allowed_mentions")
+	for key, value := range update {
+		message[key] = value
+	}
+	message.set("edited_timestamp", time.Now())
+	return http.StatusOK, message
+}
+
+func (s *serverImpl) deleteMessage(rq *request) (int, any) {
+	messages, ok := s.messages[rq.id("channel.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+	}
+	messageID := rq.id("message.id")
+	if _, ok = messages[messageID]; !ok {
+		return unknown(rest.JSONErrorCodeUnknownMessage, "Message")
+	}
+	delete(messages, messageID)
+	return http.StatusNoContent, nil
+}
+
+func (s *serverImpl) bulkDeleteMessages(rq *request) (int, any) {
+	messages, ok := s.messages[rq.id("channel.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownChannel, "Channel")
+	}
+	var body struct {
+		Messages []snowflake.ID `json:"messages"`
+	}
+	if err := json.Unmarshal(rq.body, &body); err != nil || len(body.Messages) < 2 || len(body.Messages) > 100 {
+		return invalidFormBody()
+	}
+	for _, messageID := range body.Messages {
+		delete(messages, messageID)
+	}
+	return http.StatusNoContent, nil
+}
+
+func (s *serverImpl) getRoles(rq *request) (int, any) {
+	roles, ok := s.roles[rq.id("guild.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	rs := make([]object, 0, len(roles))
+	for _, roleID := range sortedIDs(roles) {
+		rs = append(rs, roles[roleID])
+	}
+	return http.StatusOK, rs
+}
+
+func (s *serverImpl) getRole(rq *request) (int, any) {
+	roles, ok := s.roles[rq.id("guild.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	role, ok := roles[rq.id("role.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownRole, "Role")
+	}
+	return http.StatusOK, role
+}
+
+func (s *serverImpl) createRole(rq *request) (int, any) {
+	roles, ok := s.roles[rq.id("guild.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	create, ok := s.parseObject(rq)
+	if !ok {
+		return invalidFormBody()
+	}
+
+	role := object{}
+	role.set("name", "new role")
+	role.set("permissions", "0")
+	role.set("color", 0)
+	role.set("hoist", false)
+	role.set("mentionable", false)
+	for key, value := range create {
+		role[key] = value
+	}
+	role.set("id", s.newID())
+	role.set("position", len(roles))
+	delete(role, "icon")
+	roles[role.id("id")] = role
+	return http.StatusOK, role
+}
+
+func (s *serverImpl) updateRole(rq *request) (int, any) {
+	roles, ok := s.roles[rq.id("guild.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	role, ok := roles[rq.id("role.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownRole, "Role")
+	}
+	if err := role.merge(rq.body); err != nil {
+		return invalidFormBody()
+	}
+	return http.StatusOK, role
+}
+
+func (s *serverImpl) deleteRole(rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	roles, ok := s.roles[guildID]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	roleID := rq.id("role.id")
+	if _, ok = roles[roleID]; !ok {
+		return unknown(rest.JSONErrorCodeUnknownRole, "Role")
+	}
+	delete(roles, roleID)
+	for _, member := range s.members[guildID] {
+		var memberRoles []snowflake.ID
+		member.get("roles", &memberRoles)
+		member.set("roles", slices.DeleteFunc(memberRoles, func(id snowflake.ID) bool { return id == roleID }))
+	}
+	return http.StatusNoContent, nil
+}
+
+func (s *serverImpl) getMember(rq *request) (int, any) {
+	members, ok := s.members[rq.id("guild.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	member, ok := members[rq.id("user.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownMember, "Member")
+	}
+	return http.StatusOK, member
+}
+
+func (s *serverImpl) getMembers(rq *request) (int, any) {
+	members, ok := s.members[rq.id("guild.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	limit := rq.queryInt("limit", 1)
+	if limit < 1 || limit > 1000 {
+		return invalidFormBody()
+	}
+	after := rq.queryID("after")
+
+	rs := make([]object, 0, limit)
+	for _, userID := range sortedIDs(members) {
+		if userID <= after {
+			continue
+		}
+		if len(rs) == limit {
+			break
+		}
+		rs = append(rs, members[userID])
+	}
+	return http.StatusOK, rs
+}
+
+func (s *serverImpl) addMember(rq *request) (int, any) {
+	members, ok := s.members[rq.id("guild.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	userID := rq.id("user.id")
+	if _, ok = members[userID]; ok {
+		return http.StatusNoContent, nil
+	}
+	user, ok := s.users[userID]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownUser, "User")
+	}
+	add, ok := s.parseObject(rq)
+	if !ok {
+		return invalidFormBody()
+	}
+	if _, ok = add["access_token"]; !ok {
+		return invalidFormBody()
+	}
+
+	member := object{}
+	member.set("user", user)
+	member.set("roles", []snowflake.ID{})
+	member.set("joined_at", time.Now())
+	member.set("deaf", false)
+	member.set("mute", false)
+	for _, key := range []string{"nick", "roles", "mute", "deaf"} {
+		if value, ok := add[key]; ok {
+			member[key] = value
+		}
+	}
+	members[userID] = member
+	return http.StatusCreated, member
+}
+
+func (s *serverImpl) updateMember(rq *request) (int, any) {
+	members, ok := s.members[rq.id("guild.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	member, ok := members[rq.id("user.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownMember, "Member")
+	}
+	update, ok := s.parseObject(rq)
+	if !ok {
+		return invalidFormBody()
+	}
+	// the channel_id field moves the member between voice channels & is not part of the member
+	delete(update, "channel_id")
+	for key, value := range update {
+		member[key] = value
+	}
+	return http.StatusOK, member
+}
+
+func (s *serverImpl) removeMember(rq *request) (int, any) {
+	members, ok := s.members[rq.id("guild.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	userID := rq.id("user.id")
+	if _, ok = members[userID]; !ok {
+		return unknown(rest.JSONErrorCodeUnknownMember, "Member")
+	}
+	delete(members, userID)
+	return http.StatusNoContent, nil
+}
+
+func (s *serverImpl) addMemberRole(rq *request) (int, any) {
+	return s.updateMemberRoles(rq, func(roles []snowflake.ID, roleID snowflake.ID) []snowflake.ID {
+		if slices.Contains(roles, roleID) {
+			return roles
+		}
+		return append(roles, roleID)
+	})
+}
+
+func (s *serverImpl) removeMemberRole(rq *request) (int, any) {
+	return s.updateMemberRoles(rq, func(roles []snowflake.ID, roleID snowflake.ID) []snowflake.ID {
+		return slices.DeleteFunc(roles, func(id snowflake.ID) bool { return id == roleID })
+	})
+}
+
+func (s *serverImpl) updateMemberRoles(rq *request, update func(roles []snowflake.ID, roleID snowflake.ID) []snowflake.ID) (int, any) {
+	guildID := rq.id("guild.id")
+	members, ok := s.members[guildID]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownGuild, "Guild")
+	}
+	member, ok := members[rq.id("user.id")]
+	if !ok {
+		return unknown(rest.JSONErrorCodeUnknownMember, "Member")
+	}
+	roleID := rq.id("role.id")
+	if _, ok = s.roles[guildID][roleID]; !ok {
+		return unknown(rest.JSONErrorCodeUnknownRole, "Role")
+	}
+
+	var roles []snowflake.ID
+	member.get("roles", &roles)
+	member.set("roles", update(roles, roleID))
+	return http.StatusNoContent, nil
+}
@@ -0,0 +1,330 @@
+// Package resttest implements a fake Discord REST API for tests.
+// It serves the guild, channel, message, role, member & user routes of the rest package against in-memory state,
+// emits rate limit headers & 429 responses and allows injecting errors.
+//
+//	server := resttest.NewServer()
+//	defer server.Close()
+//	server.AddGuild(discord.Guild{ID: guildID, Name: "test"})
+//
+//	client := rest.New(rest.NewClient("token", rest.WithURL(server.URL())))
+package resttest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// Server is a fake Discord REST API backed by an httptest.Server.
+type Server interface {
+	// URL returns the base url of the server, which can be passed to rest.WithURL.
+	URL() string
+
+	// Close shuts down the server.
+	Close()
+
+	// AddUser adds a user which can be added as member to guilds.
+	AddUser(user discord.User)
+	// AddGuild adds a guild.
+	AddGuild(guild discord.Guild)
+	// AddChannel adds a guild channel.
+	AddChannel(channel discord.GuildChannel)
+	// AddRole adds a role to its guild.
+	AddRole(role discord.Role)
+	// AddMember adds a member to its guild.
+	AddMember(member discord.Member)
+	// AddMessage adds a message to its channel.
+	AddMessage(message discord.Message)
+
+	// InjectError makes the server respond with the given error instead of handling matching requests.
+	InjectError(err InjectedError)
+
+	// Requests returns all requests the server received in order.
+	Requests() []Request
+}
+
+// InjectedError is an error response returned for matching requests.
+type InjectedError struct {
+	// Endpoint is the endpoint to fail. nil fails requests to all endpoints
+	Endpoint *rest.Endpoint
+	// Status is the http status code of the response
+	Status int
+	// Code is the Discord error code of the response
+	Code rest.JSONErrorCode
+	// Message is the error message of the response
+	Message string
+	// Times is the number of requests to fail. 0 fails one request, -1 all requests
+	Times int
+}
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	// Endpoint is the matched endpoint or nil if no endpoint matched
+	Endpoint *rest.Endpoint
+	Status   int
+}
+
+// NewServer starts a new Server with the given ConfigOpt(s).
+func NewServer(opts ...ConfigOpt) Server {
+	config := DefaultConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "resttest"))
+
+	s := &serverImpl{
+		config:   *config,
+		users:    map[snowflake.ID]object{},
+		guilds:   map[snowflake.ID]object{},
+		channels: map[snowflake.ID]object{},
+		roles:    map[snowflake.ID]map[snowflake.ID]object{},
+		members:  map[snowflake.ID]map[snowflake.ID]object{},
+		messages: map[snowflake.ID]map[snowflake.ID]object{},
+		buckets:  map[string]*bucket{},
+	}
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+var _ Server = (*serverImpl)(nil)
+
+type serverImpl struct {
+	config Config
+	server *httptest.Server
+
+	mu       sync.Mutex
+	lastID   snowflake.ID
+	users    map[snowflake.ID]object
+	guilds   map[snowflake.ID]object
+	channels map[snowflake.ID]object
+	// guild id -> role id -> role
+	roles map[snowflake.ID]map[snowflake.ID]object
+	// guild id -> user id -> member
+	members map[snowflake.ID]map[snowflake.ID]object
+	// channel id -> message id -> message
+	messages map[snowflake.ID]map[snowflake.ID]object
+	buckets  map[string]*bucket
+	errors   []InjectedError
+	requests []Request
+}
+
+type bucket struct {
+	remaining int
+	reset     time.Time
+}
+
+// request is a request matched to a route
+type request struct {
+	*http.Request
+	params map[string]string
+	body   []byte
+	files  []*multipart.FileHeader
+}
+
+func (r *request) id(param string) snowflake.ID {
+	id, _ := snowflake.Parse(r.params[param])
+	return id
+}
+
+func (r *request) queryInt(key string, def int) int {
+	if v, err := strconv.Atoi(r.URL.Query().Get(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+func (r *request) queryID(key string) snowflake.ID {
+	id, _ := snowflake.Parse(r.URL.Query().Get(key))
+	return id
+}
+
+func (s *serverImpl) URL() string {
+	return s.server.URL
+}
+
+func (s *serverImpl) Close() {
+	s.server.Close()
+}
+
+func (s *serverImpl) InjectError(err InjectedError) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err.Times == 0 {
+		err.Times = 1
+	}
+	s.errors = append(s.errors, err)
+}
+
+func (s *serverImpl) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+func (s *serverImpl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status, body, endpoint := s.serve(w, r)
+	s.requests = append(s.requests, Request{
+		Method:   r.Method,
+		Path:     r.URL.Path,
+		Endpoint: endpoint,
+		Status:   status,
+	})
+	s.config.Logger.Debug("handled request", slog.String("method", r.Method), slog.String("path", r.URL.Path), slog.Int("status", status))
+
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func (s *serverImpl) serve(w http.ResponseWriter, r *http.Request) (int, any, *rest.Endpoint) {
+	if s.config.Token != "" && r.Header.Get("Authorization") != discord.TokenTypeBot.Apply(s.config.Token) {
+		return http.StatusUnauthorized, errorBody(0, "401: Unauthorized"), nil
+	}
+
+	rt, params, methodAllowed := matchRoute(r.Method, r.URL.Path)
+	if rt == nil {
+		if methodAllowed {
+			return http.StatusMethodNotAllowed, errorBody(0, "405: Method Not Allowed"), nil
+		}
+		return http.StatusNotFound, errorBody(0, "404: Not Found"), nil
+	}
+
+	if status, body, limited := s.rateLimit(w, rt.endpoint, params); limited {
+		return status, body, rt.endpoint
+	}
+
+	for i, injected := range s.errors {
+		if injected.Endpoint != nil && injected.Endpoint != rt.endpoint {
+			continue
+		}
+		if injected.Times > 0 {
+			s.errors[i].Times--
+			if s.errors[i].Times == 0 {
+				s.errors = slices.Delete(s.errors, i, i+1)
+			}
+		}
+		return injected.Status, errorBody(injected.Code, injected.Message), rt.endpoint
+	}
+
+	rq := &request{
+		Request: r,
+		params:  params,
+	}
+	if err := readBody(rq); err != nil {
+		return http.StatusBadRequest, errorBody(rest.JSONErrorCodeInvalidFormBody, "Invalid Form Body"), rt.endpoint
+	}
+	status, body := rt.handle(s, rq)
+	return status, body, rt.endpoint
+}
+
+func readBody(rq *request) error {
+	mediaType, _, _ := mime.ParseMediaType(rq.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		var err error
+		rq.body, err = io.ReadAll(rq.Body)
+		return err
+	}
+
+	if err := rq.ParseMultipartForm(32 << 20); err != nil {
+		return err
+	}
+	if payload := rq.MultipartForm.Value["payload_json"]; len(payload) > 0 {
+		rq.body = []byte(payload[0])
+	}
+	for i := 0; ; i++ {
+		files := rq.MultipartForm.File[fmt.Sprintf("files[%d]", i)]
+		if len(files) == 0 {
+			return nil
+		}
+		rq.files = append(rq.files, files[0])
+	}
+}
+
+// rateLimit sets the rate limit headers of the bucket of the endpoint and reports whether the request is rate limited
+func (s *serverImpl) rateLimit(w http.ResponseWriter, endpoint *rest.Endpoint, params map[string]string) (int, any, bool) {
+	if s.config.RateLimit <= 0 {
+		return 0, nil, false
+	}
+
+	h := fnv.New64a()
+	_, _ = io.WriteString(h, endpoint.Method+endpoint.Route)
+	hash := strconv.FormatUint(h.Sum64(), 16)
+
+	key := hash
+	for _, param := range strings.Split(rest.MajorParameters, ":") {
+		if value, ok := params[param]; ok {
+			key += ":" + value
+		}
+	}
+
+	now := time.Now()
+	b, ok := s.buckets[key]
+	if !ok || !b.reset.After(now) {
+		b = &bucket{
+			remaining: s.config.RateLimit,
+			reset:     now.Add(s.config.RateLimitWindow),
+		}
+		s.buckets[key] = b
+	}
+
+	resetAfter := b.reset.Sub(now).Seconds()
+	header := w.Header()
+	header.Set("Via", "1.1 google")
+	header.Set("X-RateLimit-Bucket", hash)
+	header.Set("X-RateLimit-Limit", strconv.Itoa(s.config.RateLimit))
+	header.Set("X-RateLimit-Reset", strconv.FormatFloat(float64(b.reset.UnixMilli())/1000, 'f', 3, 64))
+	header.Set("X-RateLimit-Reset-After", strconv.FormatFloat(resetAfter, 'f', 3, 64))
+
+	if b.remaining == 0 {
+		header.Set("X-RateLimit-Remaining", "0")
+		header.Set("X-RateLimit-Scope", "user")
+		header.Set("Retry-After", strconv.FormatFloat(resetAfter, 'f', 3, 64))
+		return http.StatusTooManyRequests, map[string]any{
+			"message":     "You are being rate limited.",
+			"retry_after": resetAfter,
+			"global":      false,
+		}, true
+	}
+	b.remaining--
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(b.remaining))
+	return 0, nil, false
+}
+
+func errorBody(code rest.JSONErrorCode, message string) map[string]any {
+	return map[string]any{
+		"code":    code,
+		"message": message,
+	}
+}
+
+func (s *serverImpl) newID() snowflake.ID {
+	id := snowflake.New(time.Now())
+	if id <= s.lastID {
+		id = s.lastID + 1
+	}
+	s.lastID = id
+	return id
+}
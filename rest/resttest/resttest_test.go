@@ -0,0 +1,136 @@
+package resttest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+const (
+	guildID   = snowflake.ID(100000000000000001)
+	channelID = snowflake.ID(100000000000000002)
+	userID    = snowflake.ID(100000000000000003)
+)
+
+func newTestServer(t *testing.T, opts ...ConfigOpt) (Server, rest.Rest) {
+	server := NewServer(append([]ConfigOpt{WithToken("token")}, opts...)...)
+	t.Cleanup(server.Close)
+
+	server.AddGuild(discord.Guild{ID: guildID, Name: "test"})
+	var channel discord.UnmarshalChannel
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"100000000000000002","guild_id":"100000000000000001","type":0,"name":"general"}`), &channel))
+	server.AddChannel(channel.Channel.(discord.GuildChannel))
+	server.AddMember(discord.Member{GuildID: guildID, User: discord.User{ID: userID, Username: "member"}})
+
+	return server, rest.New(rest.NewClient("token", rest.WithURL(server.URL())))
+}
+
+func TestServerMessages(t *testing.T) {
+	_, client := newTestServer(t)
+
+	message, err := client.CreateMessage(channelID, discord.NewMessageCreateBuilder().
+		SetContent("hello").
+		AddFile("test.txt", "", strings.NewReader("test")).
+		Build(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", message.Content)
+	assert.Equal(t, channelID, message.ChannelID)
+	require.Len(t, message.Attachments, 1)
+	assert.Equal(t, "test.txt", message.Attachments[0].Filename)
+
+	_, err = client.CreateMessage(channelID, discord.MessageCreate{Content: "world"})
+	require.NoError(t, err)
+
+	messages, err := client.GetMessages(channelID, 0, 0, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "world", messages[0].Content)
+	assert.Equal(t, "hello", messages[1].Content)
+
+	_, err = client.CreateMessage(channelID, discord.MessageCreate{})
+	var restErr rest.Error
+	require.True(t, errors.As(err, &restErr))
+	assert.Equal(t, rest.JSONErrorCodeCannotSendEmptyMessage, restErr.Code)
+
+	_, err = client.GetMessage(channelID, 1)
+	require.True(t, errors.As(err, &restErr))
+	assert.Equal(t, rest.JSONErrorCodeUnknownMessage, restErr.Code)
+}
+
+func TestServerRolesAndMembers(t *testing.T) {
+	_, client := newTestServer(t)
+
+	name := "mod"
+	role, err := client.CreateRole(guildID, discord.RoleCreate{Name: name})
+	require.NoError(t, err)
+	assert.Equal(t, name, role.Name)
+
+	require.NoError(t, client.AddMemberRole(guildID, userID, role.ID))
+	member, err := client.GetMember(guildID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []snowflake.ID{role.ID}, member.RoleIDs)
+
+	guild, err := client.GetGuild(guildID, false)
+	require.NoError(t, err)
+	assert.Len(t, guild.Roles, 2)
+
+	require.NoError(t, client.DeleteRole(guildID, role.ID))
+	member, err = client.GetMember(guildID, userID)
+	require.NoError(t, err)
+	assert.Empty(t, member.RoleIDs)
+}
+
+func TestServerInjectError(t *testing.T) {
+	server, client := newTestServer(t)
+
+	server.InjectError(InjectedError{
+		Endpoint: rest.GetChannel,
+		Status:   http.StatusForbidden,
+		Code:     rest.JSONErrorCodeMissingAccess,
+		Message:  "Missing Access",
+	})
+
+	_, err := client.GetChannel(channelID)
+	var restErr rest.Error
+	require.True(t, errors.As(err, &restErr))
+	assert.Equal(t, rest.JSONErrorCodeMissingAccess, restErr.Code)
+
+	// the error is only injected once
+	channel, err := client.GetChannel(channelID)
+	require.NoError(t, err)
+	assert.Equal(t, channelID, channel.ID())
+}
+
+func TestServerRateLimit(t *testing.T) {
+	server, client := newTestServer(t, WithRateLimit(1, 200*time.Millisecond))
+
+	// the first request teaches the client the bucket, so the client waits on its own afterwards
+	for range 3 {
+		_, err := client.GetChannel(channelID)
+		require.NoError(t, err)
+	}
+
+	// a fresh client runs into the 429 & retries after the bucket reset
+	client = rest.New(rest.NewClient("token", rest.WithURL(server.URL())))
+	_, err := client.GetChannel(channelID)
+	require.NoError(t, err)
+
+	var limited int
+	for _, rq := range server.Requests() {
+		if rq.Status == http.StatusTooManyRequests {
+			limited++
+		}
+	}
+	assert.Positive(t, limited)
+}
@@ -0,0 +1,118 @@
+package resttest
+
+import (
+	"strings"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+type handleFunc func(s *serverImpl, rq *request) (int, any)
+
+type route struct {
+	endpoint *rest.Endpoint
+	segments []string
+	handle   handleFunc
+}
+
+func newRoute(endpoint *rest.Endpoint, handle handleFunc) route {
+	return route{
+		endpoint: endpoint,
+		segments: strings.Split(strings.Trim(endpoint.Route, "/"), "/"),
+		handle:   handle,
+	}
+}
+
+var routes = []route{
+	newRoute(rest.GetCurrentUser, (*serverImpl).getCurrentUser),
+	newRoute(rest.GetUser, (*serverImpl).getUser),
+
+	newRoute(rest.GetGuild, (*serverImpl).getGuild),
+	newRoute(rest.UpdateGuild, (*serverImpl).updateGuild),
+	newRoute(rest.DeleteGuild, (*serverImpl).deleteGuild),
+	newRoute(rest.GetGuildChannels, (*serverImpl).getGuildChannels),
+	newRoute(rest.CreateGuildChannel, (*serverImpl).createGuildChannel),
+
+	newRoute(rest.GetChannel, (*serverImpl).getChannel),
+	newRoute(rest.UpdateChannel, (*serverImpl).updateChannel),
+	newRoute(rest.DeleteChannel, (*serverImpl).deleteChannel),
+
+	newRoute(rest.GetMessages, (*serverImpl).getMessages),
+	newRoute(rest.GetMessage, (*serverImpl).getMessage),
+	newRoute(rest.CreateMessage, (*serverImpl).createMessage),
+	newRoute(rest.UpdateMessage, (*serverImpl).updateMessage),
+	newRoute(rest.DeleteMessage, (*serverImpl).deleteMessage),
+	newRoute(rest.BulkDeleteMessages, (*serverImpl).bulkDeleteMessages),
+
+	newRoute(rest.GetRoles, (*serverImpl).getRoles),
+	newRoute(rest.GetRole, (*serverImpl).getRole),
+	newRoute(rest.CreateRole, (*serverImpl).createRole),
+	newRoute(rest.UpdateRole, (*serverImpl).updateRole),
+	newRoute(rest.DeleteRole, (*serverImpl).deleteRole),
+
+	newRoute(rest.GetMember, (*serverImpl).getMember),
+	newRoute(rest.GetMembers, (*serverImpl).getMembers),
+	newRoute(rest.AddMember, (*serverImpl).addMember),
+	newRoute(rest.UpdateMember, (*serverImpl).updateMember),
+	newRoute(rest.RemoveMember, (*serverImpl).removeMember),
+	newRoute(rest.AddMemberRole, (*serverImpl).addMemberRole),
+	newRoute(rest.RemoveMemberRole, (*serverImpl).removeMemberRole),
+}
+
+// matchRoute returns the route matching the given method & path and its url parameters.
+// If multiple routes match, the one with the most literal segments wins, so /users/@me is preferred over /users/{user.id}.
+// methodAllowed reports whether a route with another method matches the path.
+func matchRoute(method string, path string) (match *route, params map[string]string, methodAllowed bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	bestLiterals := -1
+	for i := range routes {
+		rt := &routes[i]
+		literals, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+		if rt.endpoint.Method != method {
+			methodAllowed = true
+			continue
+		}
+		if literals > bestLiterals {
+			match = rt
+			bestLiterals = literals
+		}
+	}
+	if match == nil {
+		return nil, nil, methodAllowed
+	}
+
+	params = map[string]string{}
+	for i, segment := range match.segments {
+		if isParam(segment) {
+			params[strings.Trim(segment, "{}")] = segments[i]
+		}
+	}
+	return match, params, false
+}
+
+func (r *route) match(segments []string) (int, bool) {
+	if len(segments) != len(r.segments) {
+		return 0, false
+	}
+	var literals int
+	for i, segment := range r.segments {
+		if isParam(segment) {
+			if segments[i] == "" {
+				return 0, false
+			}
+			continue
+		}
+		if segment != segments[i] {
+			return 0, false
+		}
+		literals++
+	}
+	return literals, true
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
@@ -0,0 +1,144 @@
+package resttest
+
+import (
+	"slices"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// object is a raw Discord entity. Entities are kept raw, so the server returns all fields it received.
+type object map[string]json.RawMessage
+
+func toObject(v any) object {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic("resttest: failed to marshal entity: " + err.Error())
+	}
+	var o object
+	if err = json.Unmarshal(data, &o); err != nil {
+		panic("resttest: failed to unmarshal entity: " + err.Error())
+	}
+	return o
+}
+
+func (o object) set(key string, value any) {
+	data, _ := json.Marshal(value)
+	o[key] = data
+}
+
+func (o object) get(key string, v any) bool {
+	data, ok := o[key]
+	if !ok || string(data) == "null" {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+func (o object) id(key string) snowflake.ID {
+	var id snowflake.ID
+	o.get(key, &id)
+	return id
+}
+
+// merge applies the fields of the given PATCH body to the object
+func (o object) merge(data []byte) error {
+	var patch object
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return err
+	}
+	for key, value := range patch {
+		o[key] = value
+	}
+	return nil
+}
+
+// sortedIDs returns the keys of the given map in ascending order
+func sortedIDs[T any](m map[snowflake.ID]T) []snowflake.ID {
+	ids := make([]snowflake.ID, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (s *serverImpl) AddUser(user discord.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID] = toObject(user)
+}
+
+func (s *serverImpl) AddGuild(guild discord.Guild) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.guilds[guild.ID] = toObject(guild)
+	if _, ok := s.roles[guild.ID]; !ok {
+		// every guild has an @everyone role with the id of the guild
+		s.roles[guild.ID] = map[snowflake.ID]object{
+			guild.ID: toObject(discord.Role{
+				ID:          guild.ID,
+				Name:        "@everyone",
+				Permissions: discord.PermissionsNone,
+			}),
+		}
+	}
+	if _, ok := s.members[guild.ID]; !ok {
+		s.members[guild.ID] = map[snowflake.ID]object{}
+	}
+}
+
+func (s *serverImpl) AddChannel(channel discord.GuildChannel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.channels[channel.ID()] = toObject(channel)
+	if _, ok := s.messages[channel.ID()]; !ok {
+		s.messages[channel.ID()] = map[snowflake.ID]object{}
+	}
+}
+
+func (s *serverImpl) AddRole(role discord.Role) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	roles, ok := s.roles[role.GuildID]
+	if !ok {
+		roles = map[snowflake.ID]object{}
+		s.roles[role.GuildID] = roles
+	}
+	o := toObject(role)
+	delete(o, "guild_id")
+	roles[role.ID] = o
+}
+
+func (s *serverImpl) AddMember(member discord.Member) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.users[member.User.ID]; !ok {
+		s.users[member.User.ID] = toObject(member.User)
+	}
+	members, ok := s.members[member.GuildID]
+	if !ok {
+		members = map[snowflake.ID]object{}
+		s.members[member.GuildID] = members
+	}
+	o := toObject(member)
+	delete(o, "guild_id")
+	members[member.User.ID] = o
+}
+
+func (s *serverImpl) AddMessage(message discord.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages, ok := s.messages[message.ChannelID]
+	if !ok {
+		messages = map[snowflake.ID]object{}
+		s.messages[message.ChannelID] = messages
+	}
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
+	messages[message.ID] = toObject(message)
+}
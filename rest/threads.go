@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"iter"
 	"time"
 
 	"github.com/disgoorg/snowflake/v2"
@@ -26,10 +27,18 @@ type Threads interface {
 	GetThreadMember(threadID snowflake.ID, userID snowflake.ID, withMember bool, opts ...RequestOpt) (threadMember *discord.ThreadMember, err error)
 	GetThreadMembers(threadID snowflake.ID, opts ...RequestOpt) (threadMembers []discord.ThreadMember, err error)
 	GetThreadMembersPage(threadID snowflake.ID, startID snowflake.ID, limit int, opts ...RequestOpt) ThreadMemberPage
+	GetThreadMembersIter(threadID snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.ThreadMember, error]
 
 	GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
 	GetJoinedPrivateArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error)
+
+	// GetPublicArchivedThreadsIter returns an iterator over the public archived threads, most recently archived first.
+	GetPublicArchivedThreadsIter(channelID snowflake.ID, params ArchivedThreadsIterParams, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error]
+	// GetPrivateArchivedThreadsIter returns an iterator over the private archived threads, most recently archived first.
+	GetPrivateArchivedThreadsIter(channelID snowflake.ID, params ArchivedThreadsIterParams, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error]
+	// GetJoinedPrivateArchivedThreadsIter returns an iterator over the joined private archived threads. Only DirectionBefore is supported.
+	GetJoinedPrivateArchivedThreadsIter(channelID snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error]
 }
 
 type threadImpl struct {
@@ -97,6 +106,18 @@ func (s *threadImpl) GetThreadMembersPage(threadID snowflake.ID, startID snowfla
 	}
 }
 
+func (s *threadImpl) GetThreadMembersIter(threadID snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.ThreadMember, error] {
+	return iterate(params, 100, []Direction{DirectionAfter}, func(threadMember discord.ThreadMember) snowflake.ID {
+		return threadMember.UserID
+	}, func(_ snowflake.ID, after snowflake.ID, limit int) ([]discord.ThreadMember, error) {
+		return s.getThreadMembers(threadID, discord.QueryValues{
+			"with_member": true,
+			"after":       after,
+			"limit":       limit,
+		}, opts...)
+	})
+}
+
 func (s *threadImpl) GetPublicArchivedThreads(channelID snowflake.ID, before time.Time, limit int, opts ...RequestOpt) (threads *discord.GetThreads, err error) {
 	queryValues := discord.QueryValues{}
 	if !before.IsZero() {
@@ -133,6 +154,97 @@ func (s *threadImpl) GetJoinedPrivateArchivedThreads(channelID snowflake.ID, bef
 	return
 }
 
+func (s *threadImpl) GetPublicArchivedThreadsIter(channelID snowflake.ID, params ArchivedThreadsIterParams, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error] {
+	return iterateArchivedThreads(params, func(before time.Time, limit int) (*discord.GetThreads, error) {
+		return s.GetPublicArchivedThreads(channelID, before, limit, opts...)
+	})
+}
+
+func (s *threadImpl) GetPrivateArchivedThreadsIter(channelID snowflake.ID, params ArchivedThreadsIterParams, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error] {
+	return iterateArchivedThreads(params, func(before time.Time, limit int) (*discord.GetThreads, error) {
+		return s.GetPrivateArchivedThreads(channelID, before, limit, opts...)
+	})
+}
+
+func (s *threadImpl) GetJoinedPrivateArchivedThreadsIter(channelID snowflake.ID, params IterParams, opts ...RequestOpt) iter.Seq2[discord.GuildThread, error] {
+	return iterate(params, 100, []Direction{DirectionBefore}, func(thread discord.GuildThread) snowflake.ID {
+		return thread.ID()
+	}, func(before snowflake.ID, _ snowflake.ID, limit int) ([]discord.GuildThread, error) {
+		// unlike the other archived thread endpoints, this one pages by thread id
+		queryValues := discord.QueryValues{
+			"limit": limit,
+		}
+		if before != 0 {
+			queryValues["before"] = before
+		}
+		var threads *discord.GetThreads
+		if err := s.client.Do(GetJoinedPrivateArchivedThreads.Compile(queryValues, channelID), nil, &threads, opts...); err != nil {
+			return nil, err
+		}
+		return threads.Threads, nil
+	})
+}
+
+// iterateArchivedThreads returns an iterator which pages through archived threads by their archive timestamp.
+// The before cursor of the endpoints is exclusive & only has a precision of seconds, so each page is requested before the end of the second
+// of the last yielded thread, with room for the threads already yielded in that second, which are skipped.
+// Only if more than a full page of threads was archived in the same second, the rest of them is skipped.
+func iterateArchivedThreads(params ArchivedThreadsIterParams, getThreads func(before time.Time, limit int) (*discord.GetThreads, error)) iter.Seq2[discord.GuildThread, error] {
+	return func(yield func(discord.GuildThread, error) bool) {
+		const maxPageSize = 100
+		pageSize := maxPageSize
+		if params.PageSize > 0 && params.PageSize < pageSize {
+			pageSize = params.PageSize
+		}
+
+		before := params.Before
+		// last is the second the last thread was archived in & seen holds the threads yielded in it
+		var last time.Time
+		seen := map[snowflake.ID]struct{}{}
+		yielded := 0
+		for {
+			threads, err := getThreads(before, min(pageSize+len(seen), maxPageSize))
+			if err != nil {
+				yield(discord.GuildThread{}, err)
+				return
+			}
+
+			progressed := false
+			for _, thread := range threads.Threads {
+				if _, ok := seen[thread.ID()]; ok {
+					continue
+				}
+				if !yield(thread, nil) {
+					return
+				}
+				yielded++
+				if params.Limit > 0 && yielded >= params.Limit {
+					return
+				}
+				progressed = true
+
+				timestamp := thread.ThreadMetadata.ArchiveTimestamp.Truncate(time.Second)
+				if !timestamp.Equal(last) {
+					clear(seen)
+					last = timestamp
+				}
+				seen[thread.ID()] = struct{}{}
+			}
+
+			if !threads.HasMore || len(threads.Threads) == 0 {
+				return
+			}
+			if !progressed {
+				// a full page was archived in the same second as already yielded threads, skip the rest of that second to make progress
+				before = last
+				clear(seen)
+				continue
+			}
+			before = last.Add(time.Second)
+		}
+	}
+}
+
 func (s *threadImpl) getThreadMembers(threadID snowflake.ID, queryValues discord.QueryValues, opts ...RequestOpt) (threadMembers []discord.ThreadMember, err error) {
 	err = s.client.Do(GetThreadMembers.Compile(queryValues, threadID), nil, &threadMembers, opts...)
 	return
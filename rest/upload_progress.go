@@ -0,0 +1,36 @@
+package rest
+
+import (
+	"io"
+)
+
+// UploadProgressFunc is called with the number of uploaded bytes of the request body & the total size, which is -1 for streamed bodies.
+// It is called again from 0 if the request is retried.
+type UploadProgressFunc func(uploaded int64, total int64)
+
+func newProgressReader(body io.ReadCloser, total int64, progress UploadProgressFunc) io.ReadCloser {
+	if total <= 0 {
+		total = -1
+	}
+	return &progressReader{
+		ReadCloser: body,
+		total:      total,
+		progress:   progress,
+	}
+}
+
+type progressReader struct {
+	io.ReadCloser
+	uploaded int64
+	total    int64
+	progress UploadProgressFunc
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.uploaded += int64(n)
+		r.progress(r.uploaded, r.total)
+	}
+	return n, err
+}
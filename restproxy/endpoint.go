@@ -0,0 +1,150 @@
+package restproxy
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+// idParams maps the path segment before an id to the name of the id param.
+// The names match the ones of the rest package, so rest.MajorParameters are detected.
+var idParams = map[string]string{
+	"applications":      "application.id",
+	"answers":           "answer.id",
+	"bans":              "user.id",
+	"channels":          "channel.id",
+	"commands":          "command.id",
+	"emojis":            "emoji.id",
+	"entitlements":      "entitlement.id",
+	"guilds":            "guild.id",
+	"integrations":      "integration.id",
+	"interactions":      "interaction.id",
+	"members":           "user.id",
+	"messages":          "message.id",
+	"permissions":       "overwrite.id",
+	"pins":              "message.id",
+	"polls":             "message.id",
+	"recipients":        "user.id",
+	"roles":             "role.id",
+	"rules":             "auto_moderation_rule.id",
+	"scheduled-events":  "guild_scheduled_event.id",
+	"skus":              "sku.id",
+	"soundboard-sounds": "sound.id",
+	"stage-instances":   "channel.id",
+	"stickers":          "sticker.id",
+	"thread-members":    "user.id",
+	"users":             "user.id",
+	"voice-states":      "user.id",
+	"webhooks":          "webhook.id",
+}
+
+// stringParams maps the path segment before a non numeric param to the name of the param.
+var stringParams = map[string]string{
+	"invites":   "code",
+	"reactions": "emoji",
+	"templates": "template.code",
+}
+
+// interactionTokenPrefix is the base64 encoded "interaction:" prefix of interaction tokens.
+// It tells interaction followups apart from webhook executions, which share the same route.
+const interactionTokenPrefix = "aW50ZXJhY3Rpb246"
+
+// maxEndpoints limits how many routes are interned, as clients can send requests to arbitrary paths.
+// Routes seen after the limit is reached share the fallbackEndpoint.
+const maxEndpoints = 1024
+
+// fallbackEndpoint is used for the rate limiting of routes which didn't fit into the endpoints anymore.
+var fallbackEndpoint = rest.NewNoBotAuthEndpoint("*", "/*")
+
+// endpoints interns the rest.Endpoint(s) of all seen routes, as the rest.RateLimiter caches route hashes by *rest.Endpoint.
+type endpoints struct {
+	mu        sync.Mutex
+	endpoints map[string]*rest.Endpoint
+}
+
+// compile maps the method & escaped path to a rest.CompiledEndpoint.
+// Ids & tokens in the path are replaced with params named like the ones of the rest package.
+func (e *endpoints) compile(method string, path string) *rest.CompiledEndpoint {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	routeSegments := make([]string, len(segments))
+	paramNames := make([]string, len(segments))
+	var params []any
+
+	for i, segment := range segments {
+		var prev, prevParam string
+		if i > 0 {
+			prev = segments[i-1]
+			prevParam = paramNames[i-1]
+		}
+
+		var param string
+		switch {
+		case prevParam == "webhook.id":
+			param = "webhook.token"
+			if strings.HasPrefix(segment, interactionTokenPrefix) {
+				paramNames[i-1] = "application.id"
+				routeSegments[i-1] = "{application.id}"
+				param = "interaction.token"
+			}
+		case prevParam == "interaction.id":
+			param = "interaction.token"
+		case prevParam == "emoji" && isSnowflake(segment):
+			param = "user.id"
+		case stringParams[prev] != "" && prevParam == "" && !strings.HasPrefix(segment, "@"):
+			param = stringParams[prev]
+		case isSnowflake(segment) && prevParam == "":
+			param = idParams[prev]
+			if param == "" {
+				param = "id"
+			}
+		}
+
+		if param == "" {
+			routeSegments[i] = segment
+			continue
+		}
+		paramNames[i] = param
+		routeSegments[i] = "{" + param + "}"
+		params = append(params, segment)
+	}
+
+	route := "/" + strings.Join(routeSegments, "/")
+	endpoint, ok := e.get(method, route)
+	if !ok {
+		compiled := rest.NewNoBotAuthEndpoint(method, route).Compile(nil, params...)
+		compiled.Endpoint = fallbackEndpoint
+		return compiled
+	}
+	return endpoint.Compile(nil, params...)
+}
+
+// get returns the interned rest.Endpoint of the route or false if maxEndpoints is reached.
+func (e *endpoints) get(method string, route string) (*rest.Endpoint, bool) {
+	key := method + " " + route
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	endpoint, ok := e.endpoints[key]
+	if !ok {
+		if len(e.endpoints) >= maxEndpoints {
+			return nil, false
+		}
+		// the proxy forwards the Authorization header of the request, so no bot auth is added by the endpoint
+		endpoint = rest.NewNoBotAuthEndpoint(method, route)
+		e.endpoints[key] = endpoint
+	}
+	return endpoint, true
+}
+
+func isSnowflake(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, c := range segment {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+// Package restproxy implements a http.Handler which forwards Discord API requests through a single rest.RateLimiter.
+// This allows multiple services of the same bot to share one rate limit budget.
+//
+// The Proxy expects paths relative to the api version, so mount it with http.StripPrefix and point the rest clients at it:
+//
+//	proxy := restproxy.New()
+//	http.Handle("/api/v10/", http.StripPrefix("/api/v10", proxy))
+//
+//	client := rest.NewClient(token, rest.WithURL("http://proxy:8080/api/v10"), rest.WithRateLimiter(rest.NewNoopRateLimiter()))
+//
+// The rate limits of Discord are per bot, so a Proxy should only be used by a single bot.
+//
+// With WithToken the Proxy adds the bot token to requests without an Authorization header, so the services don't need to know it.
+// Anyone who can reach the Proxy and passes its TokenAuthorizer can then use the API as the bot, so never expose such a Proxy publicly.
+package restproxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/disgoorg/json"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+// hopHeaders are removed when forwarding requests & responses (https://www.rfc-editor.org/rfc/rfc9110#section-7.6.1)
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// Proxy is a http.Handler which forwards requests to Discord through a single rest.RateLimiter.
+type Proxy interface {
+	http.Handler
+
+	// RateLimiter returns the rest.RateLimiter shared by all requests.
+	RateLimiter() rest.RateLimiter
+
+	// Close waits for all pending requests to finish. You can use a cancelling context to abort the waiting.
+	Close(ctx context.Context)
+}
+
+// New returns a new Proxy with the given ConfigOpt(s).
+func New(opts ...ConfigOpt) Proxy {
+	config := DefaultConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "restproxy"))
+
+	return &proxyImpl{
+		config: *config,
+		endpoints: endpoints{
+			endpoints: map[string]*rest.Endpoint{},
+		},
+	}
+}
+
+type proxyImpl struct {
+	config    Config
+	endpoints endpoints
+}
+
+func (p *proxyImpl) RateLimiter() rest.RateLimiter {
+	return p.config.RateLimiter
+}
+
+func (p *proxyImpl) Close(ctx context.Context) {
+	p.config.RateLimiter.Close(ctx)
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
+func (p *proxyImpl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	endpoint := p.endpoints.compile(r.Method, r.URL.EscapedPath())
+	url := p.config.URL + endpoint.URL
+	if r.URL.RawQuery != "" {
+		url += "?" + r.URL.RawQuery
+	}
+
+	body := r.Body
+	if r.ContentLength == 0 {
+		body = nil
+	}
+	rq, err := http.NewRequestWithContext(r.Context(), r.Method, url, body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	rq.ContentLength = r.ContentLength
+	rq.Header = r.Header.Clone()
+	for _, header := range hopHeaders {
+		rq.Header.Del(header)
+	}
+	// requests which are not authorized are forwarded as is, as webhook & interaction endpoints don't need a token
+	if p.config.Token != "" && rq.Header.Get("Authorization") == "" && p.config.TokenAuthorizer != nil && p.config.TokenAuthorizer(r) {
+		rq.Header.Set("Authorization", "Bot "+p.config.Token)
+	}
+
+	if err = p.config.RateLimiter.WaitBucket(r.Context(), endpoint); err != nil {
+		p.config.Logger.Debug("failed to wait for rate limit bucket", slog.Any("err", err), slog.String("route", endpoint.Endpoint.Route))
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("error locking bucket: %w", err))
+		return
+	}
+
+	rs, err := p.config.HTTPClient.Do(rq)
+	if err != nil {
+		_ = p.config.RateLimiter.UnlockBucket(endpoint, nil)
+		p.config.Logger.Error("failed to forward request", slog.Any("err", err), slog.String("route", endpoint.Endpoint.Route))
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	defer rs.Body.Close()
+
+	if err = p.config.RateLimiter.UnlockBucket(endpoint, rs); err != nil {
+		p.config.Logger.Error("failed to unlock rate limit bucket", slog.Any("err", err), slog.String("route", endpoint.Endpoint.Route))
+	}
+
+	header := w.Header()
+	for key, values := range rs.Header {
+		header[key] = values
+	}
+	for _, h := range hopHeaders {
+		header.Del(h)
+	}
+	w.WriteHeader(rs.StatusCode)
+
+	if _, err = io.Copy(flushWriter{w: w}, rs.Body); err != nil {
+		p.config.Logger.Debug("failed to stream response", slog.Any("err", err), slog.String("route", endpoint.Endpoint.Route))
+	}
+}
+
+// flushWriter flushes after each write, so the response is streamed to the client as it arrives.
+type flushWriter struct {
+	w http.ResponseWriter
+}
+
+func (f flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return n, err
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Message string `json:"message"`
+	}{
+		Message: err.Error(),
+	})
+}
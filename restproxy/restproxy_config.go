@@ -0,0 +1,92 @@
+package restproxy
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:     slog.Default(),
+		HTTPClient: &http.Client{},
+		URL:        fmt.Sprintf("%sv%d", rest.API, rest.Version),
+	}
+}
+
+// Config lets you configure your Proxy instance.
+type Config struct {
+	Logger                *slog.Logger
+	HTTPClient            *http.Client
+	RateLimiter           rest.RateLimiter
+	RateLimiterConfigOpts []rest.RateLimiterConfigOpt
+	URL                   string
+	Token                 string
+	// TokenAuthorizer decides which requests without an Authorization header are sent with the Token.
+	TokenAuthorizer TokenAuthorizer
+}
+
+// TokenAuthorizer reports whether the request may be sent with the bot token of the Proxy.
+// It is only called for requests without an Authorization header.
+type TokenAuthorizer func(r *http.Request) bool
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Proxy.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.RateLimiter == nil {
+		c.RateLimiter = rest.NewRateLimiter(c.RateLimiterConfigOpts...)
+	}
+}
+
+// WithLogger sets the Logger of the Config.
+func WithLogger(logger *slog.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithHTTPClient sets the http.Client used to send requests to Discord.
+func WithHTTPClient(httpClient *http.Client) ConfigOpt {
+	return func(config *Config) {
+		config.HTTPClient = httpClient
+	}
+}
+
+// WithRateLimiter sets the rest.RateLimiter shared by all requests going through the Proxy.
+func WithRateLimiter(rateLimiter rest.RateLimiter) ConfigOpt {
+	return func(config *Config) {
+		config.RateLimiter = rateLimiter
+	}
+}
+
+// WithRateLimiterConfigOpts applies rest.RateLimiterConfigOpt(s) to the default rest.RateLimiter.
+func WithRateLimiterConfigOpts(opts ...rest.RateLimiterConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.RateLimiterConfigOpts = append(config.RateLimiterConfigOpts, opts...)
+	}
+}
+
+// WithURL sets the Discord api url requests are forwarded to.
+func WithURL(url string) ConfigOpt {
+	return func(config *Config) {
+		config.URL = url
+	}
+}
+
+// WithToken sets the bot token which is added to requests without an Authorization header if the TokenAuthorizer allows it.
+// This allows keeping the token out of the services using the Proxy. Requests which are not authorized are forwarded without the token.
+// Everyone who passes the TokenAuthorizer can use the API as the bot, so make sure it authenticates the services, for example with a shared secret header.
+func WithToken(token string, authorizer TokenAuthorizer) ConfigOpt {
+	return func(config *Config) {
+		config.Token = token
+		config.TokenAuthorizer = authorizer
+	}
+}
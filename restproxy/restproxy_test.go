@@ -0,0 +1,105 @@
+package restproxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/rest"
+)
+
+func TestEndpointsCompile(t *testing.T) {
+	e := endpoints{endpoints: map[string]*rest.Endpoint{}}
+
+	tests := []struct {
+		method      string
+		path        string
+		route       string
+		majorParams string
+	}{
+		{http.MethodGet, "/channels/123/messages", "/channels/{channel.id}/messages", "channel.id=123"},
+		{http.MethodPut, "/guilds/1/members/2/roles/3", "/guilds/{guild.id}/members/{user.id}/roles/{role.id}", "guild.id=1"},
+		{http.MethodPut, "/channels/1/messages/2/reactions/%F0%9F%91%8D/@me", "/channels/{channel.id}/messages/{message.id}/reactions/{emoji}/@me", "channel.id=1"},
+		{http.MethodPost, "/webhooks/1/token", "/webhooks/{webhook.id}/{webhook.token}", "webhook.id=1"},
+		{http.MethodPost, "/webhooks/1/aW50ZXJhY3Rpb246abc", "/webhooks/{application.id}/{interaction.token}", "interaction.token=aW50ZXJhY3Rpb246abc"},
+		{http.MethodPost, "/interactions/1/token/callback", "/interactions/{interaction.id}/{interaction.token}/callback", "interaction.token=token"},
+		{http.MethodGet, "/users/@me/guilds", "/users/@me/guilds", ""},
+		{http.MethodGet, "/invites/abc", "/invites/{code}", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			endpoint := e.compile(tt.method, tt.path)
+			assert.Equal(t, tt.route, endpoint.Endpoint.Route)
+			assert.Equal(t, tt.path, endpoint.URL)
+			assert.Equal(t, tt.majorParams, endpoint.MajorParams)
+		})
+	}
+
+	assert.Same(t, e.compile(http.MethodGet, "/channels/1/messages").Endpoint, e.compile(http.MethodGet, "/channels/2/messages").Endpoint)
+}
+
+func TestEndpointsCompile_Limit(t *testing.T) {
+	e := endpoints{endpoints: map[string]*rest.Endpoint{}}
+	for i := 0; i < maxEndpoints+10; i++ {
+		e.compile(http.MethodGet, "/junk/x"+strconv.Itoa(i))
+	}
+	assert.Len(t, e.endpoints, maxEndpoints)
+
+	endpoint := e.compile(http.MethodGet, "/guilds/1/junk")
+	assert.Same(t, fallbackEndpoint, endpoint.Endpoint)
+	assert.Equal(t, "/guilds/1/junk", endpoint.URL)
+	assert.Equal(t, "guild.id=1", endpoint.MajorParams)
+
+	// routes interned before the limit was reached keep their endpoint
+	assert.Equal(t, "/junk/x1", e.compile(http.MethodGet, "/junk/x1").Endpoint.Route)
+}
+
+func TestProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/channels/1/messages", r.URL.Path)
+		assert.Equal(t, "limit=5", r.URL.RawQuery)
+		if r.Header.Get("Authorization") != "Bot token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-RateLimit-Bucket", "abc")
+		w.Header().Set("X-RateLimit-Limit", "5")
+		w.Header().Set("X-RateLimit-Remaining", "4")
+		w.Header().Set("X-RateLimit-Reset-After", "1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}))
+	defer upstream.Close()
+
+	proxy := New(WithURL(upstream.URL), WithToken("token", func(r *http.Request) bool {
+		return r.Header.Get("X-Proxy-Secret") == "secret"
+	}))
+	server := httptest.NewServer(http.StripPrefix("/api/v10", proxy))
+	defer server.Close()
+
+	// requests which are not authorized don't get the token
+	rs, err := http.Post(server.URL+"/api/v10/channels/1/messages?limit=5", "application/json", strings.NewReader(`{"content":"hi"}`))
+	require.NoError(t, err)
+	_ = rs.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, rs.StatusCode)
+
+	rq, err := http.NewRequest(http.MethodPost, server.URL+"/api/v10/channels/1/messages?limit=5", strings.NewReader(`{"content":"hi"}`))
+	require.NoError(t, err)
+	rq.Header.Set("X-Proxy-Secret", "secret")
+	rs, err = http.DefaultClient.Do(rq)
+	require.NoError(t, err)
+	defer rs.Body.Close()
+
+	body, err := io.ReadAll(rs.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rs.StatusCode)
+	assert.Equal(t, "abc", rs.Header.Get("X-RateLimit-Bucket"))
+	assert.Equal(t, `{"content":"hi"}`, string(body))
+}
@@ -0,0 +1,48 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/disgoorg/disgo/internal/insecurerandstr"
+)
+
+// IdentifyInterval is the time Discord requires between two identifies in the same max_concurrency bucket.
+const IdentifyInterval = 5 * time.Second
+
+// ErrLeaseNotHeld is returned when an owner tries to release a lease it does not hold.
+var ErrLeaseNotHeld = errors.New("lease not held")
+
+// Coordinator coordinates shards between multiple processes running ShardManager(s) of the same bot.
+// It hands out time limited shard ownership leases, so a shard is only connected by one process at a time,
+// and it serializes identifies in each max_concurrency bucket across all processes.
+// Leases expire after their ttl, so shards of a crashed process can be picked up by other processes.
+type Coordinator interface {
+	// AcquireShard tries to acquire or renew the lease of the given shard for the owner.
+	// It returns false if the shard is leased by another owner.
+	AcquireShard(ctx context.Context, shardID int, owner string, ttl time.Duration) (bool, error)
+
+	// ReleaseShard releases the lease of the given shard held by the owner.
+	ReleaseShard(ctx context.Context, shardID int, owner string) error
+
+	// LockIdentifyBucket tries to lock the given max_concurrency bucket for the owner.
+	// If the bucket is locked by another owner or was used within the last IdentifyInterval, it returns how long to wait before trying again.
+	// A returned duration of 0 means the bucket is now locked by the owner. The lock expires after the ttl.
+	LockIdentifyBucket(ctx context.Context, bucket int, owner string, ttl time.Duration) (time.Duration, error)
+
+	// UnlockIdentifyBucket unlocks the given max_concurrency bucket held by the owner.
+	// The bucket can be locked again after IdentifyInterval.
+	UnlockIdentifyBucket(ctx context.Context, bucket int, owner string) error
+}
+
+// DefaultCoordinatorOwner returns an owner name which is unique for this process.
+func DefaultCoordinatorOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), insecurerandstr.RandStr(8))
+}
@@ -0,0 +1,200 @@
+package sharding
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/json"
+)
+
+var _ Coordinator = (*httpCoordinator)(nil)
+
+type coordinatorRequest struct {
+	Owner string `json:"owner"`
+	TTL   int64  `json:"ttl_ms,omitempty"`
+}
+
+type coordinatorResponse struct {
+	Acquired   bool   `json:"acquired,omitempty"`
+	RetryAfter int64  `json:"retry_after_ms,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// NewCoordinatorHandler returns a http.Handler which exposes the given Coordinator to other processes.
+// Use NewHTTPCoordinator to connect to it. If secret is not empty, requests need to send it in the Authorization header.
+//
+// The following routes are served:
+//
+//	POST /shards/{shard_id}/acquire
+//	POST /shards/{shard_id}/release
+//	POST /buckets/{bucket}/lock
+//	POST /buckets/{bucket}/unlock
+func NewCoordinatorHandler(coordinator Coordinator, secret string) http.Handler {
+	return &coordinatorHandler{
+		coordinator: coordinator,
+		secret:      secret,
+	}
+}
+
+type coordinatorHandler struct {
+	coordinator Coordinator
+	secret      string
+}
+
+func (h *coordinatorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.secret != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(h.secret)) != 1 {
+		writeCoordinatorResponse(w, http.StatusUnauthorized, coordinatorResponse{Error: "unauthorized"})
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeCoordinatorResponse(w, http.StatusMethodNotAllowed, coordinatorResponse{Error: "method not allowed"})
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 3 {
+		writeCoordinatorResponse(w, http.StatusNotFound, coordinatorResponse{Error: "not found"})
+		return
+	}
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		writeCoordinatorResponse(w, http.StatusBadRequest, coordinatorResponse{Error: "invalid id"})
+		return
+	}
+
+	var rq coordinatorRequest
+	if err = json.NewDecoder(r.Body).Decode(&rq); err != nil || rq.Owner == "" {
+		writeCoordinatorResponse(w, http.StatusBadRequest, coordinatorResponse{Error: "invalid body"})
+		return
+	}
+	ttl := time.Duration(rq.TTL) * time.Millisecond
+
+	var rs coordinatorResponse
+	switch parts[0] + "/" + parts[2] {
+	case "shards/acquire":
+		rs.Acquired, err = h.coordinator.AcquireShard(r.Context(), id, rq.Owner, ttl)
+	case "shards/release":
+		err = h.coordinator.ReleaseShard(r.Context(), id, rq.Owner)
+	case "buckets/lock":
+		var retryAfter time.Duration
+		retryAfter, err = h.coordinator.LockIdentifyBucket(r.Context(), id, rq.Owner, ttl)
+		rs.RetryAfter = retryAfter.Milliseconds()
+		// don't round a pending wait down to 0, as that would mean the bucket is locked
+		if retryAfter > 0 && rs.RetryAfter == 0 {
+			rs.RetryAfter = 1
+		}
+	case "buckets/unlock":
+		err = h.coordinator.UnlockIdentifyBucket(r.Context(), id, rq.Owner)
+	default:
+		writeCoordinatorResponse(w, http.StatusNotFound, coordinatorResponse{Error: "not found"})
+		return
+	}
+
+	if errors.Is(err, ErrLeaseNotHeld) {
+		writeCoordinatorResponse(w, http.StatusConflict, coordinatorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		writeCoordinatorResponse(w, http.StatusInternalServerError, coordinatorResponse{Error: err.Error()})
+		return
+	}
+	writeCoordinatorResponse(w, http.StatusOK, rs)
+}
+
+func writeCoordinatorResponse(w http.ResponseWriter, status int, rs coordinatorResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(rs)
+}
+
+// NewHTTPCoordinator returns a Coordinator which talks to a Coordinator exposed by NewCoordinatorHandler at the given URL.
+// If httpClient is nil, http.DefaultClient is used.
+func NewHTTPCoordinator(url string, secret string, httpClient *http.Client) Coordinator {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &httpCoordinator{
+		url:        strings.TrimSuffix(url, "/"),
+		secret:     secret,
+		httpClient: httpClient,
+	}
+}
+
+type httpCoordinator struct {
+	url        string
+	secret     string
+	httpClient *http.Client
+}
+
+func (c *httpCoordinator) do(ctx context.Context, path string, rqBody coordinatorRequest) (*coordinatorResponse, error) {
+	body, err := json.Marshal(rqBody)
+	if err != nil {
+		return nil, err
+	}
+	rq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	rq.Header.Set("Content-Type", "application/json")
+	if c.secret != "" {
+		rq.Header.Set("Authorization", c.secret)
+	}
+
+	rs, err := c.httpClient.Do(rq)
+	if err != nil {
+		return nil, err
+	}
+	defer rs.Body.Close()
+
+	rawBody, err := io.ReadAll(rs.Body)
+	if err != nil {
+		return nil, err
+	}
+	var rsBody coordinatorResponse
+	if err = json.Unmarshal(rawBody, &rsBody); err != nil {
+		return nil, fmt.Errorf("failed to decode coordinator response: %w: %s", err, rawBody)
+	}
+
+	switch rs.StatusCode {
+	case http.StatusOK:
+		return &rsBody, nil
+	case http.StatusConflict:
+		return nil, ErrLeaseNotHeld
+	default:
+		return nil, fmt.Errorf("coordinator responded with %s: %s", rs.Status, rsBody.Error)
+	}
+}
+
+func (c *httpCoordinator) AcquireShard(ctx context.Context, shardID int, owner string, ttl time.Duration) (bool, error) {
+	rs, err := c.do(ctx, fmt.Sprintf("/shards/%d/acquire", shardID), coordinatorRequest{Owner: owner, TTL: ttl.Milliseconds()})
+	if err != nil {
+		return false, err
+	}
+	return rs.Acquired, nil
+}
+
+func (c *httpCoordinator) ReleaseShard(ctx context.Context, shardID int, owner string) error {
+	_, err := c.do(ctx, fmt.Sprintf("/shards/%d/release", shardID), coordinatorRequest{Owner: owner})
+	return err
+}
+
+func (c *httpCoordinator) LockIdentifyBucket(ctx context.Context, bucket int, owner string, ttl time.Duration) (time.Duration, error) {
+	rs, err := c.do(ctx, fmt.Sprintf("/buckets/%d/lock", bucket), coordinatorRequest{Owner: owner, TTL: ttl.Milliseconds()})
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(rs.RetryAfter) * time.Millisecond, nil
+}
+
+func (c *httpCoordinator) UnlockIdentifyBucket(ctx context.Context, bucket int, owner string) error {
+	_, err := c.do(ctx, fmt.Sprintf("/buckets/%d/unlock", bucket), coordinatorRequest{Owner: owner})
+	return err
+}
@@ -0,0 +1,109 @@
+package sharding
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ Coordinator = (*memoryCoordinator)(nil)
+
+// NewMemoryCoordinator returns a Coordinator which keeps all leases in memory.
+// It can be shared by multiple ShardManager(s) in the same process or exposed to other processes with NewCoordinatorHandler.
+func NewMemoryCoordinator() Coordinator {
+	return &memoryCoordinator{
+		shards:  map[int]lease{},
+		buckets: map[int]*identifyBucket{},
+	}
+}
+
+// identifyPollInterval is the maximum time to wait before retrying to lock an identify bucket which is locked by another owner.
+const identifyPollInterval = time.Second
+
+type lease struct {
+	owner   string
+	expires time.Time
+}
+
+type identifyBucket struct {
+	lease
+	reset time.Time
+}
+
+type memoryCoordinator struct {
+	mu      sync.Mutex
+	shards  map[int]lease
+	buckets map[int]*identifyBucket
+}
+
+func (c *memoryCoordinator) AcquireShard(_ context.Context, shardID int, owner string, ttl time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if l, ok := c.shards[shardID]; ok && l.owner != owner && l.expires.After(now) {
+		return false, nil
+	}
+	c.shards[shardID] = lease{
+		owner:   owner,
+		expires: now.Add(ttl),
+	}
+	return true, nil
+}
+
+func (c *memoryCoordinator) ReleaseShard(_ context.Context, shardID int, owner string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	l, ok := c.shards[shardID]
+	if !ok || l.owner != owner {
+		return ErrLeaseNotHeld
+	}
+	delete(c.shards, shardID)
+	return nil
+}
+
+func (c *memoryCoordinator) LockIdentifyBucket(_ context.Context, bucket int, owner string, ttl time.Duration) (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	b, ok := c.buckets[bucket]
+	if !ok {
+		b = &identifyBucket{}
+		c.buckets[bucket] = b
+	}
+	if b.owner != "" && b.expires.After(now) {
+		if b.owner == owner {
+			return 0, nil
+		}
+		// the owner usually unlocks long before the lock expires, so poll again soon
+		return min(b.expires.Sub(now), identifyPollInterval), nil
+	}
+	if b.owner != "" {
+		// the lock expired without an unlock, we don't know whether the identify happened
+		b.owner = ""
+		b.reset = b.expires.Add(IdentifyInterval)
+	}
+	if b.reset.After(now) {
+		return b.reset.Sub(now), nil
+	}
+	b.lease = lease{
+		owner:   owner,
+		expires: now.Add(ttl),
+	}
+	return 0, nil
+}
+
+func (c *memoryCoordinator) UnlockIdentifyBucket(_ context.Context, bucket int, owner string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, ok := c.buckets[bucket]
+	if !ok || b.owner != owner {
+		return ErrLeaseNotHeld
+	}
+	b.lease = lease{}
+	b.reset = time.Now().Add(IdentifyInterval)
+	return nil
+}
@@ -0,0 +1,103 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func TestHTTPCoordinator(t *testing.T) {
+	server := httptest.NewServer(NewCoordinatorHandler(NewMemoryCoordinator(), "secret"))
+	defer server.Close()
+
+	ctx := context.Background()
+	a := NewHTTPCoordinator(server.URL, "secret", server.Client())
+	b := NewHTTPCoordinator(server.URL, "secret", server.Client())
+
+	acquired, err := a.AcquireShard(ctx, 0, "a", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = b.AcquireShard(ctx, 0, "b", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	assert.ErrorIs(t, b.ReleaseShard(ctx, 0, "b"), ErrLeaseNotHeld)
+	assert.NoError(t, a.ReleaseShard(ctx, 0, "a"))
+
+	acquired, err = b.AcquireShard(ctx, 0, "b", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	retryAfter, err := a.LockIdentifyBucket(ctx, 0, "a", time.Minute)
+	assert.NoError(t, err)
+	assert.Zero(t, retryAfter)
+
+	retryAfter, err = b.LockIdentifyBucket(ctx, 0, "b", time.Minute)
+	assert.NoError(t, err)
+	assert.Positive(t, retryAfter)
+
+	assert.NoError(t, a.UnlockIdentifyBucket(ctx, 0, "a"))
+
+	// the bucket has to wait for the identify interval after an unlock
+	retryAfter, err = b.LockIdentifyBucket(ctx, 0, "b", time.Minute)
+	assert.NoError(t, err)
+	assert.Greater(t, retryAfter, IdentifyInterval-time.Second)
+
+	_, err = NewHTTPCoordinator(server.URL, "wrong", server.Client()).AcquireShard(ctx, 1, "c", time.Minute)
+	assert.Error(t, err)
+}
+
+type testCoordinator struct {
+	Coordinator
+	acquired bool
+	err      error
+}
+
+func (c *testCoordinator) AcquireShard(context.Context, int, string, time.Duration) (bool, error) {
+	return c.acquired, c.err
+}
+
+type testGateway struct {
+	gateway.Gateway
+	closed bool
+}
+
+func (g *testGateway) CloseWithCode(context.Context, int, string) {
+	g.closed = true
+}
+
+func TestShardManager_SyncLeases(t *testing.T) {
+	coordinator := &testCoordinator{acquired: true}
+	m := New("token", nil, WithShardIDs(0), WithShardCount(1), WithCoordinator(coordinator), WithShardLeaseTTL(0)).(*shardManagerImpl)
+	assert.Equal(t, time.Second, m.config.ShardLeaseTTL)
+
+	shard := &testGateway{}
+	m.shards[0] = shard
+	m.syncLeases(context.Background())
+	assert.False(t, shard.closed)
+
+	// shards keep running while the coordinator is unavailable until their lease expired
+	coordinator.acquired = false
+	coordinator.err = errors.New("coordinator unavailable")
+	m.syncLeases(context.Background())
+	assert.False(t, shard.closed)
+
+	m.leases[0] = time.Now().Add(-time.Second)
+	m.syncLeases(context.Background())
+	assert.True(t, shard.closed)
+
+	// shards are closed right away when another process holds their lease
+	coordinator.err = nil
+	shard = &testGateway{}
+	m.shards[0] = shard
+	m.leases[0] = time.Now()
+	m.syncLeases(context.Background())
+	assert.True(t, shard.closed)
+}
@@ -2,6 +2,7 @@ package sharding
 
 import (
 	"context"
+	"errors"
 
 	"github.com/disgoorg/snowflake/v2"
 
@@ -11,6 +12,9 @@ import (
 // ShardSplitCount is the default count a shard should be split into when it needs re-sharding.
 const ShardSplitCount = 2
 
+// ErrShardLeased is returned when a shard can't be opened because another process holds its lease.
+var ErrShardLeased = errors.New("shard is leased by another process")
+
 // ShardManager manages multiple gateway.Gateway connections.
 // For more information on sharding see: https://discord.com/developers/docs/topics/gateway#sharding
 type ShardManager interface {
@@ -25,6 +29,13 @@ type ShardManager interface {
 	// CloseShard closes a specific shard.
 	CloseShard(ctx context.Context, shardID int)
 
+	// Reshard connects a complete new set of shards with the given shard count while the current shards keep dispatching events.
+	// Once all new shards received their guilds, the ShardManager atomically switches over to them and closes the old shards.
+	// Events received by both sets during the overlap are only dispatched once.
+	// If the context is done before the new shards are ready, the new shards are closed and the current shards are kept.
+	// It returns ErrReshardUnsupported if the ShardManager only manages some of the shards or uses a Coordinator.
+	Reshard(ctx context.Context, shardCount int) error
+
 	// ShardByGuildID returns the gateway.Gateway for the shard that contains the given guild.
 	ShardByGuildID(guildId snowflake.ID) gateway.Gateway
 
@@ -2,16 +2,21 @@ package sharding
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/disgoorg/disgo/gateway"
 )
 
+// minShardLeaseTTL is the shortest ShardLeaseTTL, the leases are renewed every third of it.
+const minShardLeaseTTL = time.Second
+
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig() *Config {
 	return &Config{
 		Logger:            slog.Default(),
 		GatewayCreateFunc: gateway.New,
 		ShardSplitCount:   ShardSplitCount,
+		ShardLeaseTTL:     30 * time.Second,
 	}
 }
 
@@ -31,6 +36,15 @@ type Config struct {
 	GatewayCreateFunc gateway.CreateFunc
 	// GatewayConfigOpts are the ConfigOpt(s) which are applied to the gateway.Gateway.
 	GatewayConfigOpts []gateway.ConfigOpt
+	// SessionStore is the gateway.SessionStore which is passed to all gateway.Gateway(s) so they can resume after a restart. Defaults to nil (no persistence).
+	SessionStore gateway.SessionStore
+	// Coordinator coordinates shard ownership and identifies with other processes. Defaults to nil (no coordination).
+	// If set, the ShardManager only opens shards it holds a lease for and RateLimiter defaults to NewCoordinatedRateLimiter.
+	Coordinator Coordinator
+	// CoordinatorOwner is the name this ShardManager uses with the Coordinator. Defaults to DefaultCoordinatorOwner().
+	CoordinatorOwner string
+	// ShardLeaseTTL is how long a shard lease is valid before it has to be renewed. Defaults to 30 seconds. TTLs below one second are raised to one second.
+	ShardLeaseTTL time.Duration
 	// RateLimiter is the RateLimiter which is used by the ShardManager. Defaults to NewRateLimiter()
 	RateLimiter RateLimiter
 	// RateLimiterConfigOpts are the RateLimiterConfigOpt(s) which are applied to the RateLimiter.
@@ -45,8 +59,18 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.ShardLeaseTTL < minShardLeaseTTL {
+		c.ShardLeaseTTL = minShardLeaseTTL
+	}
+	if c.Coordinator != nil && c.CoordinatorOwner == "" {
+		c.CoordinatorOwner = DefaultCoordinatorOwner()
+	}
 	if c.RateLimiter == nil {
-		c.RateLimiter = NewRateLimiter(c.RateLimiterConfigOpts...)
+		if c.Coordinator != nil {
+			c.RateLimiter = NewCoordinatedRateLimiter(c.Coordinator, c.CoordinatorOwner, c.RateLimiterConfigOpts...)
+		} else {
+			c.RateLimiter = NewRateLimiter(c.RateLimiterConfigOpts...)
+		}
 	}
 }
 
@@ -105,6 +129,36 @@ func WithGatewayConfigOpts(opts ...gateway.ConfigOpt) ConfigOpt {
 	}
 }
 
+// WithSessionStore sets the gateway.SessionStore used by all gateway.Gateway(s) of the ShardManager.
+func WithSessionStore(sessionStore gateway.SessionStore) ConfigOpt {
+	return func(config *Config) {
+		config.SessionStore = sessionStore
+	}
+}
+
+// WithCoordinator sets the Coordinator used to share shards and identify buckets with other processes.
+func WithCoordinator(coordinator Coordinator) ConfigOpt {
+	return func(config *Config) {
+		config.Coordinator = coordinator
+	}
+}
+
+// WithCoordinatorOwner sets the name the ShardManager uses with the Coordinator.
+// It has to be unique across all processes.
+func WithCoordinatorOwner(owner string) ConfigOpt {
+	return func(config *Config) {
+		config.CoordinatorOwner = owner
+	}
+}
+
+// WithShardLeaseTTL sets how long a shard lease is valid before it has to be renewed.
+// The leases are renewed every third of the TTL. TTLs below one second are raised to one second.
+func WithShardLeaseTTL(ttl time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.ShardLeaseTTL = ttl
+	}
+}
+
 // WithRateLimiter lets you inject your own RateLimiter into the ShardManager.
 func WithRateLimiter(rateLimiter RateLimiter) ConfigOpt {
 	return func(config *Config) {
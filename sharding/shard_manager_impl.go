@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/disgoorg/snowflake/v2"
 	"github.com/gorilla/websocket"
@@ -23,6 +25,8 @@ func New(token string, eventHandlerFunc gateway.EventHandlerFunc, opts ...Config
 
 	return &shardManagerImpl{
 		shards:           map[int]gateway.Gateway{},
+		opening:          map[int]struct{}{},
+		leases:           map[int]time.Time{},
 		token:            token,
 		eventHandlerFunc: eventHandlerFunc,
 		config:           *config,
@@ -31,8 +35,19 @@ func New(token string, eventHandlerFunc gateway.EventHandlerFunc, opts ...Config
 
 type shardManagerImpl struct {
 	shards   map[int]gateway.Gateway
+	opening  map[int]struct{}
 	shardsMu sync.Mutex
 
+	leaseCancel context.CancelFunc
+	// leases holds when the lease of each shard was last acquired or renewed
+	leases   map[int]time.Time
+	leasesMu sync.Mutex
+
+	// generation is incremented with every Reshard. Events of gateways from older generations are dropped.
+	generation atomic.Int64
+	reshard    atomic.Pointer[reshardState]
+	reshardMu  sync.Mutex
+
 	token            string
 	eventHandlerFunc gateway.EventHandlerFunc
 	config           Config
@@ -47,6 +62,13 @@ func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error) {
 	// make sure shard is closed
 	shard.Close(context.TODO())
 
+	// the session of the old shard can't be resumed with the new shard count
+	if m.config.SessionStore != nil {
+		if err := m.config.SessionStore.Delete(context.TODO(), shard.ShardID()); err != nil {
+			m.config.Logger.Error("failed to delete shard session", slog.Any("err", err), slog.Int("shard_id", shard.ShardID()))
+		}
+	}
+
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
 
@@ -71,13 +93,20 @@ func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			if acquired, err := m.acquireShard(context.TODO(), shardID); err != nil {
+				m.config.Logger.Error("failed to acquire shard lease", slog.Any("err", err), slog.Int("shard_id", shardID))
+				return
+			} else if !acquired {
+				m.config.Logger.Debug("shard is leased by another process", slog.Int("shard_id", shardID))
+				return
+			}
 			if err := m.config.RateLimiter.WaitBucket(context.TODO(), shardID); err != nil {
 				m.config.Logger.Error("failed to wait shard bucket", slog.Any("err", err), slog.Int("shard_id", shardID))
 				return
 			}
 			defer m.config.RateLimiter.UnlockBucket(shardID)
 
-			newShard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(newShardCount))...)
+			newShard := m.newGateway(m.generation.Load(), shardID, newShardCount)
 			m.shards[shardID] = newShard
 			if err := newShard.Open(context.TODO()); err != nil {
 				m.config.Logger.Error("failed to re shard", slog.Any("err", err), slog.Int("shard_id", shardID))
@@ -88,6 +117,43 @@ func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error) {
 	m.config.Logger.Debug("re-sharded shard", slog.Int("shard_id", shard.ShardID()), slog.String("new_shard_ids", fmt.Sprint(newShardIDs)), slog.Int("new_shard_count", newShardCount))
 }
 
+// newGateway creates the gateway.Gateway of the given shard for the given generation.
+func (m *shardManagerImpl) newGateway(generation int64, shardID int, shardCount int) gateway.Gateway {
+	opts := make([]gateway.ConfigOpt, 0, len(m.config.GatewayConfigOpts)+3)
+	opts = append(opts, m.config.GatewayConfigOpts...)
+	opts = append(opts, gateway.WithShardID(shardID), gateway.WithShardCount(shardCount))
+	if m.config.SessionStore != nil {
+		opts = append(opts, gateway.WithSessionStore(&generationSessionStore{
+			SessionStore: m.config.SessionStore,
+			manager:      m,
+			generation:   generation,
+		}))
+	}
+	return m.config.GatewayCreateFunc(m.token, m.eventHandler(generation), m.closeHandler, opts...)
+}
+
+// generationSessionStore only stores the sessions of gateways of the current generation.
+// While resharding, the old & new shards with the same id would otherwise overwrite each others session.
+type generationSessionStore struct {
+	gateway.SessionStore
+	manager    *shardManagerImpl
+	generation int64
+}
+
+func (s *generationSessionStore) Put(ctx context.Context, shardID int, session gateway.Session) error {
+	if s.generation != s.manager.generation.Load() {
+		return nil
+	}
+	return s.SessionStore.Put(ctx, shardID, session)
+}
+
+func (s *generationSessionStore) Delete(ctx context.Context, shardID int) error {
+	if s.generation != s.manager.generation.Load() {
+		return nil
+	}
+	return s.SessionStore.Delete(ctx, shardID)
+}
+
 func (m *shardManagerImpl) Open(ctx context.Context) {
 	m.config.Logger.Debug("opening shards", slog.String("shard_ids", fmt.Sprint(m.config.ShardIDs)))
 	var wg sync.WaitGroup
@@ -103,13 +169,20 @@ func (m *shardManagerImpl) Open(ctx context.Context) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			if acquired, err := m.acquireShard(ctx, shardID); err != nil {
+				m.config.Logger.Error("failed to acquire shard lease", slog.Any("err", err), slog.Int("shard_id", shardID))
+				return
+			} else if !acquired {
+				m.config.Logger.Debug("shard is leased by another process", slog.Int("shard_id", shardID))
+				return
+			}
 			if err := m.config.RateLimiter.WaitBucket(ctx, shardID); err != nil {
 				m.config.Logger.Error("failed to wait shard bucket", slog.Any("err", err), slog.Int("shard_id", shardID))
 				return
 			}
 			defer m.config.RateLimiter.UnlockBucket(shardID)
 
-			shard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(m.config.ShardCount))...)
+			shard := m.newGateway(m.generation.Load(), shardID, m.config.ShardCount)
 			m.shards[shardID] = shard
 			if err := shard.Open(ctx); err != nil {
 				m.config.Logger.Error("failed to open shard", slog.Any("err", err), slog.Int("shard_id", shardID))
@@ -117,6 +190,12 @@ func (m *shardManagerImpl) Open(ctx context.Context) {
 		}()
 	}
 	wg.Wait()
+
+	if m.config.Coordinator != nil && m.leaseCancel == nil {
+		leaseCtx, cancel := context.WithCancel(context.Background())
+		m.leaseCancel = cancel
+		go m.renewLeases(leaseCtx)
+	}
 }
 
 func (m *shardManagerImpl) Close(ctx context.Context) {
@@ -125,6 +204,10 @@ func (m *shardManagerImpl) Close(ctx context.Context) {
 
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
+	if m.leaseCancel != nil {
+		m.leaseCancel()
+		m.leaseCancel = nil
+	}
 	for shardID := range m.shards {
 		shard := m.shards[shardID]
 		delete(m.shards, shardID)
@@ -132,6 +215,7 @@ func (m *shardManagerImpl) Close(ctx context.Context) {
 		go func() {
 			defer wg.Done()
 			shard.Close(ctx)
+			m.releaseShard(shard.ShardID())
 		}()
 	}
 	wg.Wait()
@@ -144,11 +228,16 @@ func (m *shardManagerImpl) OpenShard(ctx context.Context, shardID int) error {
 func (m *shardManagerImpl) openShard(ctx context.Context, shardID int, shardCount int) error {
 	m.config.Logger.Debug("opening shard", slog.Int("shard_id", shardID))
 
+	if acquired, err := m.acquireShard(ctx, shardID); err != nil {
+		return fmt.Errorf("failed to acquire shard lease: %w", err)
+	} else if !acquired {
+		return ErrShardLeased
+	}
 	if err := m.config.RateLimiter.WaitBucket(ctx, shardID); err != nil {
 		return err
 	}
 	defer m.config.RateLimiter.UnlockBucket(shardID)
-	shard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(shardCount))...)
+	shard := m.newGateway(m.generation.Load(), shardID, shardCount)
 
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
@@ -165,9 +254,130 @@ func (m *shardManagerImpl) CloseShard(ctx context.Context, shardID int) {
 	if ok {
 		shard.Close(ctx)
 		delete(m.shards, shardID)
+		m.releaseShard(shardID)
 	}
 }
 
+// acquireShard acquires or renews the lease of the given shard. It always succeeds without a Coordinator.
+// It returns false without an error if the lease is held by another process.
+func (m *shardManagerImpl) acquireShard(ctx context.Context, shardID int) (bool, error) {
+	if m.config.Coordinator == nil {
+		return true, nil
+	}
+	now := time.Now()
+	acquired, err := m.config.Coordinator.AcquireShard(ctx, shardID, m.config.CoordinatorOwner, m.config.ShardLeaseTTL)
+	if err != nil || !acquired {
+		return false, err
+	}
+	m.leasesMu.Lock()
+	m.leases[shardID] = now
+	m.leasesMu.Unlock()
+	return true, nil
+}
+
+// leaseExpired reports whether the lease of the given shard was not renewed within the ShardLeaseTTL.
+func (m *shardManagerImpl) leaseExpired(shardID int) bool {
+	m.leasesMu.Lock()
+	defer m.leasesMu.Unlock()
+	renewed, ok := m.leases[shardID]
+	return !ok || time.Since(renewed) >= m.config.ShardLeaseTTL
+}
+
+// releaseShard releases the lease of the given shard if a Coordinator is configured.
+func (m *shardManagerImpl) releaseShard(shardID int) {
+	if m.config.Coordinator == nil {
+		return
+	}
+	m.leasesMu.Lock()
+	delete(m.leases, shardID)
+	m.leasesMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.config.Coordinator.ReleaseShard(ctx, shardID, m.config.CoordinatorOwner); err != nil && !errors.Is(err, ErrLeaseNotHeld) {
+		m.config.Logger.Error("failed to release shard lease", slog.Any("err", err), slog.Int("shard_id", shardID))
+	}
+}
+
+// renewLeases periodically renews the leases of all open shards and picks up configured shards which are not leased by any process.
+func (m *shardManagerImpl) renewLeases(ctx context.Context) {
+	ticker := time.NewTicker(m.config.ShardLeaseTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.syncLeases(ctx)
+		}
+	}
+}
+
+func (m *shardManagerImpl) syncLeases(ctx context.Context) {
+	m.shardsMu.Lock()
+	shardIDs := make([]int, 0, len(m.config.ShardIDs))
+	for shardID := range m.config.ShardIDs {
+		if _, ok := m.opening[shardID]; !ok {
+			shardIDs = append(shardIDs, shardID)
+		}
+	}
+	m.shardsMu.Unlock()
+
+	for _, shardID := range shardIDs {
+		shard := m.Shard(shardID)
+		if shard == nil {
+			m.openLeasedShard(ctx, shardID)
+			continue
+		}
+		acquired, err := m.acquireShard(ctx, shardID)
+		if acquired {
+			continue
+		}
+		if err != nil {
+			if !m.leaseExpired(shardID) {
+				// the coordinator might only be unavailable for a moment, keep the shard running until the lease could have been taken over
+				m.config.Logger.Error("failed to renew shard lease", slog.Any("err", err), slog.Int("shard_id", shardID))
+				continue
+			}
+			m.config.Logger.Error("failed to renew shard lease before it expired, closing shard", slog.Any("err", err), slog.Int("shard_id", shardID))
+		} else {
+			m.config.Logger.Warn("lost shard lease, closing shard", slog.Int("shard_id", shardID))
+		}
+
+		// another process might take over the shard, close it without invalidating the session, so it can be resumed by the new owner
+		m.shardsMu.Lock()
+		delete(m.shards, shardID)
+		m.shardsMu.Unlock()
+		shard.CloseWithCode(ctx, websocket.CloseServiceRestart, "lost shard lease")
+	}
+}
+
+// openLeasedShard opens the given shard in the background if its lease can be acquired.
+func (m *shardManagerImpl) openLeasedShard(ctx context.Context, shardID int) {
+	if acquired, err := m.acquireShard(ctx, shardID); err != nil {
+		m.config.Logger.Error("failed to acquire shard lease", slog.Any("err", err), slog.Int("shard_id", shardID))
+		return
+	} else if !acquired {
+		return
+	}
+
+	m.shardsMu.Lock()
+	m.opening[shardID] = struct{}{}
+	m.shardsMu.Unlock()
+
+	go func() {
+		defer func() {
+			m.shardsMu.Lock()
+			delete(m.opening, shardID)
+			m.shardsMu.Unlock()
+		}()
+		m.config.Logger.Debug("acquired unowned shard", slog.Int("shard_id", shardID))
+		if err := m.openShard(ctx, shardID, m.config.ShardCount); err != nil {
+			m.config.Logger.Error("failed to open shard", slog.Any("err", err), slog.Int("shard_id", shardID))
+		}
+	}()
+}
+
 func (m *shardManagerImpl) ShardByGuildID(guildId snowflake.ID) gateway.Gateway {
 	shardCount := m.config.ShardCount
 	var shard gateway.Gateway
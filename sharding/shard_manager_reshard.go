@@ -0,0 +1,426 @@
+package sharding
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/gorilla/websocket"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+var (
+	// ErrReshardInProgress is returned by ShardManager.Reshard when another reshard is still running.
+	ErrReshardInProgress = errors.New("reshard already in progress")
+
+	// ErrReshardUnsupported is returned by ShardManager.Reshard when the ShardManager doesn't own all shards.
+	// Which shards a process owns under a new shard count can't be decided by one process alone.
+	ErrReshardUnsupported = errors.New("reshard requires a ShardManager which owns all shards without a Coordinator")
+)
+
+const (
+	// reshardDedupeWindow is how long events of the new generation are still deduplicated against the events of the old generation after the swap.
+	// The new shards can receive events after the swap which the old shards already dispatched before it.
+	reshardDedupeWindow = 30 * time.Second
+
+	// maxReshardSeen limits the fingerprints remembered during a reshard. Once reached, events might be dispatched twice instead of growing the memory.
+	maxReshardSeen = 100_000
+)
+
+// eventHandler returns the gateway.EventHandlerFunc for gateways of the given generation.
+func (m *shardManagerImpl) eventHandler(generation int64) gateway.EventHandlerFunc {
+	return func(eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+		if rs := m.reshard.Load(); rs != nil {
+			rs.handleEvent(generation, eventType, sequenceNumber, shardID, event)
+			return
+		}
+		if generation != m.generation.Load() {
+			// the gateway was replaced by a reshard and is about to be closed
+			return
+		}
+		m.eventHandlerFunc(eventType, sequenceNumber, shardID, event)
+	}
+}
+
+func (m *shardManagerImpl) Reshard(ctx context.Context, shardCount int) error {
+	if !m.reshardMu.TryLock() {
+		return ErrReshardInProgress
+	}
+	defer m.reshardMu.Unlock()
+
+	if !m.ownsAllShards() {
+		return ErrReshardUnsupported
+	}
+
+	oldGeneration := m.generation.Load()
+	newGeneration := oldGeneration + 1
+	m.config.Logger.Debug("resharding", slog.Int("shard_count", shardCount), slog.Int64("generation", newGeneration))
+
+	rs := &reshardState{
+		eventHandlerFunc: m.eventHandlerFunc,
+		oldGeneration:    oldGeneration,
+		newGeneration:    newGeneration,
+		shardCount:       shardCount,
+		seen:             map[uint64]int{},
+		connected:        map[int]struct{}{},
+		pendingGuilds:    map[int]map[snowflake.ID]struct{}{},
+		readyShards:      map[int]struct{}{},
+		ready:            make(chan struct{}),
+	}
+	m.reshard.Store(rs)
+
+	newShards := make(map[int]gateway.Gateway, shardCount)
+	abort := func(err error) error {
+		m.reshard.Store(nil)
+		for _, shard := range newShards {
+			// don't close normally, as this would invalidate the session of the old shard with the same id in the gateway.SessionStore
+			shard.CloseWithCode(context.Background(), websocket.CloseServiceRestart, "reshard aborted")
+		}
+		return err
+	}
+
+	for shardID := 0; shardID < shardCount; shardID++ {
+		if err := m.config.RateLimiter.WaitBucket(ctx, shardID); err != nil {
+			return abort(fmt.Errorf("failed to reshard: %w", err))
+		}
+		shard := m.newGateway(newGeneration, shardID, shardCount)
+		newShards[shardID] = shard
+		err := shard.Open(ctx)
+		m.config.RateLimiter.UnlockBucket(shardID)
+		if err != nil {
+			return abort(fmt.Errorf("failed to reshard: failed to open shard %d: %w", shardID, err))
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return abort(ctx.Err())
+	case <-rs.ready:
+	}
+
+	m.shardsMu.Lock()
+	oldShards := m.shards
+	m.shards = newShards
+	m.config.ShardCount = shardCount
+	m.config.ShardIDs = make(map[int]struct{}, shardCount)
+	for shardID := range newShards {
+		m.config.ShardIDs[shardID] = struct{}{}
+	}
+	m.shardsMu.Unlock()
+
+	rs.swap(func() {
+		m.generation.Store(newGeneration)
+	})
+	time.AfterFunc(reshardDedupeWindow, func() {
+		m.reshard.CompareAndSwap(rs, nil)
+	})
+	m.config.Logger.Debug("swapped to new shards", slog.Int("shard_count", shardCount), slog.Int64("generation", newGeneration))
+
+	var wg sync.WaitGroup
+	for _, shard := range oldShards {
+		wg.Add(1)
+		go func(shard gateway.Gateway) {
+			defer wg.Done()
+			// don't close normally, as this would remove the session of the new shard with the same id from the gateway.SessionStore
+			shard.CloseWithCode(ctx, websocket.CloseServiceRestart, "resharded")
+		}(shard)
+	}
+	wg.Wait()
+	return nil
+}
+
+// ownsAllShards reports whether the ShardManager manages every shard of the current shard count by itself.
+func (m *shardManagerImpl) ownsAllShards() bool {
+	if m.config.Coordinator != nil {
+		return false
+	}
+	m.shardsMu.Lock()
+	defer m.shardsMu.Unlock()
+	for shardID := 0; shardID < m.config.ShardCount; shardID++ {
+		if _, ok := m.config.ShardIDs[shardID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// reshardState buffers & deduplicates events while two shard generations are connected at the same time.
+//
+// Events of the old generation are dispatched right away. Their fingerprints are only remembered once the new shard which receives the same event is connected,
+// as the new shards don't receive the events from before they connected.
+// The READY & initial GUILD_CREATE events of the new generation are dropped, as the old generation already dispatched them.
+// All other events of the new generation are buffered until all new shards are ready.
+// On swap, the buffered events which have not been dispatched by the old generation are dispatched and the old generation is muted.
+// Events the new generation receives after the swap are still deduplicated until the reshardState is discarded.
+// Events are always dispatched without holding the lock.
+type reshardState struct {
+	eventHandlerFunc gateway.EventHandlerFunc
+	oldGeneration    int64
+	newGeneration    int64
+	shardCount       int
+
+	mu            sync.Mutex
+	swapped       bool
+	draining      bool
+	seen          map[uint64]int
+	connected     map[int]struct{}
+	buffer        []bufferedEvent
+	pendingGuilds map[int]map[snowflake.ID]struct{}
+	readyShards   map[int]struct{}
+	ready         chan struct{}
+}
+
+type bufferedEvent struct {
+	eventType      gateway.EventType
+	sequenceNumber int
+	shardID        int
+	event          gateway.EventData
+	fingerprint    uint64
+	// counted is whether the fingerprint of an event of the old generation is remembered
+	counted bool
+}
+
+func (s *reshardState) handleEvent(generation int64, eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+	var (
+		fingerprint uint64
+		counted     bool
+	)
+	if eventType != gateway.EventTypeHeartbeatAck {
+		// raw events are read to find the guild id & fingerprint, so they are dispatched with a fresh payload
+		var payload []byte
+		if raw, ok := event.(gateway.EventRaw); ok {
+			payload, _ = io.ReadAll(raw.Payload)
+			raw.Payload = bytes.NewReader(payload)
+			event = raw
+		}
+		// only the events of the old generation which the new generation receives as well are fingerprinted
+		counted = generation != s.oldGeneration || s.isConnected(eventGuildID(event, payload))
+		if counted {
+			fingerprint = eventFingerprint(eventType, event, payload)
+		}
+	}
+	if s.accept(generation, bufferedEvent{
+		eventType:      eventType,
+		sequenceNumber: sequenceNumber,
+		shardID:        shardID,
+		event:          event,
+		fingerprint:    fingerprint,
+		counted:        counted,
+	}) {
+		s.eventHandlerFunc(eventType, sequenceNumber, shardID, event)
+	}
+}
+
+// accept reports whether the event should be dispatched right away.
+func (s *reshardState) accept(generation int64, e bufferedEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.swapped {
+		if generation != s.newGeneration || e.eventType == gateway.EventTypeHeartbeatAck {
+			return generation == s.newGeneration
+		}
+		if s.unsee(e.fingerprint) {
+			return false
+		}
+		if s.draining {
+			// keep the order of events while the buffer is dispatched
+			s.buffer = append(s.buffer, e)
+			return false
+		}
+		return true
+	}
+
+	switch generation {
+	case s.oldGeneration:
+		if e.counted && (len(s.seen) < maxReshardSeen || s.seen[e.fingerprint] > 0) {
+			s.seen[e.fingerprint]++
+		}
+		return true
+
+	case s.newGeneration:
+		if s.isInitialEvent(e.eventType, e.shardID, e.event) {
+			return false
+		}
+		s.buffer = append(s.buffer, e)
+	}
+	return false
+}
+
+// isConnected reports whether the new shard which receives the events of the given guild is connected. Events without a guild are received by shard 0.
+func (s *reshardState) isConnected(guildID snowflake.ID) bool {
+	shardID := 0
+	if guildID != 0 {
+		shardID = ShardIDByGuild(guildID, s.shardCount)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.connected[shardID]
+	return ok
+}
+
+// unsee reports whether the old generation dispatched an event with the given fingerprint, which was not matched yet. s.mu must be held.
+func (s *reshardState) unsee(fingerprint uint64) bool {
+	count := s.seen[fingerprint]
+	if count == 0 {
+		return false
+	}
+	if count == 1 {
+		delete(s.seen, fingerprint)
+	} else {
+		s.seen[fingerprint] = count - 1
+	}
+	return true
+}
+
+// isInitialEvent reports whether the event is part of the connection burst of a new shard and tracks when the shard is ready.
+func (s *reshardState) isInitialEvent(eventType gateway.EventType, shardID int, event gateway.EventData) bool {
+	switch e := event.(type) {
+	case gateway.EventHeartbeatAck:
+		return true
+
+	case gateway.EventRaw:
+		// raw READY & GUILD_CREATE events are always dispatched by the old generation
+		return e.EventType == gateway.EventTypeReady || e.EventType == gateway.EventTypeGuildCreate
+
+	case gateway.EventReady:
+		s.connected[shardID] = struct{}{}
+		pending := make(map[snowflake.ID]struct{}, len(e.Guilds))
+		for _, guild := range e.Guilds {
+			pending[guild.ID] = struct{}{}
+		}
+		s.pendingGuilds[shardID] = pending
+		s.checkReady(shardID)
+		return true
+
+	case gateway.EventGuildCreate:
+		pending, ok := s.pendingGuilds[shardID]
+		if !ok {
+			return false
+		}
+		if _, ok = pending[e.ID]; !ok {
+			return false
+		}
+		delete(pending, e.ID)
+		s.checkReady(shardID)
+		return true
+	}
+	return eventType == gateway.EventTypeResumed
+}
+
+func (s *reshardState) checkReady(shardID int) {
+	if _, ok := s.readyShards[shardID]; ok || len(s.pendingGuilds[shardID]) > 0 {
+		return
+	}
+	s.readyShards[shardID] = struct{}{}
+	if len(s.readyShards) == s.shardCount {
+		close(s.ready)
+	}
+}
+
+// swap dispatches the buffered events of the new generation which the old generation did not dispatch and mutes the old generation.
+func (s *reshardState) swap(onSwap func()) {
+	s.mu.Lock()
+	s.swapped = true
+	s.draining = true
+	s.mu.Unlock()
+	onSwap()
+
+	for {
+		s.mu.Lock()
+		buffer := s.buffer
+		s.buffer = nil
+		if len(buffer) == 0 {
+			s.draining = false
+			s.mu.Unlock()
+			return
+		}
+		events := buffer[:0]
+		for _, e := range buffer {
+			if s.unsee(e.fingerprint) {
+				continue
+			}
+			events = append(events, e)
+		}
+		s.mu.Unlock()
+
+		for _, e := range events {
+			s.eventHandlerFunc(e.eventType, e.sequenceNumber, e.shardID, e.event)
+		}
+	}
+}
+
+// guildIDFields caches the index of the GuildID field of event types, or nil if they have none.
+var guildIDFields sync.Map
+
+var (
+	snowflakeType    = reflect.TypeOf(snowflake.ID(0))
+	snowflakePtrType = reflect.TypeOf((*snowflake.ID)(nil))
+)
+
+// eventGuildID returns the guild id of the event or 0 if it has none. The payload is only set for raw events.
+func eventGuildID(event gateway.EventData, payload []byte) snowflake.ID {
+	if _, ok := event.(gateway.EventRaw); ok {
+		var v struct {
+			GuildID snowflake.ID `json:"guild_id"`
+		}
+		_ = json.Unmarshal(payload, &v)
+		return v.GuildID
+	}
+
+	value := reflect.ValueOf(event)
+	if value.Kind() != reflect.Struct {
+		return 0
+	}
+	index, ok := guildIDFields.Load(value.Type())
+	if !ok {
+		var fieldIndex []int
+		if field, found := value.Type().FieldByName("GuildID"); found && (field.Type == snowflakeType || field.Type == snowflakePtrType) {
+			fieldIndex = field.Index
+		}
+		index, _ = guildIDFields.LoadOrStore(value.Type(), fieldIndex)
+	}
+	if index.([]int) == nil {
+		return 0
+	}
+	field, err := value.FieldByIndexErr(index.([]int))
+	if err != nil {
+		return 0
+	}
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			return 0
+		}
+		field = field.Elem()
+	}
+	return snowflake.ID(field.Uint())
+}
+
+// eventFingerprint returns a hash of the event content, which is the same for an event received by two different shards.
+// The payload is only set for raw events.
+func eventFingerprint(eventType gateway.EventType, event gateway.EventData, payload []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = io.WriteString(h, string(eventType))
+
+	if raw, ok := event.(gateway.EventRaw); ok {
+		_, _ = io.WriteString(h, string(raw.EventType))
+		_, _ = h.Write(payload)
+		return h.Sum64()
+	}
+
+	// encode directly into the hash to avoid buffering the encoded event
+	if err := json.NewEncoder(h).Encode(event); err != nil {
+		_, _ = fmt.Fprintf(h, "%#v", event)
+	}
+	return h.Sum64()
+}
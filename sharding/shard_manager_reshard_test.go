@@ -0,0 +1,118 @@
+package sharding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func TestReshardState(t *testing.T) {
+	var (
+		dispatched []gateway.EventData
+		s          *reshardState
+	)
+	s = &reshardState{
+		eventHandlerFunc: func(_ gateway.EventType, _ int, _ int, event gateway.EventData) {
+			// events are dispatched without holding the lock
+			assert.True(t, s.mu.TryLock())
+			s.mu.Unlock()
+			dispatched = append(dispatched, event)
+		},
+		oldGeneration: 0,
+		newGeneration: 1,
+		shardCount:    1,
+		seen:          map[uint64]int{},
+		connected:     map[int]struct{}{},
+		pendingGuilds: map[int]map[snowflake.ID]struct{}{},
+		readyShards:   map[int]struct{}{},
+		ready:         make(chan struct{}),
+	}
+
+	both := gateway.EventTypingStart{ChannelID: 1}
+	onlyNew := gateway.EventTypingStart{ChannelID: 2}
+	late := gateway.EventTypingStart{ChannelID: 4}
+	guildCreate := gateway.EventGuildCreate{GatewayGuild: discord.GatewayGuild{RestGuild: discord.RestGuild{Guild: discord.Guild{ID: 3}}}}
+
+	// events of the old generation from before the new shard connected are not received by the new shard
+	before := gateway.EventTypingStart{ChannelID: 5}
+	s.handleEvent(0, gateway.EventTypeTypingStart, 9, 0, before)
+	assert.Equal(t, []gateway.EventData{before}, dispatched)
+	dispatched = nil
+
+	// initial events of the new generation are dropped
+	s.handleEvent(1, gateway.EventTypeReady, 1, 0, gateway.EventReady{Guilds: []discord.UnavailableGuild{{ID: 3, Unavailable: true}}})
+	s.handleEvent(1, gateway.EventTypeGuildCreate, 2, 0, guildCreate)
+	assert.Empty(t, dispatched)
+
+	select {
+	case <-s.ready:
+	default:
+		t.Fatal("new generation should be ready")
+	}
+
+	s.handleEvent(0, gateway.EventTypeTypingStart, 10, 0, both)
+	s.handleEvent(1, gateway.EventTypeTypingStart, 3, 0, both)
+	s.handleEvent(1, gateway.EventTypeTypingStart, 4, 0, onlyNew)
+	s.handleEvent(0, gateway.EventTypeTypingStart, 12, 0, late)
+	assert.Equal(t, []gateway.EventData{both, late}, dispatched)
+
+	s.swap(func() {})
+	assert.Equal(t, []gateway.EventData{both, late, onlyNew}, dispatched)
+
+	// the old generation is muted after the swap
+	s.handleEvent(0, gateway.EventTypeTypingStart, 11, 0, onlyNew)
+	assert.Len(t, dispatched, 3)
+
+	// events the new generation receives after the swap are still deduplicated
+	s.handleEvent(1, gateway.EventTypeTypingStart, 5, 0, late)
+	assert.Len(t, dispatched, 3)
+	s.handleEvent(1, gateway.EventTypeTypingStart, 6, 0, late)
+	assert.Equal(t, []gateway.EventData{both, late, onlyNew, late}, dispatched)
+}
+
+func TestReshardState_Repeated(t *testing.T) {
+	var dispatched []gateway.EventData
+	s := &reshardState{
+		eventHandlerFunc: func(_ gateway.EventType, _ int, _ int, event gateway.EventData) {
+			dispatched = append(dispatched, event)
+		},
+		oldGeneration: 0,
+		newGeneration: 1,
+		shardCount:    2,
+		seen:          map[uint64]int{},
+		connected:     map[int]struct{}{},
+		pendingGuilds: map[int]map[snowflake.ID]struct{}{},
+		readyShards:   map[int]struct{}{},
+		ready:         make(chan struct{}),
+	}
+
+	// guild 1 << 22 belongs to shard 1 under the new shard count
+	guildID := snowflake.ID(1 << 22)
+	add := gateway.EventMessageReactionAdd{GuildID: &guildID, MessageID: 1}
+
+	s.handleEvent(1, gateway.EventTypeReady, 1, 0, gateway.EventReady{})
+	// shard 1 is not connected yet, so the first add is not remembered
+	s.handleEvent(0, gateway.EventTypeMessageReactionAdd, 10, 1, add)
+	s.handleEvent(1, gateway.EventTypeReady, 1, 1, gateway.EventReady{})
+
+	// the same reaction is added again, which both generations receive
+	s.handleEvent(0, gateway.EventTypeMessageReactionAdd, 11, 1, add)
+	s.handleEvent(1, gateway.EventTypeMessageReactionAdd, 2, 1, add)
+	s.swap(func() {})
+
+	assert.Equal(t, []gateway.EventData{add, add}, dispatched)
+	assert.Empty(t, s.seen)
+}
+
+func TestReshard_Unsupported(t *testing.T) {
+	m := &shardManagerImpl{config: Config{ShardCount: 2, ShardIDs: map[int]struct{}{0: {}}}}
+	assert.ErrorIs(t, m.Reshard(context.Background(), 4), ErrReshardUnsupported)
+
+	m = &shardManagerImpl{config: Config{ShardCount: 1, ShardIDs: map[int]struct{}{0: {}}, Coordinator: &testCoordinator{}}}
+	assert.ErrorIs(t, m.Reshard(context.Background(), 4), ErrReshardUnsupported)
+}
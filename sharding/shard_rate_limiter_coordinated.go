@@ -0,0 +1,102 @@
+package sharding
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/sasha-s/go-csync"
+)
+
+var _ RateLimiter = (*coordinatedRateLimiter)(nil)
+
+// identifyLockTTL is how long a coordinated identify bucket stays locked if the owner never unlocks it.
+const identifyLockTTL = 30 * time.Second
+
+// NewCoordinatedRateLimiter creates a new RateLimiter which shares its max_concurrency buckets with all processes using the same Coordinator.
+func NewCoordinatedRateLimiter(coordinator Coordinator, owner string, opts ...RateLimiterConfigOpt) RateLimiter {
+	config := DefaultRateLimiterConfig()
+	config.Apply(opts)
+	config.Logger = config.Logger.With(slog.String("name", "sharding_coordinated_rate_limiter"))
+
+	return &coordinatedRateLimiter{
+		coordinator: coordinator,
+		owner:       owner,
+		locks:       map[int]*csync.Mutex{},
+		config:      *config,
+	}
+}
+
+type coordinatedRateLimiter struct {
+	coordinator Coordinator
+	owner       string
+
+	// locks serialize identifies of the same bucket inside this process, as the Coordinator only knows about the owner
+	locks   map[int]*csync.Mutex
+	locksMu sync.Mutex
+
+	config RateLimiterConfig
+}
+
+func (r *coordinatedRateLimiter) getLock(key int) *csync.Mutex {
+	r.locksMu.Lock()
+	defer r.locksMu.Unlock()
+	mu, ok := r.locks[key]
+	if !ok {
+		mu = &csync.Mutex{}
+		r.locks[key] = mu
+	}
+	return mu
+}
+
+func (r *coordinatedRateLimiter) Close(_ context.Context) {}
+
+func (r *coordinatedRateLimiter) WaitBucket(ctx context.Context, shardID int) error {
+	key := ShardMaxConcurrencyKey(shardID, r.config.MaxConcurrency)
+	mu := r.getLock(key)
+	if err := mu.CLock(ctx); err != nil {
+		return err
+	}
+	if err := r.waitCoordinator(ctx, key); err != nil {
+		mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (r *coordinatedRateLimiter) waitCoordinator(ctx context.Context, key int) error {
+	for {
+		r.config.Logger.Debug("locking coordinated shard bucket", slog.Int("key", key))
+		retryAfter, err := r.coordinator.LockIdentifyBucket(ctx, key, r.owner, identifyLockTTL)
+		if err != nil {
+			return err
+		}
+		if retryAfter <= 0 {
+			return nil
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(retryAfter).After(deadline) {
+			return context.DeadlineExceeded
+		}
+		timer := time.NewTimer(retryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *coordinatedRateLimiter) UnlockBucket(shardID int) {
+	key := ShardMaxConcurrencyKey(shardID, r.config.MaxConcurrency)
+	r.config.Logger.Debug("unlocking coordinated shard bucket", slog.Int("key", key))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.coordinator.UnlockIdentifyBucket(ctx, key, r.owner); err != nil {
+		r.config.Logger.Error("failed to unlock coordinated shard bucket", slog.Any("err", err), slog.Int("key", key))
+	}
+	r.getLock(key).Unlock()
+}